@@ -6,27 +6,44 @@ import (
 	"github.com/market-place/usecase/repository"
 	elasticRepo "github.com/market-place/usecase/repository/elasticsearch"
 	mongoRepo "github.com/market-place/usecase/repository/mongodb"
+	oidcRepo "github.com/market-place/usecase/repository/oidc"
 	redisRepo "github.com/market-place/usecase/repository/redis"
+	smsSender "github.com/market-place/usecase/repository/sms"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type mongoRepoConfig struct {
-	userRepo      repository.CustomerRepository
-	adminRepo     repository.AdminRepository
-	merchantRepo  repository.MerchantRepository
-	productRepo   repository.ProductRepository
-	cartRepo      repository.CartRepository
-	orderRepo     repository.OrderRepository
-	returRepo     repository.ReturRepository
-	tBuyerRepo    repository.TBuyerRepository
-	tSellerRepo   repository.TSellerRepository
-	tRefundRepo   repository.TRefundRepository
-	rMerchantRepo repository.RMerchantRepository
-	rProductRepo  repository.RProductRepository
-	shippingRepo  repository.ShippingRepository
-	searchRepo    repository.SearchRepository
-	cityRepo      repository.CityRepository
-	ongkirRepo    repository.OngkirRepository
+	userRepo             repository.CustomerRepository
+	adminRepo            repository.AdminRepository
+	merchantRepo         repository.MerchantRepository
+	productRepo          repository.ProductRepository
+	cartRepo             repository.CartRepository
+	orderRepo            repository.OrderRepository
+	returRepo            repository.ReturRepository
+	tBuyerRepo           repository.TBuyerRepository
+	tSellerRepo          repository.TSellerRepository
+	tRefundRepo          repository.TRefundRepository
+	rMerchantRepo        repository.RMerchantRepository
+	rProductRepo         repository.RProductRepository
+	shippingRepo         repository.ShippingRepository
+	searchRepo           repository.SearchRepository
+	cityRepo             repository.CityRepository
+	ongkirRepo           repository.OngkirRepository
+	apiKeyRepo           repository.APIKeyRepository
+	auditLogRepo         repository.AuditLogRepository
+	sessionRepo          repository.SessionRepository
+	oidcProviderRepo     repository.OIDCProviderRepository
+	oidcStateRepo        repository.OIDCStateRepository
+	phoneOTPRepo         repository.PhoneOTPRepository
+	twoFactorAttemptRepo repository.TwoFactorAttemptRepository
+	smsSender            repository.SMSSender
+	guestCartRepo        repository.GuestCartRepository
+	wishlistRepo         repository.WishlistRepository
+	notificationRepo     repository.NotificationRepository
+	productImportJobRepo repository.ProductImportJobRepository
+	categoryRepo         repository.CategoryRepository
+	voucherRepo          repository.VoucherRepository
+	flashSaleRepo        repository.FlashSaleRepository
 }
 
 func NewMongoRepo(
@@ -35,22 +52,37 @@ func NewMongoRepo(
 	redis *redis.Client,
 ) RepoConfig {
 	return &mongoRepoConfig{
-		userRepo:      mongoRepo.NewCustomerRepository(db),
-		adminRepo:     mongoRepo.NewAdminRepository(db),
-		merchantRepo:  mongoRepo.NewMerchantRepository(db),
-		productRepo:   mongoRepo.NewProductRepository(db),
-		cartRepo:      mongoRepo.NewCartRepository(db),
-		orderRepo:     mongoRepo.NewOrderRepository(db),
-		returRepo:     mongoRepo.NewReturRepository(db),
-		tBuyerRepo:    mongoRepo.NewTBuyerRepository(db),
-		tSellerRepo:   mongoRepo.NewTSellerRepository(db),
-		tRefundRepo:   mongoRepo.NewTRefundRepository(db),
-		rMerchantRepo: mongoRepo.NewRMerchantRepository(db),
-		rProductRepo:  mongoRepo.NewRProductRepository(db),
-		shippingRepo:  mongoRepo.NewShippingRepository(db),
-		searchRepo:    elasticRepo.NewElasticSearchRepository(es),
-		cityRepo:      redisRepo.NewCityRepo(redis),
-		ongkirRepo:    redisRepo.NewOngkirRepo(redis),
+		userRepo:             mongoRepo.NewCustomerRepository(db),
+		adminRepo:            mongoRepo.NewAdminRepository(db),
+		merchantRepo:         mongoRepo.NewMerchantRepository(db),
+		productRepo:          mongoRepo.NewProductRepository(db),
+		cartRepo:             mongoRepo.NewCartRepository(db),
+		orderRepo:            mongoRepo.NewOrderRepository(db),
+		returRepo:            mongoRepo.NewReturRepository(db),
+		tBuyerRepo:           mongoRepo.NewTBuyerRepository(db),
+		tSellerRepo:          mongoRepo.NewTSellerRepository(db),
+		tRefundRepo:          mongoRepo.NewTRefundRepository(db),
+		rMerchantRepo:        mongoRepo.NewRMerchantRepository(db),
+		rProductRepo:         mongoRepo.NewRProductRepository(db),
+		shippingRepo:         mongoRepo.NewShippingRepository(db),
+		searchRepo:           elasticRepo.NewElasticSearchRepository(es),
+		cityRepo:             redisRepo.NewCityRepo(redis),
+		ongkirRepo:           redisRepo.NewOngkirRepo(redis),
+		apiKeyRepo:           mongoRepo.NewAPIKeyRepository(db),
+		auditLogRepo:         mongoRepo.NewAuditLogRepository(db),
+		sessionRepo:          mongoRepo.NewSessionRepository(db),
+		oidcProviderRepo:     oidcRepo.NewOIDCProviderRepo(),
+		oidcStateRepo:        redisRepo.NewOIDCStateRepo(redis),
+		phoneOTPRepo:         redisRepo.NewPhoneOTPRepo(redis),
+		twoFactorAttemptRepo: redisRepo.NewTwoFactorAttemptRepo(redis),
+		smsSender:            smsSender.NewLogSMSSender(),
+		guestCartRepo:        redisRepo.NewGuestCartRepo(redis),
+		wishlistRepo:         mongoRepo.NewWishlistRepository(db),
+		notificationRepo:     mongoRepo.NewNotificationRepository(db),
+		productImportJobRepo: mongoRepo.NewProductImportJobRepository(db),
+		categoryRepo:         mongoRepo.NewCategoryRepository(db),
+		voucherRepo:          mongoRepo.NewVoucherRepository(db),
+		flashSaleRepo:        mongoRepo.NewFlashSaleRepository(db),
 	}
 }
 
@@ -117,3 +149,63 @@ func (mr *mongoRepoConfig) GetRepoCity() repository.CityRepository {
 func (mr *mongoRepoConfig) GetRepoOngkir() repository.OngkirRepository {
 	return mr.ongkirRepo
 }
+
+func (mr *mongoRepoConfig) GetRepoAPIKey() repository.APIKeyRepository {
+	return mr.apiKeyRepo
+}
+
+func (mr *mongoRepoConfig) GetRepoAuditLog() repository.AuditLogRepository {
+	return mr.auditLogRepo
+}
+
+func (mr *mongoRepoConfig) GetRepoSession() repository.SessionRepository {
+	return mr.sessionRepo
+}
+
+func (mr *mongoRepoConfig) GetRepoOIDCProvider() repository.OIDCProviderRepository {
+	return mr.oidcProviderRepo
+}
+
+func (mr *mongoRepoConfig) GetRepoOIDCState() repository.OIDCStateRepository {
+	return mr.oidcStateRepo
+}
+
+func (mr *mongoRepoConfig) GetRepoPhoneOTP() repository.PhoneOTPRepository {
+	return mr.phoneOTPRepo
+}
+
+func (mr *mongoRepoConfig) GetRepoTwoFactorAttempt() repository.TwoFactorAttemptRepository {
+	return mr.twoFactorAttemptRepo
+}
+
+func (mr *mongoRepoConfig) GetSMSSender() repository.SMSSender {
+	return mr.smsSender
+}
+
+func (mr *mongoRepoConfig) GetRepoGuestCart() repository.GuestCartRepository {
+	return mr.guestCartRepo
+}
+
+func (mr *mongoRepoConfig) GetRepoWishlist() repository.WishlistRepository {
+	return mr.wishlistRepo
+}
+
+func (mr *mongoRepoConfig) GetRepoNotification() repository.NotificationRepository {
+	return mr.notificationRepo
+}
+
+func (mr *mongoRepoConfig) GetRepoProductImportJob() repository.ProductImportJobRepository {
+	return mr.productImportJobRepo
+}
+
+func (mr *mongoRepoConfig) GetRepoCategory() repository.CategoryRepository {
+	return mr.categoryRepo
+}
+
+func (mr *mongoRepoConfig) GetRepoVoucher() repository.VoucherRepository {
+	return mr.voucherRepo
+}
+
+func (mr *mongoRepoConfig) GetRepoFlashSale() repository.FlashSaleRepository {
+	return mr.flashSaleRepo
+}
@@ -26,6 +26,21 @@ type RepoConfig interface {
 	GetRepoSearch() repository.SearchRepository
 	GetRepoCity() repository.CityRepository
 	GetRepoOngkir() repository.OngkirRepository
+	GetRepoAPIKey() repository.APIKeyRepository
+	GetRepoAuditLog() repository.AuditLogRepository
+	GetRepoSession() repository.SessionRepository
+	GetRepoOIDCProvider() repository.OIDCProviderRepository
+	GetRepoOIDCState() repository.OIDCStateRepository
+	GetRepoPhoneOTP() repository.PhoneOTPRepository
+	GetRepoTwoFactorAttempt() repository.TwoFactorAttemptRepository
+	GetSMSSender() repository.SMSSender
+	GetRepoGuestCart() repository.GuestCartRepository
+	GetRepoWishlist() repository.WishlistRepository
+	GetRepoNotification() repository.NotificationRepository
+	GetRepoProductImportJob() repository.ProductImportJobRepository
+	GetRepoCategory() repository.CategoryRepository
+	GetRepoVoucher() repository.VoucherRepository
+	GetRepoFlashSale() repository.FlashSaleRepository
 }
 
 func NewRepoConfig(
@@ -9,6 +9,8 @@ import (
 
 const (
 	contextTimeOut = 2 * time.Second
+	//openid connect login call provider for discovery, token and signing key
+	oidcContextTimeOut = 10 * time.Second
 )
 
 type UsecaseConfig interface {
@@ -17,6 +19,14 @@ type UsecaseConfig interface {
 	GetMerchantUseCase() logic.MerchantUsecase
 	GetProductUseCase() logic.ProductUsecase
 	GetCartUseCase() logic.CartUsecase
+	GetGuestCartUsecase() logic.GuestCartUsecase
+	GetWishlistUsecase() logic.WishlistUsecase
+	GetNotificationUsecase() logic.NotificationUsecase
+	GetProductImportUsecase() logic.ProductImportUsecase
+	GetCategoryUsecase() logic.CategoryUsecase
+	GetVoucherUsecase() logic.VoucherUsecase
+	GetFlashSaleUsecase() logic.FlashSaleUsecase
+	GetBundleUsecase() logic.BundleUsecase
 	GetOrderUseCase() logic.OrderUsecase
 	GetReturUseCase() logic.ReturUseCase
 	GetTBuyerUseCase() logic.TBuyerUsecase
@@ -25,6 +35,14 @@ type UsecaseConfig interface {
 	GetRMerchantUseCase() logic.ReviewMerchantUsecase
 	GetRProductUseCase() logic.ReviewProductUsecase
 	GetAuthUsecase() logic.AuthenticationUsecase
+	GetTwoFactorUsecase() logic.TwoFactorUsecase
+	GetAPIKeyUsecase() logic.APIKeyUsecase
+	GetAuditLogUsecase() logic.AuditLogUsecase
+	GetSessionUsecase() logic.SessionUsecase
+	GetOIDCUsecase() logic.OIDCUsecase
+	GetPhoneVerificationUsecase() logic.PhoneVerificationUsecase
+	GetImpersonationUsecase() logic.ImpersonationUsecase
+	GetSuspensionUsecase() logic.SuspensionUsecase
 	GetShippingUsecase() logic.ShippingUsecase
 	GetSearchUsecase() logic.SearchUsecase
 	GetCityUsecase() logic.CityUsecase
@@ -48,6 +66,8 @@ func (l *usecaseConfig) GetCustomersUseCase() logic.CustomerUsecase {
 		l.repoConfig.GetRepoRProduct(),
 		l.repoConfig.GetRepoCart(),
 		l.repoConfig.GetRepoOrder(),
+		l.repoConfig.GetRepoSession(),
+		l.repoConfig.GetRepoAuditLog(),
 		contextTimeOut,
 	)
 }
@@ -55,6 +75,8 @@ func (l *usecaseConfig) GetCustomersUseCase() logic.CustomerUsecase {
 func (l *usecaseConfig) GetAdminsUseCase() logic.AdminUsecase {
 	return logic.NewAdminUsecase(
 		l.repoConfig.GetRepoAdmin(),
+		l.repoConfig.GetRepoSession(),
+		l.repoConfig.GetRepoAuditLog(),
 		contextTimeOut,
 	)
 }
@@ -67,6 +89,7 @@ func (l *usecaseConfig) GetMerchantUseCase() logic.MerchantUsecase {
 		l.repoConfig.GetRepoProduct(),
 		l.repoConfig.GetRepoOrder(),
 		l.repoConfig.GetRepoCart(),
+		l.repoConfig.GetRepoAuditLog(),
 		contextTimeOut,
 	)
 }
@@ -77,6 +100,9 @@ func (l *usecaseConfig) GetProductUseCase() logic.ProductUsecase {
 		l.repoConfig.GetRepoMerchant(),
 		l.repoConfig.GetRepoCart(),
 		l.repoConfig.GetRepoOrder(),
+		l.repoConfig.GetRepoAuditLog(),
+		l.repoConfig.GetRepoWishlist(),
+		l.repoConfig.GetRepoNotification(),
 		contextTimeOut,
 	)
 }
@@ -85,6 +111,81 @@ func (l *usecaseConfig) GetCartUseCase() logic.CartUsecase {
 	return logic.NewCartUsecase(
 		l.repoConfig.GetRepoCart(),
 		l.repoConfig.GetRepoProduct(),
+		l.repoConfig.GetRepoCustomer(),
+		l.repoConfig.GetRepoWishlist(),
+		l.GetOngkirUsecase(),
+		contextTimeOut,
+	)
+}
+
+func (l *usecaseConfig) GetGuestCartUsecase() logic.GuestCartUsecase {
+	return logic.NewGuestCartUsecase(
+		l.repoConfig.GetRepoGuestCart(),
+		l.repoConfig.GetRepoProduct(),
+		contextTimeOut,
+	)
+}
+
+func (l *usecaseConfig) GetWishlistUsecase() logic.WishlistUsecase {
+	return logic.NewWishlistUsecase(
+		l.repoConfig.GetRepoWishlist(),
+		l.repoConfig.GetRepoProduct(),
+		contextTimeOut,
+	)
+}
+
+func (l *usecaseConfig) GetProductImportUsecase() logic.ProductImportUsecase {
+	return logic.NewProductImportUsecase(
+		l.repoConfig.GetRepoProduct(),
+		l.repoConfig.GetRepoMerchant(),
+		l.repoConfig.GetRepoProductImportJob(),
+		l.repoConfig.GetRepoAuditLog(),
+		l.repoConfig.GetRepoWishlist(),
+		l.repoConfig.GetRepoNotification(),
+		contextTimeOut,
+	)
+}
+
+func (l *usecaseConfig) GetCategoryUsecase() logic.CategoryUsecase {
+	return logic.NewCategoryUsecase(
+		l.repoConfig.GetRepoCategory(),
+		l.repoConfig.GetRepoProduct(),
+		l.repoConfig.GetRepoAuditLog(),
+		contextTimeOut,
+	)
+}
+
+func (l *usecaseConfig) GetVoucherUsecase() logic.VoucherUsecase {
+	return logic.NewVoucherUsecase(
+		l.repoConfig.GetRepoVoucher(),
+		l.repoConfig.GetRepoAuditLog(),
+		contextTimeOut,
+	)
+}
+
+func (l *usecaseConfig) GetFlashSaleUsecase() logic.FlashSaleUsecase {
+	return logic.NewFlashSaleUsecase(
+		l.repoConfig.GetRepoFlashSale(),
+		l.repoConfig.GetRepoProduct(),
+		l.repoConfig.GetRepoAuditLog(),
+		contextTimeOut,
+	)
+}
+
+func (l *usecaseConfig) GetBundleUsecase() logic.BundleUsecase {
+	return logic.NewBundleUsecase(
+		l.repoConfig.GetRepoProduct(),
+		l.repoConfig.GetRepoMerchant(),
+		l.repoConfig.GetRepoAuditLog(),
+		l.repoConfig.GetRepoWishlist(),
+		l.repoConfig.GetRepoNotification(),
+		contextTimeOut,
+	)
+}
+
+func (l *usecaseConfig) GetNotificationUsecase() logic.NotificationUsecase {
+	return logic.NewNotificationUsecase(
+		l.repoConfig.GetRepoNotification(),
 		contextTimeOut,
 	)
 }
@@ -97,6 +198,11 @@ func (l *usecaseConfig) GetOrderUseCase() logic.OrderUsecase {
 		l.repoConfig.GetRepoMerchant(),
 		l.repoConfig.GetRepoCart(),
 		l.repoConfig.GetRepoTBuyer(),
+		l.repoConfig.GetRepoVoucher(),
+		l.repoConfig.GetRepoFlashSale(),
+		l.repoConfig.GetRepoAuditLog(),
+		l.repoConfig.GetRepoWishlist(),
+		l.repoConfig.GetRepoNotification(),
 		contextTimeOut,
 	)
 }
@@ -113,6 +219,7 @@ func (l *usecaseConfig) GetTBuyerUseCase() logic.TBuyerUsecase {
 	return logic.NewTBuyerUsecase(
 		l.repoConfig.GetRepoTBuyer(),
 		l.repoConfig.GetRepoOrder(),
+		l.repoConfig.GetRepoAuditLog(),
 		contextTimeOut,
 	)
 }
@@ -158,10 +265,91 @@ func (l *usecaseConfig) GetRProductUseCase() logic.ReviewProductUsecase {
 func (l *usecaseConfig) GetAuthUsecase() logic.AuthenticationUsecase {
 	return logic.NewAuthenticationUseCase(
 		l.repoConfig.GetRepoCustomer(),
+		l.repoConfig.GetRepoMerchant(),
 		l.repoConfig.GetRepoAdmin(),
 		l.repoConfig.GetRepoProduct(),
 		l.repoConfig.GetRepoTBuyer(),
 		l.repoConfig.GetRepoOrder(),
+		l.repoConfig.GetRepoAPIKey(),
+		l.repoConfig.GetRepoSession(),
+		l.repoConfig.GetRepoCart(),
+		l.repoConfig.GetRepoGuestCart(),
+		l.repoConfig.GetRepoTwoFactorAttempt(),
+		contextTimeOut,
+	)
+}
+
+func (l *usecaseConfig) GetTwoFactorUsecase() logic.TwoFactorUsecase {
+	return logic.NewTwoFactorUsecase(
+		l.repoConfig.GetRepoCustomer(),
+		l.repoConfig.GetRepoTwoFactorAttempt(),
+		contextTimeOut,
+	)
+}
+
+func (l *usecaseConfig) GetAPIKeyUsecase() logic.APIKeyUsecase {
+	return logic.NewAPIKeyUsecase(
+		l.repoConfig.GetRepoAPIKey(),
+		l.repoConfig.GetRepoMerchant(),
+		l.repoConfig.GetRepoAuditLog(),
+		contextTimeOut,
+	)
+}
+
+func (l *usecaseConfig) GetAuditLogUsecase() logic.AuditLogUsecase {
+	return logic.NewAuditLogUsecase(
+		l.repoConfig.GetRepoAuditLog(),
+		contextTimeOut,
+	)
+}
+
+func (l *usecaseConfig) GetSessionUsecase() logic.SessionUsecase {
+	return logic.NewSessionUsecase(
+		l.repoConfig.GetRepoSession(),
+		contextTimeOut,
+	)
+}
+
+func (l *usecaseConfig) GetOIDCUsecase() logic.OIDCUsecase {
+	return logic.NewOIDCUsecase(
+		l.repoConfig.GetRepoOIDCProvider(),
+		l.repoConfig.GetRepoOIDCState(),
+		l.repoConfig.GetRepoCustomer(),
+		l.repoConfig.GetRepoCart(),
+		l.repoConfig.GetRepoGuestCart(),
+		l.repoConfig.GetRepoProduct(),
+		l.repoConfig.GetRepoSession(),
+		l.repoConfig.GetRepoAuditLog(),
+		oidcContextTimeOut,
+	)
+}
+
+func (l *usecaseConfig) GetPhoneVerificationUsecase() logic.PhoneVerificationUsecase {
+	return logic.NewPhoneVerificationUsecase(
+		l.repoConfig.GetRepoCustomer(),
+		l.repoConfig.GetRepoPhoneOTP(),
+		l.repoConfig.GetSMSSender(),
+		contextTimeOut,
+	)
+}
+
+func (l *usecaseConfig) GetImpersonationUsecase() logic.ImpersonationUsecase {
+	return logic.NewImpersonationUsecase(
+		l.repoConfig.GetRepoCustomer(),
+		l.repoConfig.GetRepoSession(),
+		l.repoConfig.GetRepoAuditLog(),
+		contextTimeOut,
+	)
+}
+
+func (l *usecaseConfig) GetSuspensionUsecase() logic.SuspensionUsecase {
+	return logic.NewSuspensionUsecase(
+		l.repoConfig.GetRepoCustomer(),
+		l.repoConfig.GetRepoMerchant(),
+		l.repoConfig.GetRepoProduct(),
+		l.repoConfig.GetRepoSession(),
+		l.repoConfig.GetSMSSender(),
+		l.repoConfig.GetRepoAuditLog(),
 		contextTimeOut,
 	)
 }
@@ -169,6 +357,7 @@ func (l *usecaseConfig) GetAuthUsecase() logic.AuthenticationUsecase {
 func (l *usecaseConfig) GetShippingUsecase() logic.ShippingUsecase {
 	return logic.NewShippingUsecase(
 		l.repoConfig.GetRepoShipping(),
+		l.repoConfig.GetRepoAuditLog(),
 		contextTimeOut,
 	)
 }
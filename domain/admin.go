@@ -15,6 +15,8 @@ type Admin struct {
 	Phone     string    `json:"phone" bson:"phone" validate:"required,phone"`
 	Avatar    string    `json:"avatar" bson:"avatar" validate:"required"`
 	Gender    string    `json:"gender" bson:"gender" validate:"required,gender"`
+	Role      string    `json:"role" bson:"role" validate:"required,admin_role"`
+	TwoFactor TwoFactor `json:"two_factor" bson:"two_factor"`
 	Confrimed bool      `json:"confrimed" bson:"confrimed"`
 	CreatedAt time.Time `json:"created_at" bson:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
@@ -23,4 +25,5 @@ type Admin struct {
 type AdminSearchOptions struct {
 	Name  string `json:"name"`
 	Email string `json:"email"`
+	Role  string `json:"role"`
 }
@@ -0,0 +1,85 @@
+package domain
+
+const (
+	ADMIN_ROLE_SUPERADMIN = "SUPERADMIN"
+	ADMIN_ROLE_FINANCE    = "FINANCE"
+	ADMIN_ROLE_MODERATOR  = "MODERATOR"
+	ADMIN_ROLE_SUPPORT    = "SUPPORT"
+)
+
+const (
+	PERMISSION_MANAGE_ADMIN       = "MANAGE_ADMIN"
+	PERMISSION_MANAGE_SHIPPING    = "MANAGE_SHIPPING"
+	PERMISSION_READ_CUSTOMER      = "READ_CUSTOMER"
+	PERMISSION_READ_TRANSACTION   = "READ_TRANSACTION"
+	PERMISSION_MANAGE_TRANSACTION = "MANAGE_TRANSACTION"
+	//reserved for refund transaction, refund has no api yet so nothing checks it
+	PERMISSION_MANAGE_REFUND  = "MANAGE_REFUND"
+	PERMISSION_READ_AUDIT_LOG = "READ_AUDIT_LOG"
+	//read only access as customer, see Credential.ImpersonatorID
+	PERMISSION_IMPERSONATE_CUSTOMER = "IMPERSONATE_CUSTOMER"
+	//suspend and ban customer or merchant
+	PERMISSION_MANAGE_SUSPENSION = "MANAGE_SUSPENSION"
+	//take down and lift take down of merchant product
+	PERMISSION_MANAGE_PRODUCT = "MANAGE_PRODUCT"
+	//create, update and delete product category tree
+	PERMISSION_MANAGE_CATEGORY = "MANAGE_CATEGORY"
+	//create, update and delete platform voucher
+	PERMISSION_MANAGE_VOUCHER = "MANAGE_VOUCHER"
+)
+
+// AdminRolePermissions maps every registered admin role to its permission set.
+// Money moving permissions (transaction and refund) are given to finance and superadmin only.
+// Audit log is only readable by superadmin.
+// Customer impersonation is given to support and superadmin.
+// Suspension, product take down and category tree are managed by moderator and superadmin.
+// Platform vouchers spend platform money, they are managed by finance and superadmin.
+var AdminRolePermissions = map[string][]string{
+	ADMIN_ROLE_SUPERADMIN: []string{
+		PERMISSION_MANAGE_ADMIN,
+		PERMISSION_MANAGE_SHIPPING,
+		PERMISSION_READ_CUSTOMER,
+		PERMISSION_READ_TRANSACTION,
+		PERMISSION_MANAGE_TRANSACTION,
+		PERMISSION_MANAGE_REFUND,
+		PERMISSION_READ_AUDIT_LOG,
+		PERMISSION_IMPERSONATE_CUSTOMER,
+		PERMISSION_MANAGE_SUSPENSION,
+		PERMISSION_MANAGE_PRODUCT,
+		PERMISSION_MANAGE_CATEGORY,
+		PERMISSION_MANAGE_VOUCHER,
+	},
+	ADMIN_ROLE_FINANCE: []string{
+		PERMISSION_READ_CUSTOMER,
+		PERMISSION_READ_TRANSACTION,
+		PERMISSION_MANAGE_TRANSACTION,
+		PERMISSION_MANAGE_REFUND,
+		PERMISSION_MANAGE_VOUCHER,
+	},
+	ADMIN_ROLE_MODERATOR: []string{
+		PERMISSION_MANAGE_SHIPPING,
+		PERMISSION_READ_CUSTOMER,
+		PERMISSION_MANAGE_SUSPENSION,
+		PERMISSION_MANAGE_PRODUCT,
+		PERMISSION_MANAGE_CATEGORY,
+	},
+	ADMIN_ROLE_SUPPORT: []string{
+		PERMISSION_READ_CUSTOMER,
+		PERMISSION_READ_TRANSACTION,
+		PERMISSION_IMPERSONATE_CUSTOMER,
+	},
+}
+
+func IsRegisteredAdminRole(role string) bool {
+	_, ok := AdminRolePermissions[role]
+	return ok
+}
+
+func HasPermission(role, permission string) bool {
+	for _, rolePermission := range AdminRolePermissions[role] {
+		if rolePermission == permission {
+			return true
+		}
+	}
+	return false
+}
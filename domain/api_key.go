@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+var LOGIN_AS_API_KEY = "API_KEY"
+
+const (
+	API_KEY_SCOPE_PRODUCTS_WRITE = "products:write"
+	API_KEY_SCOPE_ORDERS_READ    = "orders:read"
+	API_KEY_SCOPE_ORDERS_SHIP    = "orders:ship"
+)
+
+var RegisteredAPIKeyScopes = map[string]bool{
+	API_KEY_SCOPE_PRODUCTS_WRITE: true,
+	API_KEY_SCOPE_ORDERS_READ:    true,
+	API_KEY_SCOPE_ORDERS_SHIP:    true,
+}
+
+// merchant scoped key for programmatic integration, only hash of the key is saved
+type APIKey struct {
+	ID         string    `json:"_id" bson:"_id" validate:"required"`
+	MerchantID string    `json:"merchant_id" bson:"merchant_id" validate:"required"`
+	Name       string    `json:"name" bson:"name" validate:"required,max=100"`
+	Prefix     string    `json:"prefix" bson:"prefix" validate:"required"`
+	HashedKey  string    `json:"-" bson:"hashed_key" validate:"required"`
+	Scopes     []string  `json:"scopes" bson:"scopes" validate:"min=1,api_key_scopes"`
+	LastUsedAt time.Time `json:"last_used_at" bson:"last_used_at"`
+	Revoked    bool      `json:"revoked" bson:"revoked"`
+	RevokedAt  time.Time `json:"revoked_at" bson:"revoked_at"`
+	CreatedAt  time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+func (a *APIKey) HasScope(scope string) bool {
+	for _, keyScope := range a.Scopes {
+		if keyScope == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type APIKeySearchOptions struct {
+	MerchantID string
+	HashedKey  string
+}
@@ -0,0 +1,91 @@
+package domain
+
+import "time"
+
+const (
+	AUDIT_ACTION_ADMIN_CREATE             = "ADMIN_CREATE"
+	AUDIT_ACTION_ADMIN_UPDATE_ROLE        = "ADMIN_UPDATE_ROLE"
+	AUDIT_ACTION_SHIPPING_CREATE          = "SHIPPING_CREATE"
+	AUDIT_ACTION_SHIPPING_UPDATE          = "SHIPPING_UPDATE"
+	AUDIT_ACTION_SHIPPING_DELETE          = "SHIPPING_DELETE"
+	AUDIT_ACTION_TRANSACTION_ACCEPT       = "TRANSACTION_ACCEPT"
+	AUDIT_ACTION_TRANSACTION_REJECT       = "TRANSACTION_REJECT"
+	AUDIT_ACTION_CUSTOMER_UPDATE          = "CUSTOMER_UPDATE"
+	AUDIT_ACTION_CUSTOMER_UPDATE_PASSWORD = "CUSTOMER_UPDATE_PASSWORD"
+	AUDIT_ACTION_CUSTOMER_ADD_BANK        = "CUSTOMER_ADD_BANK_ACCOUNT"
+	AUDIT_ACTION_CUSTOMER_UPDATE_BANK     = "CUSTOMER_UPDATE_BANK_ACCOUNT"
+	AUDIT_ACTION_CUSTOMER_LINK_IDENTITY   = "CUSTOMER_LINK_IDENTITY"
+	AUDIT_ACTION_CUSTOMER_UNLINK_IDENTITY = "CUSTOMER_UNLINK_IDENTITY"
+	AUDIT_ACTION_CUSTOMER_IMPERSONATE     = "CUSTOMER_IMPERSONATE"
+	AUDIT_ACTION_IMPERSONATION_REQUEST    = "IMPERSONATION_REQUEST"
+	AUDIT_ACTION_CUSTOMER_SUSPEND         = "CUSTOMER_SUSPEND"
+	AUDIT_ACTION_CUSTOMER_UNSUSPEND       = "CUSTOMER_UNSUSPEND"
+	AUDIT_ACTION_MERCHANT_UPDATE          = "MERCHANT_UPDATE"
+	AUDIT_ACTION_MERCHANT_ADD_SHIPPING    = "MERCHANT_ADD_SHIPPING"
+	AUDIT_ACTION_MERCHANT_REMOVE_SHIPPING = "MERCHANT_REMOVE_SHIPPING"
+	AUDIT_ACTION_MERCHANT_ADD_BANK        = "MERCHANT_ADD_BANK_ACCOUNT"
+	AUDIT_ACTION_MERCHANT_UPDATE_BANK     = "MERCHANT_UPDATE_BANK_ACCOUNT"
+	AUDIT_ACTION_MERCHANT_SUSPEND         = "MERCHANT_SUSPEND"
+	AUDIT_ACTION_MERCHANT_UNSUSPEND       = "MERCHANT_UNSUSPEND"
+	AUDIT_ACTION_PRODUCT_UPDATE           = "PRODUCT_UPDATE"
+	AUDIT_ACTION_PRODUCT_DELETE           = "PRODUCT_DELETE"
+	AUDIT_ACTION_CATEGORY_CREATE          = "CATEGORY_CREATE"
+	AUDIT_ACTION_CATEGORY_UPDATE          = "CATEGORY_UPDATE"
+	AUDIT_ACTION_CATEGORY_DELETE          = "CATEGORY_DELETE"
+	AUDIT_ACTION_PRODUCT_RESTORE          = "PRODUCT_RESTORE"
+	AUDIT_ACTION_PRODUCT_UPDATE_STATUS    = "PRODUCT_UPDATE_STATUS"
+	AUDIT_ACTION_PRODUCT_TAKE_DOWN        = "PRODUCT_TAKE_DOWN"
+	AUDIT_ACTION_PRODUCT_LIFT_TAKE_DOWN   = "PRODUCT_LIFT_TAKE_DOWN"
+	AUDIT_ACTION_PRODUCT_ADD_DISCOUNT     = "PRODUCT_ADD_DISCOUNT"
+	AUDIT_ACTION_PRODUCT_REMOVE_DISCOUNT  = "PRODUCT_REMOVE_DISCOUNT"
+	AUDIT_ACTION_ORDER_INPUT_RESI         = "ORDER_INPUT_RESI"
+	AUDIT_ACTION_ORDER_REJECT             = "ORDER_REJECT"
+	AUDIT_ACTION_API_KEY_CREATE           = "API_KEY_CREATE"
+	AUDIT_ACTION_API_KEY_REVOKE           = "API_KEY_REVOKE"
+	AUDIT_ACTION_VOUCHER_CREATE           = "VOUCHER_CREATE"
+	AUDIT_ACTION_VOUCHER_UPDATE           = "VOUCHER_UPDATE"
+	AUDIT_ACTION_VOUCHER_DELETE           = "VOUCHER_DELETE"
+	AUDIT_ACTION_FLASH_SALE_CREATE        = "FLASH_SALE_CREATE"
+	AUDIT_ACTION_FLASH_SALE_DELETE        = "FLASH_SALE_DELETE"
+)
+
+const (
+	AUDIT_TARGET_ADMIN       = "ADMIN"
+	AUDIT_TARGET_SHIPPING    = "SHIPPING"
+	AUDIT_TARGET_TRANSACTION = "TRANSACTION_BUYER"
+	AUDIT_TARGET_CUSTOMER    = "CUSTOMER"
+	AUDIT_TARGET_MERCHANT    = "MERCHANT"
+	AUDIT_TARGET_PRODUCT     = "PRODUCT"
+	AUDIT_TARGET_CATEGORY    = "CATEGORY"
+	AUDIT_TARGET_ORDER       = "ORDER"
+	AUDIT_TARGET_API_KEY     = "API_KEY"
+	AUDIT_TARGET_VOUCHER     = "VOUCHER"
+	AUDIT_TARGET_FLASH_SALE  = "FLASH_SALE"
+)
+
+// append only record of a privileged mutation, before and after only hold changed fields
+type AuditLog struct {
+	ID         string                 `json:"_id" bson:"_id"`
+	ActorID    string                 `json:"actor_id" bson:"actor_id"`
+	ActorType  string                 `json:"actor_type" bson:"actor_type"`
+	ActorRole  string                 `json:"actor_role" bson:"actor_role"`
+	MerchantID string                 `json:"merchant_id" bson:"merchant_id"`
+	Action     string                 `json:"action" bson:"action"`
+	TargetType string                 `json:"target_type" bson:"target_type"`
+	TargetID   string                 `json:"target_id" bson:"target_id"`
+	Before     map[string]interface{} `json:"before" bson:"before"`
+	After      map[string]interface{} `json:"after" bson:"after"`
+	IP         string                 `json:"ip" bson:"ip"`
+	RequestID  string                 `json:"request_id" bson:"request_id"`
+	CreatedAt  time.Time              `json:"created_at" bson:"created_at"`
+}
+
+type AuditLogSearchOptions struct {
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	RequestID  string
+	From       time.Time
+	To         time.Time
+}
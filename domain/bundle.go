@@ -0,0 +1,38 @@
+package domain
+
+// BundleItem is product of the same merchant sold inside a bundle, bundle is a product with bundle items.
+// Name and price are copied when bundle is saved for display, checkout always use current product
+type BundleItem struct {
+	ProductID string  `json:"product_id" bson:"product_id" validate:"required"`
+	SKU       string  `json:"sku" bson:"sku"`
+	Name      string  `json:"name" bson:"name"`
+	Photo     string  `json:"photo" bson:"photo"`
+	Price     float64 `json:"price" bson:"price"`
+	Quantity  int64   `json:"quantity" bson:"quantity" validate:"min=1"`
+}
+
+// IsBundle is true when product is sold as its bundle items
+func (p DenormalizationProduct) IsBundle() bool {
+	return len(p.BundleItems) != 0
+}
+
+// BundleStock is number of bundles which can be made from current stock of its products,
+// it is zero when one of its products is not available anymore
+func BundleStock(items []BundleItem, products map[string]Product) float64 {
+	stock := float64(-1)
+	for _, item := range items {
+		product, ok := products[item.ProductID]
+		if !ok || !product.IsActive() {
+			return 0
+		}
+		snapshot := product.DenormalizationData()
+		available := float64(int64(snapshot.StockOf(item.SKU)) / item.Quantity)
+		if stock == -1 || available < stock {
+			stock = available
+		}
+	}
+	if stock < 0 {
+		return 0
+	}
+	return stock
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"math"
 	"time"
 )
 
@@ -11,8 +12,12 @@ type Cart struct {
 	UpdatedAt time.Time `json:"updated_at" bson:"updated_at" validate:"required"`
 }
 
+// Item hold product snapshot when it is added to cart, Updated and Message are set when
+// product changed after that and stay until buyer acknowledge the change, checkout is blocked meanwhile.
+// SKU is required for product with variants, same product with different sku is different item
 type Item struct {
 	Product  DenormalizationProduct  `json:"product" bson:"product" validate:"required"`
+	SKU      string                  `json:"sku" bson:"sku"`
 	Merchant DenormalizationMerchant `json:"merchant" bson:"merchant" validate:"required"`
 	Quantity int64                   `json:"quantity" bson:"quantity" validate:"min=1"`
 	Sizes    []string                `json:"sizes" bson:"sizes"`
@@ -20,6 +25,20 @@ type Item struct {
 	Note     string                  `json:"note" bson:"note"`
 	Updated  bool                    `json:"updated" bson:"updated"`
 	Message  string                  `json:"message" bson:"message"`
+	//prices of chosen sku are computed when cart summary is read, they are not stored
+	OriginalPrice   float64 `json:"original_price,omitempty" bson:"-"`
+	Price           float64 `json:"price,omitempty" bson:"-"`
+	DiscountPercent float64 `json:"discount_percent,omitempty" bson:"-"`
+}
+
+// SyncPrice set original price, price after running discount or reached wholesale tier and discount percent of chosen sku
+func (i *Item) SyncPrice() {
+	i.OriginalPrice = i.Product.OriginalPriceOf(i.SKU)
+	i.Price = i.Product.PriceFor(i.SKU, i.Quantity)
+	i.DiscountPercent = 0
+	if i.OriginalPrice > 0 {
+		i.DiscountPercent = math.Round((i.OriginalPrice - i.Price) / i.OriginalPrice * 100)
+	}
 }
 
 type CartSearchOptions struct {
@@ -28,3 +47,26 @@ type CartSearchOptions struct {
 	//cart's items have item with field merchant.id equals to search merchantID keyword
 	MerchantID string
 }
+
+// CartSummary group cart items by merchant as they are checked out, one order per merchant.
+// ShippingEstimate use the cheapest quote of every merchant
+type CartSummary struct {
+	CartID           string              `json:"cart_id"`
+	Address          Address             `json:"address"`
+	Merchants        []CartMerchantGroup `json:"merchants"`
+	Subtotal         float64             `json:"subtotal"`
+	ShippingEstimate float64             `json:"shipping_estimate"`
+	GrandTotal       float64             `json:"grand_total"`
+	//some item is changed and not acknowledged yet, checkout is blocked
+	HasChanges bool `json:"has_changes"`
+}
+
+type CartMerchantGroup struct {
+	Merchant         DenormalizationMerchant `json:"merchant"`
+	Items            []Item                  `json:"items"`
+	Subtotal         float64                 `json:"subtotal"`
+	Weight           float64                 `json:"weight"`
+	Shippings        []ShippingProvider      `json:"shippings"`
+	Ongkirs          []Ongkir                `json:"ongkirs"`
+	ShippingEstimate float64                 `json:"shipping_estimate"`
+}
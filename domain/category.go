@@ -1,7 +1,126 @@
 package domain
 
+import (
+	"sort"
+	"time"
+)
+
 type Category struct {
 	Top       string `json:"top" bson:"top" validate:"required"`
 	SecondSub string `json:"second_sub" bson:"second_sub" validate:"required"`
 	ThirdSub  string `json:"third_sub" bson:"third_sub" validate:"required"`
 }
+
+const (
+	CATEGORY_LEVEL_TOP        = 1
+	CATEGORY_LEVEL_SECOND_SUB = 2
+	CATEGORY_LEVEL_THIRD_SUB  = 3
+)
+
+const (
+	ATTRIBUTE_TYPE_TEXT    = "TEXT"
+	ATTRIBUTE_TYPE_NUMBER  = "NUMBER"
+	ATTRIBUTE_TYPE_BOOLEAN = "BOOLEAN"
+	ATTRIBUTE_TYPE_OPTION  = "OPTION"
+)
+
+// CategoryAttribute is schema of product attribute, sub categories inherit attributes of their parents
+type CategoryAttribute struct {
+	Name string `json:"name" bson:"name" validate:"required"`
+	Type string `json:"type" bson:"type" validate:"oneof=TEXT NUMBER BOOLEAN OPTION"`
+	//allowed values of OPTION attribute
+	Values   []string `json:"values" bson:"values"`
+	Required bool     `json:"required" bson:"required"`
+}
+
+// CategoryNode is one node of admin managed category tree,
+// product Category is names of nodes from top level to third sub level
+type CategoryNode struct {
+	ID         string              `json:"_id" bson:"_id" validate:"required"`
+	ParentID   string              `json:"parent_id" bson:"parent_id"`
+	Level      int                 `json:"level" bson:"level" validate:"min=1,max=3"`
+	Slug       string              `json:"slug" bson:"slug" validate:"required"`
+	Name       string              `json:"name" bson:"name" validate:"required"`
+	Icon       string              `json:"icon" bson:"icon"`
+	Order      int                 `json:"order" bson:"order"`
+	Attributes []CategoryAttribute `json:"attributes" bson:"attributes" validate:"unique_attributes,dive"`
+	Children   []CategoryNode      `json:"children" bson:"-"`
+	CreatedAt  time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at" bson:"updated_at"`
+}
+
+type CategorySearchOptions struct {
+	//category's parent id equals to search parent id, top level category has empty parent id
+	ParentID *string
+	//category's slug equals to search slug
+	Slug string
+}
+
+// BuildCategoryTree nest nodes under their parent, siblings are sorted by order then name
+func BuildCategoryTree(nodes []CategoryNode) []CategoryNode {
+	childrenOf := map[string][]CategoryNode{}
+	for _, node := range nodes {
+		childrenOf[node.ParentID] = append(childrenOf[node.ParentID], node)
+	}
+
+	var build func(parentID string) []CategoryNode
+	build = func(parentID string) []CategoryNode {
+		children := childrenOf[parentID]
+		sort.SliceStable(children, func(i, j int) bool {
+			if children[i].Order != children[j].Order {
+				return children[i].Order < children[j].Order
+			}
+			return children[i].Name < children[j].Name
+		})
+		tree := []CategoryNode{}
+		for _, child := range children {
+			child.Children = build(child.ID)
+			tree = append(tree, child)
+		}
+		return tree
+	}
+	return build("")
+}
+
+// CategoryPath is product Category of node, levels below node are empty
+func CategoryPath(nodes []CategoryNode, nodeID string) Category {
+	byID := map[string]CategoryNode{}
+	for _, node := range nodes {
+		byID[node.ID] = node
+	}
+
+	var path Category
+	node, ok := byID[nodeID]
+	for ok {
+		switch node.Level {
+		case CATEGORY_LEVEL_TOP:
+			path.Top = node.Name
+		case CATEGORY_LEVEL_SECOND_SUB:
+			path.SecondSub = node.Name
+		case CATEGORY_LEVEL_THIRD_SUB:
+			path.ThirdSub = node.Name
+		}
+		node, ok = byID[node.ParentID]
+	}
+	return path
+}
+
+// CategoryAttributes is attributes of node and all of its parents, from top level to node
+func CategoryAttributes(nodes []CategoryNode, nodeID string) []CategoryAttribute {
+	byID := map[string]CategoryNode{}
+	for _, node := range nodes {
+		byID[node.ID] = node
+	}
+
+	path := []CategoryNode{}
+	node, ok := byID[nodeID]
+	for ok {
+		path = append([]CategoryNode{node}, path...)
+		node, ok = byID[node.ParentID]
+	}
+	attributes := []CategoryAttribute{}
+	for _, node := range path {
+		attributes = append(attributes, node.Attributes...)
+	}
+	return attributes
+}
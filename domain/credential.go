@@ -10,6 +10,9 @@ var APPLICATION_NAME = "Market-Place"
 var LOGIN_AS_CUSTOMER = "CUSTOMER"
 var LOGIN_AS_ADMIN = "ADMIN"
 
+// impersonation credential expire after this duration, it can not be extended
+var IMPERSONATION_DURATION = 30 * time.Minute
+
 type Credential struct {
 	jwt.StandardClaims
 	UserID     string `json:"user_id"`
@@ -17,9 +20,14 @@ type Credential struct {
 	Email      string `json:"email"`
 	MerchantID string `json:"merchant_id"`
 	LoginType  string `json:"login_type"`
+	Role       string `json:"role"`
+	//filled only when request authenticated with merchant api key
+	Scopes []string `json:"scopes"`
+	//filled with admin id when admin impersonate customer, the credential is read only
+	ImpersonatorID string `json:"impersonator_id,omitempty"`
 }
 
-func NewCredential(userID, cartID, merchantId, email, loginType string) Credential {
+func NewCredential(userID, cartID, merchantId, email, loginType, role string) Credential {
 	return Credential{
 		jwt.StandardClaims{
 			Issuer:   APPLICATION_NAME,
@@ -30,5 +38,12 @@ func NewCredential(userID, cartID, merchantId, email, loginType string) Credenti
 		email,
 		merchantId,
 		loginType,
+		role,
+		nil,
+		"",
 	}
 }
+
+func (c *Credential) IsImpersonation() bool {
+	return c.ImpersonatorID != ""
+}
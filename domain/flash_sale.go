@@ -0,0 +1,60 @@
+package domain
+
+import "time"
+
+// FlashSale sell limited quota of a product or its variant at special price during a time slot.
+// Sold and purchases are only changed by atomic reserve and release, so quota is never oversold
+type FlashSale struct {
+	ID         string                 `json:"_id" bson:"_id" validate:"required"`
+	MerchantID string                 `json:"merchant_id" bson:"merchant_id" validate:"required"`
+	Product    DenormalizationProduct `json:"product" bson:"product" validate:"required"`
+	//empty sku is product without variants
+	SKU              string  `json:"sku" bson:"sku"`
+	Price            float64 `json:"price" bson:"price" validate:"min=1"`
+	OriginalPrice    float64 `json:"original_price" bson:"original_price"`
+	Quota            int64   `json:"quota" bson:"quota" validate:"min=1"`
+	LimitPerCustomer int64   `json:"limit_per_customer" bson:"limit_per_customer" validate:"min=1"`
+	Sold             int64   `json:"sold" bson:"sold"`
+	//quantity bought by every customer keyed by customer id
+	Purchases map[string]int64 `json:"-" bson:"purchases"`
+	StartAt   time.Time        `json:"start_at" bson:"start_at" validate:"required,ltfield=EndAt"`
+	EndAt     time.Time        `json:"end_at" bson:"end_at" validate:"required"`
+	CreatedAt time.Time        `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at" bson:"updated_at"`
+}
+
+// ProductData is flash sale price kept in its product, so product price follows the slot
+func (f *FlashSale) ProductData() ProductFlashSale {
+	return ProductFlashSale{
+		ID:      f.ID,
+		SKU:     f.SKU,
+		Price:   f.Price,
+		StartAt: f.StartAt,
+		EndAt:   f.EndAt,
+		SoldOut: f.Sold >= f.Quota,
+	}
+}
+
+type FlashSaleSearchOptions struct {
+	//flash sale's product id equals to search product id
+	ProductID string
+	//flash sale's merchant id equals to search merchant id
+	MerchantID string
+	//flash sale ends after search time, it is ongoing or upcoming
+	EndAfter time.Time
+}
+
+// ProductFlashSale is flash sale slot of product, sold out flash sale no longer change price
+type ProductFlashSale struct {
+	ID      string    `json:"_id" bson:"_id"`
+	SKU     string    `json:"sku" bson:"sku"`
+	Price   float64   `json:"price" bson:"price"`
+	StartAt time.Time `json:"start_at" bson:"start_at"`
+	EndAt   time.Time `json:"end_at" bson:"end_at"`
+	SoldOut bool      `json:"sold_out" bson:"sold_out"`
+}
+
+// IsRunning is true when flash sale is not sold out and given time is in its slot
+func (f ProductFlashSale) IsRunning(at time.Time) bool {
+	return !f.SoldOut && !at.Before(f.StartAt) && at.Before(f.EndAt)
+}
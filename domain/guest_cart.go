@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+var GUEST_CART_SUBJECT = "GUEST_CART"
+var GUEST_CART_DURATION = 7 * 24 * time.Hour
+
+// GuestCartToken identify cart of anonymous visitor, it is sent back by cookie or header.
+// Token and saved cart expire together, counted from cart creation
+type GuestCartToken struct {
+	jwt.StandardClaims
+	CartID string `json:"cart_id"`
+}
+
+func NewGuestCartToken(cart Cart) GuestCartToken {
+	return GuestCartToken{
+		jwt.StandardClaims{
+			Issuer:    APPLICATION_NAME,
+			Subject:   GUEST_CART_SUBJECT,
+			IssuedAt:  cart.CreatedAt.Unix(),
+			ExpiresAt: cart.CreatedAt.Add(GUEST_CART_DURATION).Unix(),
+		},
+		cart.ID,
+	}
+}
@@ -22,6 +22,7 @@ type Merchant struct {
 	Shippings     []ShippingProvider `json:"shippings" bson:"shippings" validate:"min=1,unique_shippings"`
 	BankAccounts  []BankAccount      `json:"bank_accounts" bson:"bank_accounts" validate:"unique_bank_accounts"`
 	LocationPoint LocationPoint      `json:"location_point" bson:"location_point"`
+	Suspension    Suspension         `json:"suspension" bson:"suspension"`
 	CreatedAt     time.Time          `json:"created_at" bson:"created_at" validate:"required"`
 	UpdatedAt     time.Time          `json:"updated_at" bson:"updated_at" validate:"required"`
 }
@@ -37,6 +38,9 @@ func (m *Merchant) DenomarlizationData() DenormalizationMerchant {
 	denom.LocationPoint = m.LocationPoint
 	denom.Rating = m.Rating
 	denom.NumReview = m.NumReview
+	if m.Suspension.Status != "" {
+		denom.SuspendedUntil = m.Suspension.Until
+	}
 	return denom
 }
 
@@ -50,6 +54,8 @@ type DenormalizationMerchant struct {
 	LocationPoint LocationPoint      `json:"location_point" bson:"location_point"`
 	Rating        float64            `json:"rating" bson:"rating"`
 	NumReview     float64            `json:"num_review" bson:"num_review"`
+	//product is hidden from search until this time, zero when merchant is not suspended
+	SuspendedUntil time.Time `json:"suspended_until" bson:"suspended_until"`
 }
 
 type MerchantSearchOptions struct {
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+const (
+	NOTIFICATION_TYPE_PRICE_DROP    = "PRICE_DROP"
+	NOTIFICATION_TYPE_BACK_IN_STOCK = "BACK_IN_STOCK"
+)
+
+// Notification is shown to customer in application, it is created by the system and only marked as read by customer
+type Notification struct {
+	ID         string    `json:"_id" bson:"_id"`
+	CustomerID string    `json:"customer_id" bson:"customer_id"`
+	Type       string    `json:"type" bson:"type"`
+	Message    string    `json:"message" bson:"message"`
+	ProductID  string    `json:"product_id" bson:"product_id"`
+	Read       bool      `json:"read" bson:"read"`
+	CreatedAt  time.Time `json:"created_at" bson:"created_at"`
+}
+
+type NotificationSearchOptions struct {
+	CustomerID string
+	Unread     bool
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+)
+
+var OIDC_AUTH_REQUEST_DURATION = 10 * time.Minute
+
+const (
+	OIDC_PURPOSE_LOGIN = "LOGIN"
+	OIDC_PURPOSE_LINK  = "LINK"
+)
+
+// external account from openid connect provider linked to customer
+type ExternalIdentity struct {
+	Provider string    `json:"provider" bson:"provider"`
+	Subject  string    `json:"subject" bson:"subject"`
+	Email    string    `json:"email" bson:"email"`
+	LinkedAt time.Time `json:"linked_at" bson:"linked_at"`
+}
+
+// authorization request waiting for provider callback, saved by state
+type OIDCAuthRequest struct {
+	State        string `json:"state"`
+	Provider     string `json:"provider"`
+	Purpose      string `json:"purpose"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+	//filled only when purpose is link
+	CustomerID string `json:"customer_id"`
+}
+
+type OIDCAuthorization struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+// claims from verified id token
+type OIDCIdentity struct {
+	Provider      string `json:"provider"`
+	Subject       string `json:"subject"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
+	Nonce         string `json:"-"`
+}
@@ -20,13 +20,33 @@ const (
 	STATUS_ORDER_SELESAI = "STATUS_ORDER_SELESAI"
 )
 
+// STATUS_ORDER_CANCELLABLE are status of order which is not shipped yet, so it still could be canceled
+var STATUS_ORDER_CANCELLABLE = []string{
+	STATUS_ORDER_MENUNGGU_PEMBAYARAN,
+	STATUS_ORDER_SEDANG_DIPROSES,
+}
+
+const (
+	PAYMENT_METHOD_TRANSFER = "TRANSFER"
+	PAYMENT_METHOD_COD      = "COD"
+)
+
+// order with total (items and shipping cost) from this amount need verified receiver phone
+var HIGH_VALUE_ORDER_AMOUNT float64 = 5000000
+
 type OrderItems struct {
 	Product   DenormalizationProduct `json:"product"`
+	SKU       string                 `json:"sku" bson:"sku"`
 	Quantity  int64                  `json:"quantity" bson:"quantity" validate:"min=1"`
 	BuyerNote string                 `json:"buyer_note" bson:"buyer_note"`
 	Colors    []string               `json:"colors" bson:"colors"`
 	Sizes     []string               `json:"sizes" bson:"sizes"`
 	Price     int64                  `json:"price" bson:"price" validate:"min=0"`
+	//flash sale whose quota is reserved for the item, its price is captured in price
+	FlashSaleID string `json:"flash_sale_id,omitempty" bson:"flash_sale_id"`
+	//bundle the item is part of, its price is share of bundle price
+	BundleID   string `json:"bundle_id,omitempty" bson:"bundle_id"`
+	BundleName string `json:"bundle_name,omitempty" bson:"bundle_name"`
 }
 
 type Order struct {
@@ -40,6 +60,10 @@ type Order struct {
 	ReceiverAddress  Address                 `json:"receiver_address" bson:"receiver_address"`
 	Shipping         ShippingProvider        `json:"shipping" bson:"shipping" validate:"required"`
 	ShippingCost     int64                   `json:"shipping_cost" bson:"shipping_cost" validate:"min=0"`
+	PaymentMethod    string                  `json:"payment_method" bson:"payment_method"`
+	Vouchers         []OrderVoucher          `json:"vouchers" bson:"vouchers"`
+	Discount         int64                   `json:"discount" bson:"discount" validate:"min=0"`
+	ShippingDiscount int64                   `json:"shipping_discount" bson:"shipping_discount" validate:"min=0"`
 	ServiceName      string                  `json:"service_name" bson:"service_name"`
 	StatusOrder      string                  `json:"status_order" bson:"status_order"`
 	ResiNumber       string                  `json:"resi_number" bson:"resi_number"`
@@ -51,6 +75,26 @@ type Order struct {
 	UpdatedAt        time.Time               `json:"updated_at" bson:"updated_at" validate:"required"`
 }
 
+// Subtotal is price of every item captured at checkout,
+// so discount starting or ending after checkout does not change it
+func (o *Order) Subtotal() int64 {
+	subtotal := int64(0)
+	for _, item := range o.OrderItems {
+		subtotal += item.Price * item.Quantity
+	}
+	return subtotal
+}
+
+// Total is subtotal and shipping cost after voucher discounts
+func (o *Order) Total() float64 {
+	return float64(o.Subtotal() + o.ShippingCost - o.Discount - o.ShippingDiscount)
+}
+
+// NeedVerifiedReceiverPhone is true for cash on delivery and high value order
+func (o *Order) NeedVerifiedReceiverPhone() bool {
+	return o.PaymentMethod == PAYMENT_METHOD_COD || o.Total() >= HIGH_VALUE_ORDER_AMOUNT
+}
+
 type OrderSearchOptions struct {
 	//order's customer.id equals to search customerID keyword
 	CustomerID string
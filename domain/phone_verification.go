@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+)
+
+var PHONE_OTP_DURATION = 5 * time.Minute
+var PHONE_OTP_RESEND_INTERVAL = 1 * time.Minute
+var PHONE_OTP_MAX_ATTEMPTS int64 = 5
+
+// one time password sent to customer phone, only hash of the code is saved
+type PhoneOTP struct {
+	CustomerID string    `json:"customer_id"`
+	Phone      string    `json:"phone"`
+	HashedCode string    `json:"-"`
+	Attempts   int64     `json:"attempts"`
+	SentAt     time.Time `json:"sent_at"`
+}
@@ -1,9 +1,23 @@
 package domain
 
-import "time"
+import (
+	"math"
+	"time"
+)
+
+const (
+	PRODUCT_STATUS_DRAFT      = "DRAFT"
+	PRODUCT_STATUS_ACTIVE     = "ACTIVE"
+	PRODUCT_STATUS_ARCHIVED   = "ARCHIVED"
+	PRODUCT_STATUS_TAKEN_DOWN = "TAKEN_DOWN"
+)
+
+// PRODUCT_INACTIVE_STATUSES are hidden from buyer search, product saved before status existed has empty status and is active
+var PRODUCT_INACTIVE_STATUSES = []string{PRODUCT_STATUS_DRAFT, PRODUCT_STATUS_ARCHIVED, PRODUCT_STATUS_TAKEN_DOWN}
 
 type Product struct {
 	ID          string                  `json:"_id" bson:"_id" validate:"required"`
+	SKU         string                  `json:"sku" bson:"sku"`
 	Name        string                  `json:"name" bson:"name" validate:"required"`
 	Weight      float64                 `json:"weight" bson:"weight" validate:"min=0"`
 	Width       float64                 `json:"width" bson:"width" validate:"min=0"`
@@ -18,12 +32,35 @@ type Product struct {
 	Photos      []string                `json:"photos" bson:"photos" validate:"required"`
 	Price       float64                 `json:"price" bson:"price" validate:"min=1"`
 	Stock       float64                 `json:"stock" bson:"stock" validate:"min=1"`
+	Variants    []Variant               `json:"variants" bson:"variants" validate:"unique_variants,dive"`
+	Attributes  []ProductAttribute      `json:"attributes" bson:"attributes" validate:"unique_attributes"`
 	Merchant    DenormalizationMerchant `json:"merchant" bson:"merchant" validate:"required"`
 	Reviews     []RProduct              `json:"reviews" bson:"-"`
 	Rating      float64                 `json:"rating" bson:"rating"`
 	NumReview   float64                 `json:"num_review" bson:"num_review"`
-	CreatedAt   time.Time               `json:"created_at" bson:"created_at"`
-	UpdatedAt   time.Time               `json:"updated_at" bson:"updated_at"`
+	Status      string                  `json:"status" bson:"status" validate:"omitempty,oneof=DRAFT ACTIVE ARCHIVED TAKEN_DOWN"`
+	Discounts   []Discount              `json:"discounts" bson:"discounts" validate:"dive"`
+	FlashSales  []ProductFlashSale      `json:"flash_sales" bson:"flash_sales"`
+	Wholesales  []WholesaleTier         `json:"wholesales" bson:"wholesales" validate:"dive"`
+	BundleItems []BundleItem            `json:"bundle_items" bson:"bundle_items" validate:"dive"`
+	//discounted price and percent are stored for search, they are recomputed by SyncDiscount at price change at
+	DiscountedPrice float64    `json:"discounted_price" bson:"discounted_price"`
+	DiscountPercent float64    `json:"discount_percent" bson:"discount_percent"`
+	PriceChangeAt   *time.Time `json:"price_change_at" bson:"price_change_at"`
+	//soft deleted product keep its status, so it is back to that status when restored
+	DeletedAt *time.Time `json:"deleted_at" bson:"deleted_at"`
+	CreatedAt time.Time  `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" bson:"updated_at"`
+}
+
+// IsActive is true when product can be bought
+func (p *Product) IsActive() bool {
+	return (p.Status == "" || p.Status == PRODUCT_STATUS_ACTIVE) && p.DeletedAt == nil
+}
+
+// IsVisible is true when buyer can open the product, archived product stay visible for order history
+func (p *Product) IsVisible() bool {
+	return p.IsActive() || (p.Status == PRODUCT_STATUS_ARCHIVED && p.DeletedAt == nil)
 }
 
 func (p *Product) DenormalizationData() DenormalizationProduct {
@@ -43,32 +80,281 @@ func (p *Product) DenormalizationData() DenormalizationProduct {
 		Photos:      p.Photos,
 		Price:       p.Price,
 		Stock:       p.Stock,
+		Variants:    p.Variants,
+		Discounts:   p.Discounts,
+		FlashSales:  p.FlashSales,
+		Wholesales:  p.Wholesales,
+		BundleItems: p.BundleItems,
 		Rating:      p.Rating,
 		NumReview:   p.NumReview,
 	}
 }
 
 type DenormalizationProduct struct {
-	ID          string   `json:"_id" bson:"_id"`
-	Name        string   `json:"name" bson:"name"`
-	Weight      float64  `json:"weight" bson:"weight"`
-	Width       float64  `json:"width" bson:"width"`
-	Height      float64  `json:"height" bson:"height"`
-	Long        float64  `json:"long" bson:"long"`
-	Description string   `json:"description" bson:"description"`
-	Etalase     string   `json:"etalase" bson:"etalase"`
-	Category    Category `json:"category" bson:"category"`
-	Tags        []string `json:"tags" bson:"tags"`
-	Colors      []string `json:"colors" bson:"colors"`
-	Sizes       []string `json:"sizes" bson:"sizes"`
-	Photos      []string `json:"photos" bson:"photos"`
-	Price       float64  `json:"price" bson:"price"`
-	Stock       float64  `json:"stock" bson:"stock"`
-	Rating      float64  `json:"rating" bson:"rating"`
-	NumReview   float64  `json:"num_review" bson:"num_review"`
+	ID          string             `json:"_id" bson:"_id"`
+	Name        string             `json:"name" bson:"name"`
+	Weight      float64            `json:"weight" bson:"weight"`
+	Width       float64            `json:"width" bson:"width"`
+	Height      float64            `json:"height" bson:"height"`
+	Long        float64            `json:"long" bson:"long"`
+	Description string             `json:"description" bson:"description"`
+	Etalase     string             `json:"etalase" bson:"etalase"`
+	Category    Category           `json:"category" bson:"category"`
+	Tags        []string           `json:"tags" bson:"tags"`
+	Colors      []string           `json:"colors" bson:"colors"`
+	Sizes       []string           `json:"sizes" bson:"sizes"`
+	Photos      []string           `json:"photos" bson:"photos"`
+	Price       float64            `json:"price" bson:"price"`
+	Stock       float64            `json:"stock" bson:"stock"`
+	Variants    []Variant          `json:"variants" bson:"variants"`
+	Discounts   []Discount         `json:"discounts" bson:"discounts"`
+	FlashSales  []ProductFlashSale `json:"flash_sales" bson:"flash_sales"`
+	Wholesales  []WholesaleTier    `json:"wholesales" bson:"wholesales"`
+	BundleItems []BundleItem       `json:"bundle_items" bson:"bundle_items"`
+	Rating      float64            `json:"rating" bson:"rating"`
+	NumReview   float64            `json:"num_review" bson:"num_review"`
+}
+
+const (
+	PRODUCT_BULK_UPDATE_STATUS_UPDATED = "UPDATED"
+	PRODUCT_BULK_UPDATE_STATUS_FAILED  = "FAILED"
+)
+
+// ProductBulkUpdateResult is outcome of one item of bulk stock and price update, in same order as request items
+type ProductBulkUpdateResult struct {
+	ProductID string  `json:"product_id"`
+	SKU       string  `json:"sku"`
+	Status    string  `json:"status"`
+	Message   string  `json:"message,omitempty"`
+	Price     float64 `json:"price"`
+	Stock     float64 `json:"stock"`
+}
+
+// ProductStockPriceUpdate is product changed by bulk stock and price update with the product as it was read,
+// so only changed variants are written and stock sold meanwhile is not overwritten
+type ProductStockPriceUpdate struct {
+	Previous Product
+	Product  Product
+}
+
+// ProductAttribute is value of attribute defined by product category, see CategoryAttribute
+type ProductAttribute struct {
+	Name  string `json:"name" bson:"name"`
+	Value string `json:"value" bson:"value"`
+}
+
+const (
+	DISCOUNT_TYPE_PERCENT = "PERCENT"
+	DISCOUNT_TYPE_FIXED   = "FIXED"
+)
+
+// Discount is scheduled price cut of product, it applies to every variant from start at until end at
+type Discount struct {
+	ID      string    `json:"_id" bson:"_id" validate:"required"`
+	Type    string    `json:"type" bson:"type" validate:"oneof=PERCENT FIXED"`
+	Value   float64   `json:"value" bson:"value" validate:"min=1"`
+	StartAt time.Time `json:"start_at" bson:"start_at" validate:"required,ltfield=EndAt"`
+	EndAt   time.Time `json:"end_at" bson:"end_at" validate:"required"`
+}
+
+// IsRunning is true when given time is between discount start at and end at
+func (d Discount) IsRunning(at time.Time) bool {
+	return !at.Before(d.StartAt) && at.Before(d.EndAt)
+}
+
+// Apply is price after discount, it never goes below zero
+func (d Discount) Apply(price float64) float64 {
+	discounted := price - d.Value
+	if d.Type == DISCOUNT_TYPE_PERCENT {
+		discounted = price - math.Round(price*d.Value/100)
+	}
+	if discounted < 0 {
+		return 0
+	}
+	return discounted
+}
+
+// WholesaleTier is price of every unit when one item is bought at least min quantity
+type WholesaleTier struct {
+	MinQuantity int64   `json:"min_quantity" bson:"min_quantity" validate:"min=2"`
+	Price       float64 `json:"price" bson:"price" validate:"min=1"`
+}
+
+// Variant is one combination of product option values sold as its own SKU
+type Variant struct {
+	SKU    string  `json:"sku" bson:"sku" validate:"required"`
+	Color  string  `json:"color" bson:"color"`
+	Size   string  `json:"size" bson:"size"`
+	Price  float64 `json:"price" bson:"price" validate:"min=1"`
+	Stock  float64 `json:"stock" bson:"stock" validate:"min=0"`
+	Weight float64 `json:"weight" bson:"weight" validate:"min=0"`
+	Photo  string  `json:"photo" bson:"photo"`
+}
+
+// SyncVariants make product price, stock and options summary of its variants,
+// lowest variant price is shown in listing and stock is total of every variant
+func (p *Product) SyncVariants() {
+	if len(p.Variants) == 0 {
+		return
+	}
+
+	p.Colors, p.Sizes = []string{}, []string{}
+	colors, sizes := map[string]bool{}, map[string]bool{}
+	p.Price, p.Stock = p.Variants[0].Price, 0
+	for _, variant := range p.Variants {
+		if variant.Price < p.Price {
+			p.Price = variant.Price
+		}
+		p.Stock += variant.Stock
+		if variant.Color != "" && !colors[variant.Color] {
+			colors[variant.Color] = true
+			p.Colors = append(p.Colors, variant.Color)
+		}
+		if variant.Size != "" && !sizes[variant.Size] {
+			sizes[variant.Size] = true
+			p.Sizes = append(p.Sizes, variant.Size)
+		}
+	}
+}
+
+// SyncDiscount store product lowest price after discount or flash sale running at given time,
+// and the next time a discount or flash sale starts or ends so stored price is refreshed then
+func (p *Product) SyncDiscount(at time.Time) {
+	p.DiscountedPrice, p.DiscountPercent, p.PriceChangeAt = p.Price, 0, nil
+	if discount, ok := p.DenormalizationData().ActiveDiscount(at); ok {
+		p.DiscountedPrice = discount.Apply(p.Price)
+	}
+	changeAts := []time.Time{}
+	for _, discount := range p.Discounts {
+		changeAts = append(changeAts, discount.StartAt, discount.EndAt)
+	}
+	for _, flashSale := range p.FlashSales {
+		if flashSale.IsRunning(at) && flashSale.Price < p.DiscountedPrice {
+			p.DiscountedPrice = flashSale.Price
+		}
+		if !flashSale.SoldOut {
+			changeAts = append(changeAts, flashSale.StartAt, flashSale.EndAt)
+		}
+	}
+	if p.Price > 0 {
+		p.DiscountPercent = math.Round((p.Price - p.DiscountedPrice) / p.Price * 100)
+	}
+
+	for _, changeAt := range changeAts {
+		if changeAt.After(at) && (p.PriceChangeAt == nil || changeAt.Before(*p.PriceChangeAt)) {
+			next := changeAt
+			p.PriceChangeAt = &next
+		}
+	}
+}
+
+// EffectivePrice is stored lowest price after discount, product saved before discounts existed has only price
+func (p *Product) EffectivePrice() float64 {
+	if p.DiscountedPrice > 0 {
+		return p.DiscountedPrice
+	}
+	return p.Price
+}
+
+// Variant find variant by sku, product without variant has no sku
+func (p DenormalizationProduct) Variant(sku string) (Variant, bool) {
+	for _, variant := range p.Variants {
+		if variant.SKU == sku {
+			return variant, true
+		}
+	}
+	return Variant{}, false
+}
+
+// OriginalPriceOf is price of chosen variant before discount, or product price when product has no variant
+func (p DenormalizationProduct) OriginalPriceOf(sku string) float64 {
+	if variant, ok := p.Variant(sku); ok {
+		return variant.Price
+	}
+	return p.Price
+}
+
+// PriceOf is price of chosen variant buyer pays now, running flash sale price replace discount
+func (p DenormalizationProduct) PriceOf(sku string) float64 {
+	if flashSale, ok := p.RunningFlashSale(sku, time.Now()); ok {
+		return flashSale.Price
+	}
+	price := p.OriginalPriceOf(sku)
+	if discount, ok := p.ActiveDiscount(time.Now()); ok {
+		return discount.Apply(price)
+	}
+	return price
+}
+
+// PriceFor is price of chosen variant buyer pays now for given quantity,
+// wholesale tier price only applies when it is cheaper than discount or flash sale price
+func (p DenormalizationProduct) PriceFor(sku string, quantity int64) float64 {
+	price := p.PriceOf(sku)
+	if tier, ok := p.WholesaleTierOf(quantity); ok && tier.Price < price {
+		return tier.Price
+	}
+	return price
+}
+
+// WholesaleTierOf is highest wholesale tier reached by quantity
+func (p DenormalizationProduct) WholesaleTierOf(quantity int64) (WholesaleTier, bool) {
+	reached, found := WholesaleTier{}, false
+	for _, tier := range p.Wholesales {
+		if quantity >= tier.MinQuantity && (!found || tier.MinQuantity > reached.MinQuantity) {
+			reached, found = tier, true
+		}
+	}
+	return reached, found
+}
+
+// RunningFlashSale is flash sale of chosen variant running at given time
+func (p DenormalizationProduct) RunningFlashSale(sku string, at time.Time) (ProductFlashSale, bool) {
+	for _, flashSale := range p.FlashSales {
+		if flashSale.SKU == sku && flashSale.IsRunning(at) {
+			return flashSale, true
+		}
+	}
+	return ProductFlashSale{}, false
+}
+
+// ActiveDiscount is discount running at given time, latest started discount wins when discounts overlap
+func (p DenormalizationProduct) ActiveDiscount(at time.Time) (Discount, bool) {
+	active, found := Discount{}, false
+	for _, discount := range p.Discounts {
+		if discount.IsRunning(at) && (!found || discount.StartAt.After(active.StartAt)) {
+			active, found = discount, true
+		}
+	}
+	return active, found
+}
+
+// StockOf is stock of chosen variant, or product stock when product has no variant
+func (p DenormalizationProduct) StockOf(sku string) float64 {
+	if variant, ok := p.Variant(sku); ok {
+		return variant.Stock
+	}
+	return p.Stock
+}
+
+// WeightOf is weight of chosen variant, variant without weight use product weight
+func (p DenormalizationProduct) WeightOf(sku string) float64 {
+	if variant, ok := p.Variant(sku); ok && variant.Weight > 0 {
+		return variant.Weight
+	}
+	return p.Weight
 }
 
 type ProductSearchOptions struct {
+	//product's id is one of search ids
+	IDs []string
+	//product's merchant sku is one of search skus
+	SKUs []string
+	//product's category starts with search category path, empty level of search path is not matched
+	CategoryPath Category
+	//product's status is one of search statuses, product without status is active
+	Statuses []string
+	//nil search all products, true search only soft deleted products, false search only not deleted products
+	Deleted *bool
 	//product's name contains regex search name keyword
 	Name string
 	//product's categories have item with category name contains regex search category keyword
@@ -78,6 +364,8 @@ type ProductSearchOptions struct {
 	Description string
 	//product's price in range between search keyword price
 	Price int64
+	//product's stored discounted price is due to change at or before search time, or was never computed
+	PriceChangeBefore time.Time
 	//product's merchant city equals to search city keyword
 	City string
 	//product'merchant id equals to search merchantID keyword
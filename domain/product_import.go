@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+const (
+	PRODUCT_IMPORT_STATUS_PENDING = "PENDING"
+	PRODUCT_IMPORT_STATUS_RUNNING = "RUNNING"
+	PRODUCT_IMPORT_STATUS_DONE    = "DONE"
+	PRODUCT_IMPORT_STATUS_FAILED  = "FAILED"
+)
+
+// PRODUCT_CSV_HEADER is column order of product csv, export use the same columns so exported file can be imported back.
+// Tags, colors, sizes and attributes are separated by "|", attribute is written as name=value
+var PRODUCT_CSV_HEADER = []string{
+	"sku", "name", "description", "etalase",
+	"category_top", "category_second_sub", "category_third_sub",
+	"tags", "colors", "sizes",
+	"price", "stock", "weight", "width", "height", "long",
+	"attributes",
+}
+
+// ProductImportJob is processed in background, merchant poll it to get progress and error of every failed row
+type ProductImportJob struct {
+	ID         string                  `json:"_id" bson:"_id"`
+	MerchantID string                  `json:"merchant_id" bson:"merchant_id"`
+	Status     string                  `json:"status" bson:"status"`
+	TotalRows  int                     `json:"total_rows" bson:"total_rows"`
+	Created    int                     `json:"created" bson:"created"`
+	Updated    int                     `json:"updated" bson:"updated"`
+	Failed     int                     `json:"failed" bson:"failed"`
+	Errors     []ProductImportRowError `json:"errors" bson:"errors"`
+	CreatedAt  time.Time               `json:"created_at" bson:"created_at"`
+	UpdatedAt  time.Time               `json:"updated_at" bson:"updated_at"`
+	FinishedAt time.Time               `json:"finished_at" bson:"finished_at"`
+}
+
+// ProductImportRowError row is line number in file, header is line 1
+type ProductImportRowError struct {
+	Row     int    `json:"row" bson:"row"`
+	SKU     string `json:"sku" bson:"sku"`
+	Field   string `json:"field" bson:"field"`
+	Message string `json:"message" bson:"message"`
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDenormalizationProductPriceFor(t *testing.T) {
+	now := time.Now()
+	running := func(discount Discount) Discount {
+		discount.StartAt, discount.EndAt = now.Add(-time.Hour), now.Add(time.Hour)
+		return discount
+	}
+	runningFlashSale := func(flashSale ProductFlashSale) ProductFlashSale {
+		flashSale.StartAt, flashSale.EndAt = now.Add(-time.Hour), now.Add(time.Hour)
+		return flashSale
+	}
+	discount := running(Discount{Type: DISCOUNT_TYPE_FIXED, Value: 20})
+	upcomingDiscount := Discount{Type: DISCOUNT_TYPE_FIXED, Value: 20, StartAt: now.Add(time.Hour), EndAt: now.Add(2 * time.Hour)}
+	flashSale := runningFlashSale(ProductFlashSale{Price: 50})
+	soldOutFlashSale := runningFlashSale(ProductFlashSale{Price: 50, SoldOut: true})
+	variantFlashSale := runningFlashSale(ProductFlashSale{SKU: "RED", Price: 150})
+
+	tests := []struct {
+		name     string
+		product  DenormalizationProduct
+		sku      string
+		quantity int64
+		want     float64
+	}{
+		{
+			name:     "original price",
+			product:  DenormalizationProduct{Price: 100},
+			quantity: 1,
+			want:     100,
+		},
+		{
+			name:     "running discount",
+			product:  DenormalizationProduct{Price: 100, Discounts: []Discount{discount}},
+			quantity: 1,
+			want:     80,
+		},
+		{
+			name:     "upcoming discount is ignored",
+			product:  DenormalizationProduct{Price: 100, Discounts: []Discount{upcomingDiscount}},
+			quantity: 1,
+			want:     100,
+		},
+		{
+			name:     "percent discount",
+			product:  DenormalizationProduct{Price: 100, Discounts: []Discount{running(Discount{Type: DISCOUNT_TYPE_PERCENT, Value: 15})}},
+			quantity: 1,
+			want:     85,
+		},
+		{
+			name:     "flash sale replaces discount",
+			product:  DenormalizationProduct{Price: 100, Discounts: []Discount{discount}, FlashSales: []ProductFlashSale{flashSale}},
+			quantity: 1,
+			want:     50,
+		},
+		{
+			name:     "sold out flash sale falls back to discount",
+			product:  DenormalizationProduct{Price: 100, Discounts: []Discount{discount}, FlashSales: []ProductFlashSale{soldOutFlashSale}},
+			quantity: 1,
+			want:     80,
+		},
+		{
+			name:     "tier not reached",
+			product:  DenormalizationProduct{Price: 100, Wholesales: []WholesaleTier{{MinQuantity: 10, Price: 70}}},
+			quantity: 9,
+			want:     100,
+		},
+		{
+			name:     "highest reached tier",
+			product:  DenormalizationProduct{Price: 100, Wholesales: []WholesaleTier{{MinQuantity: 10, Price: 70}, {MinQuantity: 5, Price: 90}}},
+			quantity: 7,
+			want:     90,
+		},
+		{
+			name:     "tier cheaper than discount",
+			product:  DenormalizationProduct{Price: 100, Discounts: []Discount{discount}, Wholesales: []WholesaleTier{{MinQuantity: 10, Price: 70}}},
+			quantity: 10,
+			want:     70,
+		},
+		{
+			name:     "discount cheaper than tier",
+			product:  DenormalizationProduct{Price: 100, Discounts: []Discount{discount}, Wholesales: []WholesaleTier{{MinQuantity: 10, Price: 90}}},
+			quantity: 10,
+			want:     80,
+		},
+		{
+			name:     "flash sale cheaper than tier",
+			product:  DenormalizationProduct{Price: 100, FlashSales: []ProductFlashSale{flashSale}, Wholesales: []WholesaleTier{{MinQuantity: 10, Price: 60}}},
+			quantity: 10,
+			want:     50,
+		},
+		{
+			name: "flash sale of other variant is ignored",
+			product: DenormalizationProduct{
+				Price:      100,
+				Variants:   []Variant{{SKU: "RED", Price: 200}, {SKU: "BLUE", Price: 180}},
+				FlashSales: []ProductFlashSale{variantFlashSale},
+			},
+			sku:      "BLUE",
+			quantity: 1,
+			want:     180,
+		},
+		{
+			name: "flash sale of chosen variant",
+			product: DenormalizationProduct{
+				Price:      100,
+				Variants:   []Variant{{SKU: "RED", Price: 200}, {SKU: "BLUE", Price: 180}},
+				FlashSales: []ProductFlashSale{variantFlashSale},
+			},
+			sku:      "RED",
+			quantity: 1,
+			want:     150,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.product.PriceFor(test.sku, test.quantity); got != test.want {
+				t.Errorf("PriceFor(%q, %d) = %v, want %v", test.sku, test.quantity, got, test.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,11 @@
 package domain
 
+const (
+	SEARCH_SORT_PRICE_ASC  = "price_asc"
+	SEARCH_SORT_PRICE_DESC = "price_desc"
+	SEARCH_SORT_DISCOUNT   = "discount"
+)
+
 type Search struct {
 	Tags      []string   `json:"tags"`
 	Products  []Product  `json:"products"`
@@ -32,8 +38,30 @@ type CitySearch struct {
 	} `json:"buckets"`
 }
 
+// OptionSearch count product by color or size option, option of product with variants comes from its variants
+type OptionSearch struct {
+	Buckets []struct {
+		Count int    `json:"doc_count"`
+		Key   string `json:"key"`
+	} `json:"buckets"`
+}
+
+// AttributeSearch is nested aggregation of product attributes, values are counted per attribute name
+type AttributeSearch struct {
+	Names struct {
+		Buckets []struct {
+			Count  int          `json:"doc_count"`
+			Key    string       `json:"key"`
+			Values OptionSearch `json:"values"`
+		} `json:"buckets"`
+	} `json:"names"`
+}
+
 type SearchProduct struct {
-	Categories CategorySearch `json:"categories"`
-	Cities     CitySearch     `json:"cities"`
-	Products   []Product      `json:"products"`
+	Categories CategorySearch  `json:"categories"`
+	Cities     CitySearch      `json:"cities"`
+	Colors     OptionSearch    `json:"colors"`
+	Sizes      OptionSearch    `json:"sizes"`
+	Attributes AttributeSearch `json:"attributes"`
+	Products   []Product       `json:"products"`
 }
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// session without activity longer than this duration is not valid anymore
+var SESSION_IDLE_DURATION = 30 * 24 * time.Hour
+
+// last activity is only written when older than this interval, avoid one write for every request
+var SESSION_ACTIVITY_INTERVAL = time.Minute
+
+// session is created for every login, token carries the session id in jti claim
+type Session struct {
+	ID             string    `json:"_id" bson:"_id"`
+	UserID         string    `json:"user_id" bson:"user_id"`
+	LoginType      string    `json:"login_type" bson:"login_type"`
+	UserAgent      string    `json:"user_agent" bson:"user_agent"`
+	IP             string    `json:"ip" bson:"ip"`
+	LastActivityAt time.Time `json:"last_activity_at" bson:"last_activity_at"`
+	Revoked        bool      `json:"revoked" bson:"revoked"`
+	RevokedAt      time.Time `json:"revoked_at" bson:"revoked_at"`
+	//admin id when session is made by admin impersonation
+	ImpersonatorID string `json:"impersonator_id" bson:"impersonator_id"`
+	//marked when session is the one used by the request
+	Current   bool      `json:"current" bson:"-"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+func (s *Session) IsActive(now time.Time) bool {
+	return !s.Revoked && now.Sub(s.LastActivityAt) < SESSION_IDLE_DURATION
+}
+
+type SessionSearchOptions struct {
+	UserID     string
+	LoginType  string
+	ActiveOnly bool
+}
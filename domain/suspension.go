@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+const (
+	SUSPENSION_STATUS_SUSPENDED = "SUSPENDED"
+	SUSPENSION_STATUS_BANNED    = "BANNED"
+)
+
+// SUSPENSION_INDEFINITE_UNTIL is used as end date of ban and suspension without end date,
+// so search index can hide suspended account with single range query
+var SUSPENSION_INDEFINITE_UNTIL = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+// Suspension is saved with customer or merchant document, empty status means account is active
+type Suspension struct {
+	Status    string    `json:"status" bson:"status"`
+	Reason    string    `json:"reason" bson:"reason"`
+	Until     time.Time `json:"until" bson:"until"`
+	AdminID   string    `json:"admin_id" bson:"admin_id"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+func (s *Suspension) IsActive(now time.Time) bool {
+	return s.Status != "" && now.Before(s.Until)
+}
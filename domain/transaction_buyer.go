@@ -10,15 +10,20 @@ const (
 )
 
 type TBuyer struct {
-	ID            string    `json:"_id" bson:"_id" validate:"required"`
-	CustomerID    string    `json:"customer_id" bson:"customer_id" validate:"required"`
-	AdminID       string    `json:"admin_id" bson:"admin_id" validate:"required"`
-	TotalTransfer int64     `json:"total_transfer" bson:"total_transfer" validate:"min=0"`
-	PaymentStatus string    `json:"payment_status" bson:"payment_status" validate:"required"`
-	TransferPhoto string    `json:"transfer_photo" bson:"transfer_photo"`
-	Message       string    `json:"message" bson:"message"`
-	CreatedAt     time.Time `json:"created_at" bson:"created_at" validate:"required"`
-	UpdatedAt     time.Time `json:"updated_at" bson:"updated_at" validate:"required"`
+	ID         string `json:"_id" bson:"_id" validate:"required"`
+	CustomerID string `json:"customer_id" bson:"customer_id" validate:"required"`
+	AdminID    string `json:"admin_id" bson:"admin_id" validate:"required"`
+	//subtotal, shipping cost and discounts are sum of orders of the transaction, total transfer is what buyer pays
+	Subtotal         int64     `json:"subtotal" bson:"subtotal"`
+	ShippingCost     int64     `json:"shipping_cost" bson:"shipping_cost"`
+	Discount         int64     `json:"discount" bson:"discount"`
+	ShippingDiscount int64     `json:"shipping_discount" bson:"shipping_discount"`
+	TotalTransfer    int64     `json:"total_transfer" bson:"total_transfer" validate:"min=0"`
+	PaymentStatus    string    `json:"payment_status" bson:"payment_status" validate:"required"`
+	TransferPhoto    string    `json:"transfer_photo" bson:"transfer_photo"`
+	Message          string    `json:"message" bson:"message"`
+	CreatedAt        time.Time `json:"created_at" bson:"created_at" validate:"required"`
+	UpdatedAt        time.Time `json:"updated_at" bson:"updated_at" validate:"required"`
 }
 
 type TBuyerSearchOptions struct {
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+var LOGIN_CHALLENGE_SUBJECT = "LOGIN_CHALLENGE"
+var LOGIN_CHALLENGE_DURATION = 5 * time.Minute
+
+// user is locked out of second factor after too many wrong codes, until the lock duration is passed
+var TWO_FACTOR_MAX_ATTEMPTS int64 = 5
+var TWO_FACTOR_LOCK_DURATION = 15 * time.Minute
+
+// two factor authentication state saved with admin or customer document
+type TwoFactor struct {
+	Enabled       bool     `json:"enabled" bson:"enabled"`
+	Secret        string   `json:"-" bson:"secret"`
+	PendingSecret string   `json:"-" bson:"pending_secret"`
+	RecoveryCodes []string `json:"-" bson:"recovery_codes"`
+	//last accepted time step, prevent same code used twice
+	LastUsedStep int64 `json:"-" bson:"last_used_step"`
+}
+
+type TwoFactorEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// short lived token returned by login when second factor is needed,
+// exchanged for a full credential after the code is verified
+type LoginChallenge struct {
+	jwt.StandardClaims
+	UserID    string `json:"user_id"`
+	LoginType string `json:"login_type"`
+	//false when user has to finish enrollment with the challenge
+	Enrolled bool `json:"enrolled"`
+}
+
+func NewLoginChallenge(userID, loginType string, enrolled bool) LoginChallenge {
+	now := time.Now()
+	return LoginChallenge{
+		jwt.StandardClaims{
+			Issuer:    APPLICATION_NAME,
+			Subject:   LOGIN_CHALLENGE_SUBJECT,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(LOGIN_CHALLENGE_DURATION).Unix(),
+		},
+		userID,
+		loginType,
+		enrolled,
+	}
+}
@@ -0,0 +1,126 @@
+package domain
+
+import "time"
+
+const (
+	VOUCHER_TYPE_FIXED         = "FIXED"
+	VOUCHER_TYPE_PERCENT       = "PERCENT"
+	VOUCHER_TYPE_FREE_SHIPPING = "FREE_SHIPPING"
+)
+
+// Voucher is discount code used at checkout, voucher without merchant id is platform voucher
+type Voucher struct {
+	ID   string `json:"_id" bson:"_id" validate:"required"`
+	Code string `json:"code" bson:"code" validate:"required,min=4,max=20"`
+	//merchant voucher only applies to order of its merchant
+	MerchantID string `json:"merchant_id" bson:"merchant_id"`
+	Type       string `json:"type" bson:"type" validate:"oneof=FIXED PERCENT FREE_SHIPPING"`
+	//amount for FIXED type, percent for PERCENT type, not used by FREE_SHIPPING type
+	Value int64 `json:"value" bson:"value" validate:"min=0,voucher_value"`
+	//highest discount of PERCENT and FREE_SHIPPING type, zero is no cap
+	MaxDiscount int64 `json:"max_discount" bson:"max_discount" validate:"min=0"`
+	//eligible items price must reach min spend
+	MinSpend int64 `json:"min_spend" bson:"min_spend" validate:"min=0"`
+	//item is eligible when its category starts with one of categories, empty categories is every category
+	Categories []Category `json:"categories" bson:"categories"`
+	//platform voucher only applies to orders of these merchants, empty merchant ids is every merchant
+	MerchantIDs      []string `json:"merchant_ids" bson:"merchant_ids"`
+	NewCustomerOnly  bool     `json:"new_customer_only" bson:"new_customer_only"`
+	Quota            int64    `json:"quota" bson:"quota" validate:"min=1"`
+	QuotaPerCustomer int64    `json:"quota_per_customer" bson:"quota_per_customer" validate:"min=1"`
+	Used             int64    `json:"used" bson:"used"`
+	//number of use by every customer keyed by customer id, it is updated together with used
+	Usages    map[string]int64 `json:"-" bson:"usages"`
+	StartAt   time.Time        `json:"start_at" bson:"start_at" validate:"required,ltfield=EndAt"`
+	EndAt     time.Time        `json:"end_at" bson:"end_at" validate:"required"`
+	CreatedAt time.Time        `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at" bson:"updated_at"`
+}
+
+// IsRunning is true when given time is between voucher start at and end at
+func (v *Voucher) IsRunning(at time.Time) bool {
+	return !at.Before(v.StartAt) && at.Before(v.EndAt)
+}
+
+// EligibleAmount is captured price of order items the voucher applies to
+func (v *Voucher) EligibleAmount(order Order) int64 {
+	if v.MerchantID != "" && v.MerchantID != order.Merchant.ID {
+		return 0
+	}
+	if len(v.MerchantIDs) != 0 {
+		found := false
+		for _, merchantID := range v.MerchantIDs {
+			if merchantID == order.Merchant.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0
+		}
+	}
+
+	amount := int64(0)
+	for _, item := range order.OrderItems {
+		if v.coversCategory(item.Product.Category) {
+			amount += item.Price * item.Quantity
+		}
+	}
+	return amount
+}
+
+func (v *Voucher) coversCategory(category Category) bool {
+	if len(v.Categories) == 0 {
+		return true
+	}
+	for _, scope := range v.Categories {
+		if (scope.Top == "" || scope.Top == category.Top) &&
+			(scope.SecondSub == "" || scope.SecondSub == category.SecondSub) &&
+			(scope.ThirdSub == "" || scope.ThirdSub == category.ThirdSub) {
+			return true
+		}
+	}
+	return false
+}
+
+// Discount is item discount and shipping discount of eligible amount and shipping cost of eligible orders
+func (v *Voucher) Discount(eligibleAmount, shippingCost int64) (int64, int64) {
+	discount, shippingDiscount := int64(0), int64(0)
+	switch v.Type {
+	case VOUCHER_TYPE_FIXED:
+		discount = v.Value
+	case VOUCHER_TYPE_PERCENT:
+		discount = eligibleAmount * v.Value / 100
+	case VOUCHER_TYPE_FREE_SHIPPING:
+		shippingDiscount = shippingCost
+	}
+	if v.MaxDiscount > 0 && discount > v.MaxDiscount {
+		discount = v.MaxDiscount
+	}
+	if v.MaxDiscount > 0 && shippingDiscount > v.MaxDiscount {
+		shippingDiscount = v.MaxDiscount
+	}
+	if discount > eligibleAmount {
+		discount = eligibleAmount
+	}
+	return discount, shippingDiscount
+}
+
+type VoucherSearchOptions struct {
+	//voucher's merchant id equals to search merchant id, empty string search platform vouchers
+	MerchantID *string
+	//voucher's code equals to search code
+	Code string
+	//voucher is running at search time
+	RunningAt time.Time
+}
+
+// OrderVoucher is discount of one voucher given to an order, platform voucher is split between eligible orders of one checkout
+type OrderVoucher struct {
+	VoucherID        string `json:"voucher_id" bson:"voucher_id"`
+	Code             string `json:"code" bson:"code"`
+	MerchantID       string `json:"merchant_id" bson:"merchant_id"`
+	Type             string `json:"type" bson:"type"`
+	Discount         int64  `json:"discount" bson:"discount"`
+	ShippingDiscount int64  `json:"shipping_discount" bson:"shipping_discount"`
+}
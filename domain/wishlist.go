@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// WishlistItem is saved per customer and product, product snapshot is replaced by current product when wishlist is listed.
+// Sku, quantity, colors, sizes and note are kept when item is saved for later from cart
+type WishlistItem struct {
+	ID         string                 `json:"_id" bson:"_id"`
+	CustomerID string                 `json:"customer_id" bson:"customer_id" validate:"required"`
+	Product    DenormalizationProduct `json:"product" bson:"product" validate:"required"`
+	SKU        string                 `json:"sku" bson:"sku"`
+	AddedPrice float64                `json:"added_price" bson:"added_price"`
+	Quantity   int64                  `json:"quantity" bson:"quantity" validate:"min=1"`
+	Sizes      []string               `json:"sizes" bson:"sizes"`
+	Colors     []string               `json:"colors" bson:"colors"`
+	Note       string                 `json:"note" bson:"note"`
+	//current product state, only filled on list
+	Available bool      `json:"available" bson:"-"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+type WishlistSearchOptions struct {
+	CustomerID string
+	//wishlist item product id equals to search productID
+	ProductID string
+}
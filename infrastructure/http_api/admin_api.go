@@ -1,6 +1,7 @@
 package http_api
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 
 	"cloud.google.com/go/storage"
 	"github.com/gorilla/mux"
+	"github.com/market-place/domain"
 	"github.com/market-place/infrastructure/http_api/helper"
 	"github.com/market-place/infrastructure/http_api/http_response"
 	"github.com/market-place/usecase/adapter"
@@ -23,6 +25,7 @@ type AdminAPI interface {
 	UpdateBiodata(w http.ResponseWriter, r *http.Request)
 	UploadPhotoProfile(w http.ResponseWriter, r *http.Request)
 	UpdatePassword(w http.ResponseWriter, r *http.Request)
+	UpdateRole(w http.ResponseWriter, r *http.Request)
 	AddAddress(w http.ResponseWriter, r *http.Request)
 	UpdateAddress(w http.ResponseWriter, r *http.Request)
 	DeleteAddress(w http.ResponseWriter, r *http.Request)
@@ -57,7 +60,7 @@ func (a *adminAPI) Create(w http.ResponseWriter, r *http.Request) {
 		http_response.SendErrJSON(w, err)
 		return
 	}
-	if err := a.authUsecase.VerifiedAsAdmin(credential); err != nil {
+	if err := a.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_MANAGE_ADMIN); err != nil {
 		http_response.SendErrJSON(w, err)
 		return
 	}
@@ -73,7 +76,8 @@ func (a *adminAPI) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	admin, err := a.adminUsecase.Create(r.Context(), input)
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	admin, err := a.adminUsecase.Create(ctx, input)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -113,7 +117,7 @@ func (a *adminAPI) Fetch(w http.ResponseWriter, r *http.Request) {
 		http_response.SendErrJSON(w, err)
 		return
 	}
-	if err := a.authUsecase.VerifiedAsAdmin(credential); err != nil {
+	if err := a.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_MANAGE_ADMIN); err != nil {
 		http_response.SendErrJSON(w, err)
 		return
 	}
@@ -121,6 +125,7 @@ func (a *adminAPI) Fetch(w http.ResponseWriter, r *http.Request) {
 	search := adapter.AdminSearchOptions{
 		Name:  r.FormValue("name"),
 		Email: r.FormValue("email"),
+		Role:  r.FormValue("role"),
 	}
 
 	var defaultNum int64 = 10
@@ -270,6 +275,42 @@ func (a *adminAPI) UpdatePassword(w http.ResponseWriter, r *http.Request) {
 	http_response.SendOkJSON(w, http.StatusOK, admin)
 }
 
+func (a *adminAPI) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	adminID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	fmt.Printf("ADMIN UPDATE ROLE : ID : %v - TOKEN : %v \n", adminID, token)
+
+	credential, err := a.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := a.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_MANAGE_ADMIN); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	ctx := context.WithValue(r.Context(), "credential", credential)
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	input, err := a.serialize.DecodeUpdateRoleInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	admin, err := a.adminUsecase.UpdateRole(ctx, input, adminID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, admin)
+}
+
 func (a *adminAPI) AddAddress(w http.ResponseWriter, r *http.Request) {
 	adminID := mux.Vars(r)["id"]
 	token := r.Header.Get("token")
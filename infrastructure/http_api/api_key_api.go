@@ -0,0 +1,138 @@
+package http_api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/market-place/infrastructure/http_api/http_response"
+	adapterJSON "github.com/market-place/usecase/adapter/json"
+	"github.com/market-place/usecase/logic"
+)
+
+type APIKeyAPI interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	Fetch(w http.ResponseWriter, r *http.Request)
+	Revoke(w http.ResponseWriter, r *http.Request)
+}
+
+type apiKeyAPI struct {
+	apiKeyUsecase logic.APIKeyUsecase
+	authUsecase   logic.AuthenticationUsecase
+	serialize     adapterJSON.AdapterAPIKeyJSON
+}
+
+func NewAPIKeyAPI(
+	apiKeyUsecase logic.APIKeyUsecase,
+	authUsecase logic.AuthenticationUsecase,
+) APIKeyAPI {
+	return &apiKeyAPI{
+		apiKeyUsecase: apiKeyUsecase,
+		authUsecase:   authUsecase,
+		serialize:     adapterJSON.AdapterAPIKeyJSON{},
+	}
+}
+
+func (a *apiKeyAPI) Create(w http.ResponseWriter, r *http.Request) {
+	merchantID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := a.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := a.authUsecase.VerifiedAsCustomer(credential); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := a.authUsecase.VerifiedMerchantOwner(credential, merchantID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	input, err := a.serialize.DecodeCreateInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	apiKey, plainKey, err := a.apiKeyUsecase.Create(ctx, input, merchantID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	res := map[string]interface{}{
+		"api_key": apiKey,
+		"key":     plainKey,
+	}
+	http_response.SendOkJSON(w, http.StatusCreated, res)
+}
+
+func (a *apiKeyAPI) Fetch(w http.ResponseWriter, r *http.Request) {
+	merchantID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := a.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := a.authUsecase.VerifiedAsCustomer(credential); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := a.authUsecase.VerifiedMerchantOwner(credential, merchantID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	var defaultNum int64 = 10
+	if num, err := strconv.Atoi(r.FormValue("num")); err == nil {
+		defaultNum = int64(num)
+	}
+
+	cursor := r.FormValue("cursor")
+	apiKeys, err := a.apiKeyUsecase.Fetch(r.Context(), cursor, defaultNum, merchantID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, apiKeys)
+}
+
+func (a *apiKeyAPI) Revoke(w http.ResponseWriter, r *http.Request) {
+	merchantID := mux.Vars(r)["id"]
+	apiKeyID := mux.Vars(r)["keyID"]
+	token := r.Header.Get("token")
+	credential, err := a.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := a.authUsecase.VerifiedAsCustomer(credential); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := a.authUsecase.VerifiedMerchantOwner(credential, merchantID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	apiKey, err := a.apiKeyUsecase.Revoke(ctx, apiKeyID, merchantID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, apiKey)
+}
@@ -0,0 +1,66 @@
+package http_api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/infrastructure/http_api/http_response"
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/logic"
+)
+
+type AuditLogAPI interface {
+	Fetch(w http.ResponseWriter, r *http.Request)
+}
+
+type auditLogAPI struct {
+	auditLogUsecase logic.AuditLogUsecase
+	authUsecase     logic.AuthenticationUsecase
+}
+
+func NewAuditLogAPI(
+	auditLogUsecase logic.AuditLogUsecase,
+	authUsecase logic.AuthenticationUsecase,
+) AuditLogAPI {
+	return &auditLogAPI{
+		auditLogUsecase: auditLogUsecase,
+		authUsecase:     authUsecase,
+	}
+}
+
+func (a *auditLogAPI) Fetch(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("token")
+	credential, err := a.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := a.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_READ_AUDIT_LOG); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	search := adapter.AuditLogSearchOptions{
+		ActorID:    r.FormValue("actor_id"),
+		Action:     r.FormValue("action"),
+		TargetType: r.FormValue("target_type"),
+		TargetID:   r.FormValue("target_id"),
+		RequestID:  r.FormValue("request_id"),
+		From:       r.FormValue("from"),
+		To:         r.FormValue("to"),
+	}
+	var defaultNum int64 = 10
+	if num, err := strconv.Atoi(r.FormValue("num")); err == nil {
+		defaultNum = int64(num)
+	}
+
+	cursor := r.FormValue("cursor")
+	auditLogs, err := a.auditLogUsecase.Fetch(r.Context(), cursor, defaultNum, search)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, auditLogs)
+}
@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	"github.com/market-place/domain"
 	"github.com/market-place/infrastructure/http_api/http_response"
 	adapterJSON "github.com/market-place/usecase/adapter/json"
 	"github.com/market-place/usecase/helper"
@@ -13,6 +14,7 @@ import (
 type AuthAPI interface {
 	CustomerLogin(w http.ResponseWriter, r *http.Request)
 	AdminLogin(w http.ResponseWriter, r *http.Request)
+	VerifyLoginChallenge(w http.ResponseWriter, r *http.Request)
 }
 
 type authAPI struct {
@@ -39,18 +41,39 @@ func (a *authAPI) CustomerLogin(w http.ResponseWriter, r *http.Request) {
 		http_response.SendErrJSON(w, err)
 		return
 	}
+	if input.GuestCartToken == "" {
+		input.GuestCartToken = guestCartToken(r)
+	}
 
-	credential, err := a.authUsecase.LoginCustomer(r.Context(), input)
+	credential, challenge, err := a.authUsecase.LoginCustomer(r.Context(), input)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
 	}
+	if challenge.UserID != "" {
+		challengeToken, err := helper.EncodeChallengeToken(challenge)
+		if err != nil {
+			http_response.SendErrJSON(w, err)
+			return
+		}
+
+		res := map[string]interface{}{
+			"challenge_token": challengeToken,
+			"enrolled":        challenge.Enrolled,
+		}
+		http_response.SendOkJSON(w, http.StatusOK, res)
+		return
+	}
 
 	token, err := helper.EncodeToken(credential)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
 	}
+	//guest cart is merged into customer cart
+	if input.GuestCartToken != "" && credential.LoginType == domain.LOGIN_AS_CUSTOMER {
+		clearGuestCartCookie(w)
+	}
 
 	res := map[string]interface{}{
 		"token":      token,
@@ -71,7 +94,45 @@ func (a *authAPI) AdminLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	credential, err := a.authUsecase.LoginAdmin(r.Context(), input)
+	challenge, enrollment, err := a.authUsecase.LoginAdmin(r.Context(), input)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	challengeToken, err := helper.EncodeChallengeToken(challenge)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	res := map[string]interface{}{
+		"challenge_token": challengeToken,
+		"enrolled":        challenge.Enrolled,
+	}
+	if !challenge.Enrolled {
+		res["enrollment"] = enrollment
+	}
+	http_response.SendOkJSON(w, http.StatusOK, res)
+}
+
+func (a *authAPI) VerifyLoginChallenge(w http.ResponseWriter, r *http.Request) {
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	input, err := a.serialize.DecodeLoginChallengeInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if input.GuestCartToken == "" {
+		input.GuestCartToken = guestCartToken(r)
+	}
+
+	credential, recoveryCodes, err := a.authUsecase.VerifyLoginChallenge(r.Context(), input)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -82,10 +143,18 @@ func (a *authAPI) AdminLogin(w http.ResponseWriter, r *http.Request) {
 		http_response.SendErrJSON(w, err)
 		return
 	}
+	//guest cart is merged into customer cart
+	if input.GuestCartToken != "" && credential.LoginType == domain.LOGIN_AS_CUSTOMER {
+		clearGuestCartCookie(w)
+	}
 
 	res := map[string]interface{}{
 		"token":      token,
 		"credential": credential,
 	}
+	//recovery codes only shown once, when enrollment is finished
+	if recoveryCodes != nil {
+		res["recovery_codes"] = recoveryCodes
+	}
 	http_response.SendOkJSON(w, http.StatusOK, res)
 }
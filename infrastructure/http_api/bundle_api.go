@@ -0,0 +1,104 @@
+package http_api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/market-place/domain"
+	"github.com/market-place/infrastructure/http_api/http_response"
+	"github.com/market-place/usecase/adapter"
+	adapterJSON "github.com/market-place/usecase/adapter/json"
+	"github.com/market-place/usecase/logic"
+)
+
+type BundleAPI interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	UpdateOne(w http.ResponseWriter, r *http.Request)
+}
+
+type bundleAPI struct {
+	bundleUsecase logic.BundleUsecase
+	authUsecase   logic.AuthenticationUsecase
+	serialize     adapter.BundleAdapter
+}
+
+func NewBundleAPI(
+	bundleUsecase logic.BundleUsecase,
+	authUsecase logic.AuthenticationUsecase,
+) BundleAPI {
+	return &bundleAPI{
+		bundleUsecase: bundleUsecase,
+		authUsecase:   authUsecase,
+		serialize:     &adapterJSON.AdapterBundleJSON{},
+	}
+}
+
+func (b *bundleAPI) Create(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("token")
+	credential, err := b.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	ctx := context.WithValue(r.Context(), "credential", credential)
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	input, err := b.serialize.DecodeCreateInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	bundle, err := b.bundleUsecase.Create(ctx, input)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusCreated, bundle)
+}
+
+func (b *bundleAPI) UpdateOne(w http.ResponseWriter, r *http.Request) {
+	bundleID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	apiKey := r.Header.Get("api-key")
+	credential, err := b.authUsecase.ValidateLoginOrAPIKey(token, apiKey)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := b.authUsecase.VerifiedAPIKeyScope(credential, domain.API_KEY_SCOPE_PRODUCTS_WRITE); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := b.authUsecase.VerifiedProductOwner(r.Context(), credential, bundleID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	input, err := b.serialize.DecodeUpdateInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	bundle, err := b.bundleUsecase.UpdateOne(ctx, input, bundleID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, bundle)
+}
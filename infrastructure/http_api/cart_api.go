@@ -17,6 +17,10 @@ type CartAPI interface {
 	UpdateItemInCart(w http.ResponseWriter, r *http.Request)
 	RemoveProduct(w http.ResponseWriter, r *http.Request)
 	ClearProduct(w http.ResponseWriter, r *http.Request)
+	AcknowledgeChanges(w http.ResponseWriter, r *http.Request)
+	Summary(w http.ResponseWriter, r *http.Request)
+	SaveForLater(w http.ResponseWriter, r *http.Request)
+	MoveToCart(w http.ResponseWriter, r *http.Request)
 }
 
 type cartAPI struct {
@@ -94,6 +98,7 @@ func (c *cartAPI) AddProduct(w http.ResponseWriter, r *http.Request) {
 
 func (c *cartAPI) UpdateItemInCart(w http.ResponseWriter, r *http.Request) {
 	productID := mux.Vars(r)["pID"]
+	sku := r.FormValue("sku")
 	cartID := mux.Vars(r)["id"]
 	token := r.Header.Get("token")
 	credential, err := c.authUsecase.ValidateLogin(token)
@@ -118,7 +123,7 @@ func (c *cartAPI) UpdateItemInCart(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ctx := context.WithValue(r.Context(), "credential", credential)
-	cart, err := c.cartUsecase.UpdateItemInCart(ctx, input, productID, cartID)
+	cart, err := c.cartUsecase.UpdateItemInCart(ctx, input, productID, sku, cartID)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -129,6 +134,7 @@ func (c *cartAPI) UpdateItemInCart(w http.ResponseWriter, r *http.Request) {
 
 func (c *cartAPI) RemoveProduct(w http.ResponseWriter, r *http.Request) {
 	productID := mux.Vars(r)["pID"]
+	sku := r.FormValue("sku")
 	cartID := mux.Vars(r)["id"]
 	token := r.Header.Get("token")
 	credential, err := c.authUsecase.ValidateLogin(token)
@@ -141,7 +147,7 @@ func (c *cartAPI) RemoveProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cart, err := c.cartUsecase.RemoveProduct(r.Context(), productID, cartID)
+	cart, err := c.cartUsecase.RemoveProduct(r.Context(), productID, sku, cartID)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -169,3 +175,104 @@ func (c *cartAPI) ClearProduct(w http.ResponseWriter, r *http.Request) {
 	}
 	http_response.SendOkJSON(w, http.StatusOK, cart)
 }
+
+func (c *cartAPI) AcknowledgeChanges(w http.ResponseWriter, r *http.Request) {
+	cartID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := c.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := c.authUsecase.VerifiedCartOwner(credential, cartID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	cart, err := c.cartUsecase.AcknowledgeChanges(r.Context(), cartID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	http_response.SendOkJSON(w, http.StatusOK, cart)
+}
+
+func (c *cartAPI) Summary(w http.ResponseWriter, r *http.Request) {
+	cartID := mux.Vars(r)["id"]
+	addressID := r.FormValue("address_id")
+	token := r.Header.Get("token")
+	credential, err := c.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := c.authUsecase.VerifiedCartOwner(credential, cartID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	summary, err := c.cartUsecase.Summary(ctx, cartID, addressID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	http_response.SendOkJSON(w, http.StatusOK, summary)
+}
+
+func (c *cartAPI) SaveForLater(w http.ResponseWriter, r *http.Request) {
+	productID := mux.Vars(r)["pID"]
+	sku := r.FormValue("sku")
+	cartID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := c.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := c.authUsecase.VerifiedAsCustomer(credential); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := c.authUsecase.VerifiedCartOwner(credential, cartID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	wishlistItem, err := c.cartUsecase.SaveForLater(ctx, productID, sku, cartID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusCreated, wishlistItem)
+}
+
+func (c *cartAPI) MoveToCart(w http.ResponseWriter, r *http.Request) {
+	productID := mux.Vars(r)["pID"]
+	cartID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := c.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := c.authUsecase.VerifiedAsCustomer(credential); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := c.authUsecase.VerifiedCartOwner(credential, cartID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	cart, err := c.cartUsecase.MoveToCart(ctx, productID, cartID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, cart)
+}
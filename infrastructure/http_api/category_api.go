@@ -0,0 +1,148 @@
+package http_api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/market-place/domain"
+	"github.com/market-place/infrastructure/http_api/http_response"
+	"github.com/market-place/usecase/adapter"
+	adapterJSON "github.com/market-place/usecase/adapter/json"
+	"github.com/market-place/usecase/logic"
+)
+
+type CategoryAPI interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	FetchTree(w http.ResponseWriter, r *http.Request)
+	GetByID(w http.ResponseWriter, r *http.Request)
+	UpdateOne(w http.ResponseWriter, r *http.Request)
+	DeleteOne(w http.ResponseWriter, r *http.Request)
+}
+
+type categoryAPI struct {
+	categoryUsecase logic.CategoryUsecase
+	authUsecase     logic.AuthenticationUsecase
+	serialize       adapter.CategoryAdapter
+}
+
+func NewCategoryAPI(
+	categoryUsecase logic.CategoryUsecase,
+	authUsecase logic.AuthenticationUsecase,
+) CategoryAPI {
+	return &categoryAPI{
+		categoryUsecase: categoryUsecase,
+		authUsecase:     authUsecase,
+		serialize:       &adapterJSON.AdapterCategoryJSON{},
+	}
+}
+
+func (c *categoryAPI) Create(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("token")
+	credential, err := c.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := c.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_MANAGE_CATEGORY); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	input, err := c.serialize.DecodeCreateInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	category, err := c.categoryUsecase.Create(ctx, input)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusCreated, category)
+}
+
+func (c *categoryAPI) FetchTree(w http.ResponseWriter, r *http.Request) {
+	categories, err := c.categoryUsecase.FetchTree(r.Context())
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, categories)
+}
+
+func (c *categoryAPI) GetByID(w http.ResponseWriter, r *http.Request) {
+	categoryID := mux.Vars(r)["id"]
+	category, err := c.categoryUsecase.GetByID(r.Context(), categoryID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, category)
+}
+
+func (c *categoryAPI) UpdateOne(w http.ResponseWriter, r *http.Request) {
+	categoryID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := c.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := c.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_MANAGE_CATEGORY); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	input, err := c.serialize.DecodeUpdateInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	category, err := c.categoryUsecase.UpdateOne(ctx, input, categoryID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, category)
+}
+
+func (c *categoryAPI) DeleteOne(w http.ResponseWriter, r *http.Request) {
+	categoryID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := c.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := c.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_MANAGE_CATEGORY); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	category, err := c.categoryUsecase.DeleteOne(ctx, categoryID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, category)
+}
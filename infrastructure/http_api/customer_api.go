@@ -1,6 +1,7 @@
 package http_api
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 
 	"cloud.google.com/go/storage"
 	"github.com/gorilla/mux"
+	"github.com/market-place/domain"
 	"github.com/market-place/infrastructure/http_api/helper"
 	"github.com/market-place/infrastructure/http_api/http_response"
 	"github.com/market-place/usecase/adapter"
@@ -81,7 +83,7 @@ func (c *customerAPI) Fetch(w http.ResponseWriter, r *http.Request) {
 		http_response.SendErrJSON(w, err)
 		return
 	}
-	if err := c.authUsecase.VerifiedAsAdmin(credential); err != nil {
+	if err := c.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_READ_CUSTOMER); err != nil {
 		http_response.SendErrJSON(w, err)
 		return
 	}
@@ -115,7 +117,7 @@ func (c *customerAPI) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := c.authUsecase.VerifiedCustomerAuthor(credential, customerID); err != nil {
-		if err := c.authUsecase.VerifiedAsAdmin(credential); err != nil {
+		if err := c.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_READ_CUSTOMER); err != nil {
 			http_response.SendErrJSON(w, err)
 			return
 		}
@@ -154,7 +156,8 @@ func (c *customerAPI) UpdateBiodata(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	customer, err := c.customerUsecase.UpdateBiodata(r.Context(), input, customerID)
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	customer, err := c.customerUsecase.UpdateBiodata(ctx, input, customerID)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -187,7 +190,8 @@ func (c *customerAPI) UpdatePassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	customer, err := c.customerUsecase.UpdatePassword(r.Context(), input, customerID)
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	customer, err := c.customerUsecase.UpdatePassword(ctx, input, customerID)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -290,7 +294,8 @@ func (c *customerAPI) AddBankAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	customer, err := c.customerUsecase.AddBankAccount(r.Context(), input, customerID)
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	customer, err := c.customerUsecase.AddBankAccount(ctx, input, customerID)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -325,7 +330,8 @@ func (c *customerAPI) UpdateBankaccount(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	customer, err := c.customerUsecase.UpdateBankAccount(r.Context(), input, accountBankID, customerID)
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	customer, err := c.customerUsecase.UpdateBankAccount(ctx, input, accountBankID, customerID)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -0,0 +1,129 @@
+package http_api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/market-place/domain"
+	"github.com/market-place/infrastructure/http_api/http_response"
+	"github.com/market-place/usecase/adapter"
+	adapterJSON "github.com/market-place/usecase/adapter/json"
+	"github.com/market-place/usecase/logic"
+)
+
+type FlashSaleAPI interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	Fetch(w http.ResponseWriter, r *http.Request)
+	GetByID(w http.ResponseWriter, r *http.Request)
+	DeleteOne(w http.ResponseWriter, r *http.Request)
+}
+
+type flashSaleAPI struct {
+	flashSaleUsecase logic.FlashSaleUsecase
+	authUsecase      logic.AuthenticationUsecase
+	serialize        adapter.FlashSaleAdapter
+}
+
+func NewFlashSaleAPI(
+	flashSaleUsecase logic.FlashSaleUsecase,
+	authUsecase logic.AuthenticationUsecase,
+) FlashSaleAPI {
+	return &flashSaleAPI{
+		flashSaleUsecase: flashSaleUsecase,
+		authUsecase:      authUsecase,
+		serialize:        &adapterJSON.AdapterFlashSaleJSON{},
+	}
+}
+
+// validateProductOwner accept merchant login or api key with products write scope of the product
+func (f *flashSaleAPI) validateProductOwner(r *http.Request, productID string) (domain.Credential, error) {
+	token := r.Header.Get("token")
+	apiKey := r.Header.Get("api-key")
+	credential, err := f.authUsecase.ValidateLoginOrAPIKey(token, apiKey)
+	if err != nil {
+		return credential, err
+	}
+	if err := f.authUsecase.VerifiedAPIKeyScope(credential, domain.API_KEY_SCOPE_PRODUCTS_WRITE); err != nil {
+		return credential, err
+	}
+	if err := f.authUsecase.VerifiedProductOwner(r.Context(), credential, productID); err != nil {
+		return credential, err
+	}
+	return credential, nil
+}
+
+func (f *flashSaleAPI) Create(w http.ResponseWriter, r *http.Request) {
+	productID := mux.Vars(r)["id"]
+	credential, err := f.validateProductOwner(r, productID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	input, err := f.serialize.DecodeCreateInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	flashSale, err := f.flashSaleUsecase.Create(ctx, input, productID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusCreated, flashSale)
+}
+
+// Fetch is public schedule of ongoing and upcoming flash sales
+func (f *flashSaleAPI) Fetch(w http.ResponseWriter, r *http.Request) {
+	search := adapter.FlashSaleSearchOptions{
+		ProductID:  r.FormValue("product_id"),
+		MerchantID: r.FormValue("merchant_id"),
+	}
+
+	flashSales, err := f.flashSaleUsecase.Fetch(r.Context(), search)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, flashSales)
+}
+
+func (f *flashSaleAPI) GetByID(w http.ResponseWriter, r *http.Request) {
+	flashSaleID := mux.Vars(r)["id"]
+	flashSale, err := f.flashSaleUsecase.GetByID(r.Context(), flashSaleID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, flashSale)
+}
+
+func (f *flashSaleAPI) DeleteOne(w http.ResponseWriter, r *http.Request) {
+	productID := mux.Vars(r)["id"]
+	flashSaleID := mux.Vars(r)["flashSaleID"]
+	credential, err := f.validateProductOwner(r, productID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	flashSale, err := f.flashSaleUsecase.DeleteOne(ctx, productID, flashSaleID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, flashSale)
+}
@@ -0,0 +1,164 @@
+package http_api
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/market-place/domain"
+	"github.com/market-place/infrastructure/http_api/http_response"
+	adapterJSON "github.com/market-place/usecase/adapter/json"
+	"github.com/market-place/usecase/logic"
+)
+
+// guest cart token is read from header first, browser client can rely on the cookie
+const (
+	guestCartHeader = "guest-cart"
+	guestCartCookie = "guest_cart"
+)
+
+func guestCartToken(r *http.Request) string {
+	if token := r.Header.Get(guestCartHeader); token != "" {
+		return token
+	}
+	if cookie, err := r.Cookie(guestCartCookie); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+func setGuestCartCookie(w http.ResponseWriter, token string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     guestCartCookie,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   int(domain.GUEST_CART_DURATION.Seconds()),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func clearGuestCartCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     guestCartCookie,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+type GuestCartAPI interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	Get(w http.ResponseWriter, r *http.Request)
+	AddProduct(w http.ResponseWriter, r *http.Request)
+	UpdateItemInCart(w http.ResponseWriter, r *http.Request)
+	RemoveProduct(w http.ResponseWriter, r *http.Request)
+	ClearProduct(w http.ResponseWriter, r *http.Request)
+}
+
+type guestCartAPI struct {
+	guestCartUsecase logic.GuestCartUsecase
+	serialize        adapterJSON.AdapterCartJSON
+}
+
+func NewGuestCartAPI(
+	guestCartUsecase logic.GuestCartUsecase,
+) GuestCartAPI {
+	return &guestCartAPI{
+		guestCartUsecase: guestCartUsecase,
+		serialize:        adapterJSON.AdapterCartJSON{},
+	}
+}
+
+func (g *guestCartAPI) Create(w http.ResponseWriter, r *http.Request) {
+	cart, token, err := g.guestCartUsecase.Create(r.Context())
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	setGuestCartCookie(w, token)
+	res := map[string]interface{}{
+		"guest_cart_token": token,
+		"cart":             cart,
+	}
+	http_response.SendOkJSON(w, http.StatusCreated, res)
+}
+
+func (g *guestCartAPI) Get(w http.ResponseWriter, r *http.Request) {
+	cart, err := g.guestCartUsecase.GetByToken(r.Context(), guestCartToken(r))
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, cart)
+}
+
+func (g *guestCartAPI) AddProduct(w http.ResponseWriter, r *http.Request) {
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	input, err := g.serialize.DecodeAddItemInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	cart, err := g.guestCartUsecase.AddProduct(r.Context(), input, guestCartToken(r))
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusCreated, cart)
+}
+
+func (g *guestCartAPI) UpdateItemInCart(w http.ResponseWriter, r *http.Request) {
+	productID := mux.Vars(r)["pID"]
+	sku := r.FormValue("sku")
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	input, err := g.serialize.DecodeUpdateInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	cart, err := g.guestCartUsecase.UpdateItemInCart(r.Context(), input, productID, sku, guestCartToken(r))
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, cart)
+}
+
+func (g *guestCartAPI) RemoveProduct(w http.ResponseWriter, r *http.Request) {
+	productID := mux.Vars(r)["pID"]
+	sku := r.FormValue("sku")
+	cart, err := g.guestCartUsecase.RemoveProduct(r.Context(), productID, sku, guestCartToken(r))
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, cart)
+}
+
+func (g *guestCartAPI) ClearProduct(w http.ResponseWriter, r *http.Request) {
+	cart, err := g.guestCartUsecase.ClearProduct(r.Context(), guestCartToken(r))
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, cart)
+}
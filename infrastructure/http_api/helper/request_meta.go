@@ -0,0 +1,93 @@
+package helper
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	guuid "github.com/google/uuid"
+)
+
+const REQUEST_ID_HEADER = "X-Request-ID"
+
+// RequestMeta put client ip, user agent and request id into request context, used by usecase audit log and session.
+// Request id from client is kept so a request can be traced across services.
+func RequestMeta(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(REQUEST_ID_HEADER)
+		if requestID == "" {
+			requestID = guuid.New().String()
+		}
+		w.Header().Set(REQUEST_ID_HEADER, requestID)
+
+		ctx := context.WithValue(r.Context(), "request_id", requestID)
+		ctx = context.WithValue(ctx, "ip", clientIP(r))
+		ctx = context.WithValue(ctx, "user_agent", r.UserAgent())
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// trustedProxies are ip or cidr of reverse proxies in front of the app, comma separated in TRUSTED_PROXIES.
+// X-Forwarded-For is only honoured for request coming from them, otherwise client could forge its ip
+var trustedProxies = parseTrustedProxies(os.Getenv("TRUSTED_PROXIES"))
+
+func parseTrustedProxies(value string) []*net.IPNet {
+	proxies := []*net.IPNet{}
+	for _, proxy := range strings.Split(value, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		if _, network, err := net.ParseCIDR(proxy); err == nil {
+			proxies = append(proxies, network)
+		}
+	}
+	return proxies
+}
+
+func isTrustedProxy(host string) bool {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range trustedProxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP use X-Forwarded-For only when request is sent by trusted proxy,
+// the nearest address which is not a trusted proxy is the client
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if !isTrustedProxy(host) {
+		return host
+	}
+
+	forwarded := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		address := strings.TrimSpace(forwarded[i])
+		if address == "" {
+			continue
+		}
+		if !isTrustedProxy(address) {
+			return address
+		}
+		host = address
+	}
+	return host
+}
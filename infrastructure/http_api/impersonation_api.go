@@ -0,0 +1,91 @@
+package http_api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/market-place/domain"
+	"github.com/market-place/infrastructure/http_api/http_response"
+	"github.com/market-place/usecase/helper"
+	"github.com/market-place/usecase/logic"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type ImpersonationAPI interface {
+	Start(w http.ResponseWriter, r *http.Request)
+	// Guard refuse every mutation made with impersonation credential and audit log the others
+	Guard(next http.Handler) http.Handler
+}
+
+type impersonationAPI struct {
+	impersonationUsecase logic.ImpersonationUsecase
+	authUsecase          logic.AuthenticationUsecase
+}
+
+func NewImpersonationAPI(
+	impersonationUsecase logic.ImpersonationUsecase,
+	authUsecase logic.AuthenticationUsecase,
+) ImpersonationAPI {
+	return &impersonationAPI{
+		impersonationUsecase: impersonationUsecase,
+		authUsecase:          authUsecase,
+	}
+}
+
+func (i *impersonationAPI) Start(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := i.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := i.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_IMPERSONATE_CUSTOMER); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	impersonation, err := i.impersonationUsecase.Start(ctx, customerID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	impersonationToken, err := helper.EncodeToken(impersonation)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	res := map[string]interface{}{
+		"token":      impersonationToken,
+		"credential": impersonation,
+	}
+	http_response.SendOkJSON(w, http.StatusCreated, res)
+}
+
+func (i *impersonationAPI) Guard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("token")
+		if token == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		//token is fully validated by handler, here only signed impersonation flag is needed
+		credential, err := helper.DecodeToken(token)
+		if err != nil || !credential.IsImpersonation() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		i.impersonationUsecase.RecordRequest(r.Context(), credential, r.Method, r.URL.Path)
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+		default:
+			http_response.SendErrJSON(w, usecase_error.ErrNotAuthorization)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package http_api
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"log"
 	"net/http"
@@ -14,6 +15,7 @@ import (
 	"github.com/gorilla/mux"
 	infrastructureConfig "github.com/market-place/config/infrastructure_config"
 	usecaseConfig "github.com/market-place/config/usecase_config"
+	"github.com/market-place/infrastructure/http_api/helper"
 )
 
 type HttpConfig interface {
@@ -30,11 +32,102 @@ func NewHttpAPI(
 	infrastructureConf infrastructureConfig.InfrastructureConfig,
 ) HttpConfig {
 
+	r.Use(helper.RequestMeta)
+
+	//impersonation routing, guard run before every handler
+	{
+		impersonationHandler := NewImpersonationAPI(
+			usecaseConfig.GetImpersonationUsecase(),
+			usecaseConfig.GetAuthUsecase(),
+		)
+		r.Use(impersonationHandler.Guard)
+		r.HandleFunc("/customers/{id}/impersonations", impersonationHandler.Start).Methods("POST")
+	}
+
 	//authentication routing
 	{
 		authHandler := NewAuthAPI(usecaseConfig.GetAuthUsecase())
 		r.HandleFunc("/login-customers", authHandler.CustomerLogin).Methods("POST")
 		r.HandleFunc("/login-admins", authHandler.AdminLogin).Methods("POST")
+		r.HandleFunc("/login-challenges", authHandler.VerifyLoginChallenge).Methods("POST")
+	}
+
+	//openid connect routing
+	{
+		oidcHandler := NewOIDCAPI(
+			usecaseConfig.GetOIDCUsecase(),
+			usecaseConfig.GetAuthUsecase(),
+		)
+		r.HandleFunc("/oidc/{provider}/authorize", oidcHandler.Authorize).Methods("GET")
+		r.HandleFunc("/oidc/{provider}/callback", oidcHandler.Callback).Methods("POST")
+		r.HandleFunc("/customers/{id}/profile", oidcHandler.CompleteProfile).Methods("PUT")
+		r.HandleFunc("/customers/{id}/identities/{provider}/authorize", oidcHandler.AuthorizeLink).Methods("GET")
+		r.HandleFunc("/customers/{id}/identities/{provider}", oidcHandler.Link).Methods("POST")
+		r.HandleFunc("/customers/{id}/identities/{provider}", oidcHandler.Unlink).Methods("DELETE")
+	}
+
+	//suspension routing
+	{
+		suspensionHandler := NewSuspensionAPI(
+			usecaseConfig.GetSuspensionUsecase(),
+			usecaseConfig.GetAuthUsecase(),
+		)
+		r.HandleFunc("/customers/{id}/suspension", suspensionHandler.SuspendCustomer).Methods("PUT")
+		r.HandleFunc("/customers/{id}/suspension", suspensionHandler.LiftCustomer).Methods("DELETE")
+		r.HandleFunc("/merchants/{id}/suspension", suspensionHandler.SuspendMerchant).Methods("PUT")
+		r.HandleFunc("/merchants/{id}/suspension", suspensionHandler.LiftMerchant).Methods("DELETE")
+	}
+
+	//two factor routing
+	{
+		twoFactorHandler := NewTwoFactorAPI(
+			usecaseConfig.GetTwoFactorUsecase(),
+			usecaseConfig.GetAuthUsecase(),
+		)
+		r.HandleFunc("/customers/{id}/two-factor", twoFactorHandler.Enroll).Methods("POST")
+		r.HandleFunc("/customers/{id}/two-factor", twoFactorHandler.Confirm).Methods("PUT")
+		r.HandleFunc("/customers/{id}/two-factor", twoFactorHandler.Disable).Methods("DELETE")
+	}
+
+	//phone verification routing
+	{
+		phoneVerificationHandler := NewPhoneVerificationAPI(
+			usecaseConfig.GetPhoneVerificationUsecase(),
+			usecaseConfig.GetAuthUsecase(),
+		)
+		r.HandleFunc("/customers/{id}/phone-verification", phoneVerificationHandler.Send).Methods("POST")
+		r.HandleFunc("/customers/{id}/phone-verification", phoneVerificationHandler.Verify).Methods("PUT")
+	}
+
+	//api key routing
+	{
+		apiKeyHandler := NewAPIKeyAPI(
+			usecaseConfig.GetAPIKeyUsecase(),
+			usecaseConfig.GetAuthUsecase(),
+		)
+		r.HandleFunc("/merchants/{id}/api-keys", apiKeyHandler.Create).Methods("POST")
+		r.HandleFunc("/merchants/{id}/api-keys", apiKeyHandler.Fetch).Methods("GET")
+		r.HandleFunc("/merchants/{id}/api-keys/{keyID}", apiKeyHandler.Revoke).Methods("DELETE")
+	}
+
+	//session routing
+	{
+		sessionHandler := NewSessionAPI(
+			usecaseConfig.GetSessionUsecase(),
+			usecaseConfig.GetAuthUsecase(),
+		)
+		r.HandleFunc("/customers/{id}/sessions", sessionHandler.Fetch).Methods("GET")
+		r.HandleFunc("/customers/{id}/sessions", sessionHandler.RevokeAll).Methods("DELETE")
+		r.HandleFunc("/customers/{id}/sessions/{sID}", sessionHandler.Revoke).Methods("DELETE")
+	}
+
+	//audit log routing
+	{
+		auditLogHandler := NewAuditLogAPI(
+			usecaseConfig.GetAuditLogUsecase(),
+			usecaseConfig.GetAuthUsecase(),
+		)
+		r.HandleFunc("/audit-logs", auditLogHandler.Fetch).Methods("GET")
 	}
 
 	//city
@@ -80,12 +173,61 @@ func NewHttpAPI(
 		r.HandleFunc("/admins/{id}", adminHandler.GetByID).Methods("GET")
 		r.HandleFunc("/admins/{id}", adminHandler.UpdateBiodata).Methods("PUT")
 		r.HandleFunc("/admins/{id}/password", adminHandler.UpdatePassword).Methods("PUT")
+		r.HandleFunc("/admins/{id}/role", adminHandler.UpdateRole).Methods("PUT")
 		r.HandleFunc("/admins/{id}/photo-profile", adminHandler.UploadPhotoProfile).Methods("PUT")
 		r.HandleFunc("/admins/{id}/addresses", adminHandler.AddAddress).Methods("POST")
 		r.HandleFunc("/admins/{id}/addresses/{addID}", adminHandler.UpdateAddress).Methods("PUT")
 		r.HandleFunc("/admins/{id}/addresses/{addID}", adminHandler.DeleteAddress).Methods("DELETE")
 	}
 
+	//categories routing
+	{
+		categoryHandler := NewCategoryAPI(
+			usecaseConfig.GetCategoryUsecase(),
+			usecaseConfig.GetAuthUsecase(),
+		)
+		r.HandleFunc("/categories", categoryHandler.Create).Methods("POST")
+		r.HandleFunc("/categories", categoryHandler.FetchTree).Methods("GET")
+		r.HandleFunc("/categories/{id}", categoryHandler.GetByID).Methods("GET")
+		r.HandleFunc("/categories/{id}", categoryHandler.UpdateOne).Methods("PUT")
+		r.HandleFunc("/categories/{id}", categoryHandler.DeleteOne).Methods("DELETE")
+	}
+
+	//vouchers routing
+	{
+		voucherHandler := NewVoucherAPI(
+			usecaseConfig.GetVoucherUsecase(),
+			usecaseConfig.GetAuthUsecase(),
+		)
+		r.HandleFunc("/vouchers", voucherHandler.Create).Methods("POST")
+		r.HandleFunc("/vouchers", voucherHandler.Fetch).Methods("GET")
+		r.HandleFunc("/vouchers/{id}", voucherHandler.GetByID).Methods("GET")
+		r.HandleFunc("/vouchers/{id}", voucherHandler.UpdateOne).Methods("PUT")
+		r.HandleFunc("/vouchers/{id}", voucherHandler.DeleteOne).Methods("DELETE")
+	}
+
+	//flash sales routing
+	{
+		flashSaleHandler := NewFlashSaleAPI(
+			usecaseConfig.GetFlashSaleUsecase(),
+			usecaseConfig.GetAuthUsecase(),
+		)
+		r.HandleFunc("/flash-sales", flashSaleHandler.Fetch).Methods("GET")
+		r.HandleFunc("/flash-sales/{id}", flashSaleHandler.GetByID).Methods("GET")
+		r.HandleFunc("/products/{id}/flash-sales", flashSaleHandler.Create).Methods("POST")
+		r.HandleFunc("/products/{id}/flash-sales/{flashSaleID}", flashSaleHandler.DeleteOne).Methods("DELETE")
+	}
+
+	//bundles routing, bundle is read and deleted through products routing
+	{
+		bundleHandler := NewBundleAPI(
+			usecaseConfig.GetBundleUsecase(),
+			usecaseConfig.GetAuthUsecase(),
+		)
+		r.HandleFunc("/bundles", bundleHandler.Create).Methods("POST")
+		r.HandleFunc("/bundles/{id}", bundleHandler.UpdateOne).Methods("PUT")
+	}
+
 	//shippings routing
 	{
 		shippingHandler := NewShippingAPI(
@@ -129,10 +271,28 @@ func NewHttpAPI(
 		r.HandleFunc("/products", productHandler.Create).Methods("POST")
 		r.HandleFunc("/products", productHandler.Fetch).Methods("GET")
 		r.HandleFunc("/products/terlaris", productHandler.ProductTerlaris).Methods("GET")
+		r.HandleFunc("/products/stock-price", productHandler.BulkUpdateStockPrice).Methods("PATCH")
 		r.HandleFunc("/products/{id}", productHandler.GetByID).Methods("GET")
 		r.HandleFunc("/products/{id}", productHandler.UpdateData).Methods("PUT")
 		r.HandleFunc("/products/{id}/photos", productHandler.UploadPhotos).Methods("PUT")
+		r.HandleFunc("/products/{id}/status", productHandler.UpdateStatus).Methods("PUT")
+		r.HandleFunc("/products/{id}/takedown", productHandler.TakeDown).Methods("PUT")
+		r.HandleFunc("/products/{id}/takedown", productHandler.LiftTakeDown).Methods("DELETE")
 		r.HandleFunc("/products/{id}", productHandler.DeleteOne).Methods("DELETE")
+		r.HandleFunc("/products/{id}/restore", productHandler.Restore).Methods("PUT")
+		r.HandleFunc("/products/{id}/discounts", productHandler.AddDiscount).Methods("POST")
+		r.HandleFunc("/products/{id}/discounts/{discountID}", productHandler.RemoveDiscount).Methods("DELETE")
+	}
+
+	//guest cart routing, cart is identified by guest cart token instead of login token
+	{
+		guestCartHandler := NewGuestCartAPI(usecaseConfig.GetGuestCartUsecase())
+		r.HandleFunc("/guest-cart", guestCartHandler.Create).Methods("POST")
+		r.HandleFunc("/guest-cart", guestCartHandler.Get).Methods("GET")
+		r.HandleFunc("/guest-cart/items", guestCartHandler.AddProduct).Methods("POST")
+		r.HandleFunc("/guest-cart/items/{pID}", guestCartHandler.UpdateItemInCart).Methods("PUT")
+		r.HandleFunc("/guest-cart/items", guestCartHandler.ClearProduct).Methods("DELETE")
+		r.HandleFunc("/guest-cart/items/{pID}", guestCartHandler.RemoveProduct).Methods("DELETE")
 	}
 
 	//cart routing
@@ -142,10 +302,46 @@ func NewHttpAPI(
 			usecaseConfig.GetAuthUsecase(),
 		)
 		r.HandleFunc("/carts/{id}", cartHandler.GetByID).Methods("GET")
+		r.HandleFunc("/carts/{id}/summary", cartHandler.Summary).Methods("GET")
 		r.HandleFunc("/carts/{id}/items", cartHandler.AddProduct).Methods("POST")
 		r.HandleFunc("/carts/{id}/items/{pID}", cartHandler.UpdateItemInCart).Methods("PUT")
 		r.HandleFunc("/carts/{id}/items", cartHandler.ClearProduct).Methods("DELETE")
 		r.HandleFunc("/carts/{id}/items/{pID}", cartHandler.RemoveProduct).Methods("DELETE")
+		r.HandleFunc("/carts/{id}/acknowledgement", cartHandler.AcknowledgeChanges).Methods("PUT")
+		r.HandleFunc("/carts/{id}/items/{pID}/save-for-later", cartHandler.SaveForLater).Methods("POST")
+		r.HandleFunc("/carts/{id}/items/{pID}/move-from-wishlist", cartHandler.MoveToCart).Methods("POST")
+	}
+
+	//wishlist routing
+	{
+		wishlistHandler := NewWishlistAPI(
+			usecaseConfig.GetWishlistUsecase(),
+			usecaseConfig.GetAuthUsecase(),
+		)
+		r.HandleFunc("/customers/{id}/wishlist", wishlistHandler.Fetch).Methods("GET")
+		r.HandleFunc("/customers/{id}/wishlist", wishlistHandler.Add).Methods("POST")
+		r.HandleFunc("/customers/{id}/wishlist/{pID}", wishlistHandler.Remove).Methods("DELETE")
+	}
+
+	//product import routing
+	{
+		productImportHandler := NewProductImportAPI(
+			usecaseConfig.GetProductImportUsecase(),
+			usecaseConfig.GetAuthUsecase(),
+		)
+		r.HandleFunc("/merchants/{id}/product-imports", productImportHandler.Import).Methods("POST")
+		r.HandleFunc("/merchants/{id}/product-imports/{jobID}", productImportHandler.GetJob).Methods("GET")
+		r.HandleFunc("/merchants/{id}/product-export", productImportHandler.Export).Methods("GET")
+	}
+
+	//notification routing
+	{
+		notificationHandler := NewNotificationAPI(
+			usecaseConfig.GetNotificationUsecase(),
+			usecaseConfig.GetAuthUsecase(),
+		)
+		r.HandleFunc("/customers/{id}/notifications", notificationHandler.Fetch).Methods("GET")
+		r.HandleFunc("/customers/{id}/notifications/{nID}/read", notificationHandler.MarkRead).Methods("PUT")
 	}
 
 	//order routing
@@ -213,13 +409,31 @@ func NewHttpAPI(
 		r.HandleFunc("/reviews-products", reviewProductHandler.Fetch).Methods("GET")
 	}
 
+	//metrics
+	{
+		authUsecase := usecaseConfig.GetAuthUsecase()
+		expvar.Publish("legacy_password_hashes", expvar.Func(func() interface{} {
+			count, err := authUsecase.LegacyPasswordHashCount(context.Background())
+			if err != nil {
+				return err.Error()
+			}
+			return count
+		}))
+		metricsHandler := NewMetricsAPI(authUsecase)
+		r.HandleFunc("/debug/vars", metricsHandler.Vars).Methods("GET")
+	}
+
 	//migrations
 	{
 		migrantionHandler := NewMigrationAPI(
 			infrastructureConf.GetElasticClient(),
+			infrastructureConf.GetMongoDBDatabase(),
+			usecaseConfig.GetAdminsUseCase(),
 		)
 		r.HandleFunc("/elastic-product-index", migrantionHandler.ElasticProductIndex).Methods("GET")
 		r.HandleFunc("/elastic-merchant-index", migrantionHandler.ElasticMerchantIndex).Methods("GET")
+		r.HandleFunc("/mongo-voucher-index", migrantionHandler.MongoVoucherIndex).Methods("GET")
+		r.HandleFunc("/migrate-admin-role", migrantionHandler.AdminRole).Methods("GET")
 	}
 
 	//seeder
@@ -227,6 +441,7 @@ func NewHttpAPI(
 		seederHandler := NewSeederAPI(
 			usecaseConfig.GetAdminsUseCase(),
 			usecaseConfig.GetShippingUsecase(),
+			usecaseConfig.GetCategoryUsecase(),
 			usecaseConfig.GetCustomersUseCase(),
 			usecaseConfig.GetMerchantUseCase(),
 			usecaseConfig.GetProductUseCase(),
@@ -238,6 +453,7 @@ func NewHttpAPI(
 		)
 		r.HandleFunc("/seed-admin", seederHandler.SeedSuperAdmin).Methods("GET")
 		r.HandleFunc("/seed-shipping", seederHandler.SeedShipping).Methods("GET")
+		r.HandleFunc("/seed-category", seederHandler.SeedCategory).Methods("GET")
 		r.HandleFunc("/seed-customer", seederHandler.SeedCustomer).Methods("GET")
 		r.HandleFunc("/seed-merchant", seederHandler.SeedMerchant).Methods("GET")
 		r.HandleFunc("/seed-product", seederHandler.SeedProduct).Methods("GET")
@@ -251,7 +467,7 @@ func NewHttpAPI(
 }
 
 func (h *httpConfig) StartServer(port string, readTimeOut, writeTimeOut time.Duration) error {
-	headersOk := handlers.AllowedHeaders([]string{"Access-Control-Allow-Headers", "Content-Type", "token"})
+	headersOk := handlers.AllowedHeaders([]string{"Access-Control-Allow-Headers", "Content-Type", "token", "api-key", "guest-cart"})
 	originsOk := handlers.AllowedOrigins([]string{"*"})
 	methodsOk := handlers.AllowedMethods([]string{"GET", "HEAD", "POST", "PUT", "OPTIONS", "DELETE"})
 
@@ -136,7 +136,8 @@ func (m *merchantAPI) UpdateData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	merchant, err := m.merchantUsecase.UpdateData(r.Context(), input, merchantID)
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	merchant, err := m.merchantUsecase.UpdateData(ctx, input, merchantID)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -221,7 +222,8 @@ func (m *merchantAPI) AddShipping(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	shipping, err := m.merchantUsecase.AddShipping(r.Context(), shippingID, merchantID)
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	shipping, err := m.merchantUsecase.AddShipping(ctx, shippingID, merchantID)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -243,7 +245,8 @@ func (m *merchantAPI) RemoveShipping(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	shipping, err := m.merchantUsecase.RemoveShipping(r.Context(), shippingID, merchantID)
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	shipping, err := m.merchantUsecase.RemoveShipping(ctx, shippingID, merchantID)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -275,7 +278,8 @@ func (m *merchantAPI) AddBankAccounts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	merchant, err := m.merchantUsecase.AddBankAccount(r.Context(), input, merchantID)
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	merchant, err := m.merchantUsecase.AddBankAccount(ctx, input, merchantID)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -365,7 +369,8 @@ func (m *merchantAPI) UpdateBankAccount(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	merchant, err := m.merchantUsecase.UpdateBankAccount(r.Context(), input, accountBankID, merchantID)
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	merchant, err := m.merchantUsecase.UpdateBankAccount(ctx, input, accountBankID, merchantID)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -0,0 +1,40 @@
+package http_api
+
+import (
+	"expvar"
+	"net/http"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/infrastructure/http_api/http_response"
+	"github.com/market-place/usecase/logic"
+)
+
+type MetricsAPI interface {
+	Vars(w http.ResponseWriter, r *http.Request)
+}
+
+type metricsAPI struct {
+	authUsecase logic.AuthenticationUsecase
+}
+
+func NewMetricsAPI(authUsecase logic.AuthenticationUsecase) MetricsAPI {
+	return &metricsAPI{
+		authUsecase: authUsecase,
+	}
+}
+
+// Vars is only for super admin, some published metrics scan whole collection when they are read
+func (m *metricsAPI) Vars(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("token")
+	credential, err := m.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := m.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_MANAGE_ADMIN); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	expvar.Handler().ServeHTTP(w, r)
+}
@@ -6,22 +6,33 @@ import (
 	"github.com/elastic/go-elasticsearch/v7"
 	"github.com/market-place/infrastructure/http_api/http_response"
 	migrations "github.com/market-place/migrations/elasticsearch"
+	mongoMigrations "github.com/market-place/migrations/mongodb"
+	"github.com/market-place/usecase/logic"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type MigrationAPI interface {
 	ElasticProductIndex(w http.ResponseWriter, r *http.Request)
 	ElasticMerchantIndex(w http.ResponseWriter, r *http.Request)
+	MongoVoucherIndex(w http.ResponseWriter, r *http.Request)
+	AdminRole(w http.ResponseWriter, r *http.Request)
 }
 
 type migrationAPI struct {
-	esClient *elasticsearch.Client
+	esClient     *elasticsearch.Client
+	mongoDB      *mongo.Database
+	adminUsecase logic.AdminUsecase
 }
 
 func NewMigrationAPI(
 	esClient *elasticsearch.Client,
+	mongoDB *mongo.Database,
+	adminUsecase logic.AdminUsecase,
 ) MigrationAPI {
 	return &migrationAPI{
-		esClient: esClient,
+		esClient:     esClient,
+		mongoDB:      mongoDB,
+		adminUsecase: adminUsecase,
 	}
 }
 
@@ -48,3 +59,30 @@ func (m *migrationAPI) ElasticMerchantIndex(w http.ResponseWriter, r *http.Reque
 	}
 	http_response.SendOkJSON(w, http.StatusCreated, data)
 }
+
+func (m *migrationAPI) MongoVoucherIndex(w http.ResponseWriter, r *http.Request) {
+	if err := mongoMigrations.CreateIndexVoucher(m.mongoDB); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"message": "voucher index is success created!",
+	}
+	http_response.SendOkJSON(w, http.StatusCreated, data)
+}
+
+// AdminRole assign superadmin role to admins registered before admin roles exist
+func (m *migrationAPI) AdminRole(w http.ResponseWriter, r *http.Request) {
+	assigned, err := m.adminUsecase.AssignLegacyRole(r.Context())
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"message":  "role of legacy admins is success assigned!",
+		"assigned": assigned,
+	}
+	http_response.SendOkJSON(w, http.StatusOK, data)
+}
@@ -0,0 +1,90 @@
+package http_api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/market-place/infrastructure/http_api/http_response"
+	"github.com/market-place/usecase/logic"
+)
+
+type NotificationAPI interface {
+	Fetch(w http.ResponseWriter, r *http.Request)
+	MarkRead(w http.ResponseWriter, r *http.Request)
+}
+
+type notificationAPI struct {
+	notificationUsecase logic.NotificationUsecase
+	authUsecase         logic.AuthenticationUsecase
+}
+
+func NewNotificationAPI(
+	notificationUsecase logic.NotificationUsecase,
+	authUsecase logic.AuthenticationUsecase,
+) NotificationAPI {
+	return &notificationAPI{
+		notificationUsecase: notificationUsecase,
+		authUsecase:         authUsecase,
+	}
+}
+
+func (n *notificationAPI) Fetch(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := n.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := n.authUsecase.VerifiedAsCustomer(credential); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := n.authUsecase.VerifiedCustomerAuthor(credential, customerID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	var defaultNum int64 = 10
+	if num, err := strconv.Atoi(r.FormValue("num")); err == nil {
+		defaultNum = int64(num)
+	}
+
+	cursor := r.FormValue("cursor")
+	unread := r.FormValue("unread") == "true"
+	notifications, err := n.notificationUsecase.Fetch(r.Context(), cursor, defaultNum, customerID, unread)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, notifications)
+}
+
+func (n *notificationAPI) MarkRead(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	notificationID := mux.Vars(r)["nID"]
+	token := r.Header.Get("token")
+	credential, err := n.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := n.authUsecase.VerifiedAsCustomer(credential); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := n.authUsecase.VerifiedCustomerAuthor(credential, customerID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	notification, err := n.notificationUsecase.MarkRead(r.Context(), notificationID, customerID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, notification)
+}
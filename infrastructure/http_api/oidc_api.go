@@ -0,0 +1,238 @@
+package http_api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/market-place/infrastructure/http_api/http_response"
+	adapterJSON "github.com/market-place/usecase/adapter/json"
+	"github.com/market-place/usecase/helper"
+	"github.com/market-place/usecase/logic"
+)
+
+type OIDCAPI interface {
+	Authorize(w http.ResponseWriter, r *http.Request)
+	Callback(w http.ResponseWriter, r *http.Request)
+	CompleteProfile(w http.ResponseWriter, r *http.Request)
+	AuthorizeLink(w http.ResponseWriter, r *http.Request)
+	Link(w http.ResponseWriter, r *http.Request)
+	Unlink(w http.ResponseWriter, r *http.Request)
+}
+
+type oidcAPI struct {
+	oidcUsecase logic.OIDCUsecase
+	authUsecase logic.AuthenticationUsecase
+	serialize   adapterJSON.AdapterOIDCJSON
+}
+
+func NewOIDCAPI(
+	oidcUsecase logic.OIDCUsecase,
+	authUsecase logic.AuthenticationUsecase,
+) OIDCAPI {
+	return &oidcAPI{
+		oidcUsecase: oidcUsecase,
+		authUsecase: authUsecase,
+		serialize:   adapterJSON.AdapterOIDCJSON{},
+	}
+}
+
+func (o *oidcAPI) Authorize(w http.ResponseWriter, r *http.Request) {
+	provider := mux.Vars(r)["provider"]
+	authorization, err := o.oidcUsecase.Authorize(r.Context(), provider)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, authorization)
+}
+
+func (o *oidcAPI) Callback(w http.ResponseWriter, r *http.Request) {
+	provider := mux.Vars(r)["provider"]
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	input, err := o.serialize.DecodeCallbackInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if input.GuestCartToken == "" {
+		input.GuestCartToken = guestCartToken(r)
+	}
+
+	credential, challenge, err := o.oidcUsecase.Login(r.Context(), provider, input)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if challenge.UserID != "" {
+		challengeToken, err := helper.EncodeChallengeToken(challenge)
+		if err != nil {
+			http_response.SendErrJSON(w, err)
+			return
+		}
+
+		res := map[string]interface{}{
+			"challenge_token": challengeToken,
+			"enrolled":        challenge.Enrolled,
+		}
+		http_response.SendOkJSON(w, http.StatusOK, res)
+		return
+	}
+
+	token, err := helper.EncodeToken(credential)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	//guest cart is merged into customer cart
+	if input.GuestCartToken != "" {
+		clearGuestCartCookie(w)
+	}
+
+	res := map[string]interface{}{
+		"token":      token,
+		"credential": credential,
+	}
+	http_response.SendOkJSON(w, http.StatusOK, res)
+}
+
+func (o *oidcAPI) CompleteProfile(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := o.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := o.authUsecase.VerifiedAsCustomer(credential); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := o.authUsecase.VerifiedCustomerAuthor(credential, customerID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	input, err := o.serialize.DecodeProfileInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	customer, err := o.oidcUsecase.CompleteProfile(ctx, input, customerID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, customer)
+}
+
+func (o *oidcAPI) AuthorizeLink(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	provider := mux.Vars(r)["provider"]
+	token := r.Header.Get("token")
+	credential, err := o.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := o.authUsecase.VerifiedAsCustomer(credential); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := o.authUsecase.VerifiedCustomerAuthor(credential, customerID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	authorization, err := o.oidcUsecase.AuthorizeLink(r.Context(), provider, customerID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, authorization)
+}
+
+func (o *oidcAPI) Link(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	provider := mux.Vars(r)["provider"]
+	token := r.Header.Get("token")
+	credential, err := o.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := o.authUsecase.VerifiedAsCustomer(credential); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := o.authUsecase.VerifiedCustomerAuthor(credential, customerID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	input, err := o.serialize.DecodeCallbackInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	customer, err := o.oidcUsecase.Link(ctx, provider, input, customerID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, customer)
+}
+
+func (o *oidcAPI) Unlink(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	provider := mux.Vars(r)["provider"]
+	token := r.Header.Get("token")
+	credential, err := o.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := o.authUsecase.VerifiedAsCustomer(credential); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := o.authUsecase.VerifiedCustomerAuthor(credential, customerID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	customer, err := o.oidcUsecase.Unlink(ctx, provider, customerID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, customer)
+}
@@ -9,6 +9,7 @@ import (
 
 	"cloud.google.com/go/storage"
 	"github.com/gorilla/mux"
+	"github.com/market-place/domain"
 	"github.com/market-place/infrastructure/http_api/helper"
 	"github.com/market-place/infrastructure/http_api/http_response"
 	"github.com/market-place/usecase/adapter"
@@ -101,11 +102,16 @@ func (o *orderAPI) GetByID(w http.ResponseWriter, r *http.Request) {
 func (o *orderAPI) FetchOrderMerchant(w http.ResponseWriter, r *http.Request) {
 	merchantID := mux.Vars(r)["id"]
 	token := r.Header.Get("token")
-	credential, err := o.authUsecase.ValidateLogin(token)
+	apiKey := r.Header.Get("api-key")
+	credential, err := o.authUsecase.ValidateLoginOrAPIKey(token, apiKey)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
 	}
+	if err := o.authUsecase.VerifiedAPIKeyScope(credential, domain.API_KEY_SCOPE_ORDERS_READ); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
 	if err := o.authUsecase.VerifiedMerchantOwner(credential, merchantID); err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -166,11 +172,16 @@ func (o *orderAPI) FetchOrderCustomer(w http.ResponseWriter, r *http.Request) {
 func (o *orderAPI) AddResiNumber(w http.ResponseWriter, r *http.Request) {
 	orderID := mux.Vars(r)["id"]
 	token := r.Header.Get("token")
-	credential, err := o.authUsecase.ValidateLogin(token)
+	apiKey := r.Header.Get("api-key")
+	credential, err := o.authUsecase.ValidateLoginOrAPIKey(token, apiKey)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
 	}
+	if err := o.authUsecase.VerifiedAPIKeyScope(credential, domain.API_KEY_SCOPE_ORDERS_SHIP); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
 	if err := o.authUsecase.VerifiedMerchantOrderOwner(r.Context(), credential, orderID); err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -187,7 +198,8 @@ func (o *orderAPI) AddResiNumber(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	order, err := o.orderUsecase.InputResiNumber(r.Context(), input, orderID)
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	order, err := o.orderUsecase.InputResiNumber(ctx, input, orderID)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -294,7 +306,8 @@ func (o *orderAPI) RejectOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	order, err := o.orderUsecase.RejectOrder(r.Context(), orderID)
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	order, err := o.orderUsecase.RejectOrder(ctx, orderID)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
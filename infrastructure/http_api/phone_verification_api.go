@@ -0,0 +1,88 @@
+package http_api
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/market-place/infrastructure/http_api/http_response"
+	adapterJSON "github.com/market-place/usecase/adapter/json"
+	"github.com/market-place/usecase/logic"
+)
+
+type PhoneVerificationAPI interface {
+	Send(w http.ResponseWriter, r *http.Request)
+	Verify(w http.ResponseWriter, r *http.Request)
+}
+
+type phoneVerificationAPI struct {
+	phoneVerificationUsecase logic.PhoneVerificationUsecase
+	authUsecase              logic.AuthenticationUsecase
+	serialize                adapterJSON.AdapaterCustomerJSON
+}
+
+func NewPhoneVerificationAPI(
+	phoneVerificationUsecase logic.PhoneVerificationUsecase,
+	authUsecase logic.AuthenticationUsecase,
+) PhoneVerificationAPI {
+	return &phoneVerificationAPI{
+		phoneVerificationUsecase: phoneVerificationUsecase,
+		authUsecase:              authUsecase,
+		serialize:                adapterJSON.AdapaterCustomerJSON{},
+	}
+}
+
+func (p *phoneVerificationAPI) Send(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := p.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := p.authUsecase.VerifiedCustomerAuthor(credential, customerID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	otp, err := p.phoneVerificationUsecase.Send(r.Context(), customerID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusCreated, otp)
+}
+
+func (p *phoneVerificationAPI) Verify(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := p.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := p.authUsecase.VerifiedCustomerAuthor(credential, customerID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	input, err := p.serialize.DecodePhoneOTPInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	customer, err := p.phoneVerificationUsecase.Verify(r.Context(), input, customerID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, customer)
+}
@@ -8,6 +8,7 @@ import (
 
 	"cloud.google.com/go/storage"
 	"github.com/gorilla/mux"
+	"github.com/market-place/domain"
 	"github.com/market-place/infrastructure/http_api/helper"
 	"github.com/market-place/infrastructure/http_api/http_response"
 	"github.com/market-place/usecase/adapter"
@@ -22,7 +23,14 @@ type ProductAPI interface {
 	Fetch(w http.ResponseWriter, r *http.Request)
 	UpdateData(w http.ResponseWriter, r *http.Request)
 	UploadPhotos(w http.ResponseWriter, r *http.Request)
+	BulkUpdateStockPrice(w http.ResponseWriter, r *http.Request)
+	UpdateStatus(w http.ResponseWriter, r *http.Request)
+	TakeDown(w http.ResponseWriter, r *http.Request)
+	LiftTakeDown(w http.ResponseWriter, r *http.Request)
 	DeleteOne(w http.ResponseWriter, r *http.Request)
+	Restore(w http.ResponseWriter, r *http.Request)
+	AddDiscount(w http.ResponseWriter, r *http.Request)
+	RemoveDiscount(w http.ResponseWriter, r *http.Request)
 	ProductTerlaris(w http.ResponseWriter, r *http.Request)
 }
 
@@ -77,7 +85,12 @@ func (p *productAPI) Create(w http.ResponseWriter, r *http.Request) {
 
 func (p *productAPI) GetByID(w http.ResponseWriter, r *http.Request) {
 	productID := mux.Vars(r)["id"]
-	product, err := p.productUsecase.GetByID(r.Context(), productID)
+	//login is optional, owner merchant and admin can see product which is hidden from buyer
+	ctx := r.Context()
+	if credential, err := p.authUsecase.ValidateLogin(r.Header.Get("token")); err == nil {
+		ctx = context.WithValue(ctx, "credential", credential)
+	}
+	product, err := p.productUsecase.GetByID(ctx, productID)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -99,6 +112,8 @@ func (p *productAPI) Fetch(w http.ResponseWriter, r *http.Request) {
 		Etalase:     r.FormValue("etalase"),
 		Price:       price,
 		City:        r.FormValue("city"),
+		Status:      r.FormValue("status"),
+		Deleted:     r.FormValue("deleted") == "true",
 	}
 
 	var defaultNum int64 = 10
@@ -106,8 +121,12 @@ func (p *productAPI) Fetch(w http.ResponseWriter, r *http.Request) {
 		defaultNum = int64(num)
 	}
 
+	ctx := r.Context()
+	if credential, err := p.authUsecase.ValidateLogin(r.Header.Get("token")); err == nil {
+		ctx = context.WithValue(ctx, "credential", credential)
+	}
 	cursor := r.FormValue("cursor")
-	products, err := p.productUsecase.Fetch(r.Context(), cursor, defaultNum, search)
+	products, err := p.productUsecase.Fetch(ctx, cursor, defaultNum, search)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -119,11 +138,16 @@ func (p *productAPI) Fetch(w http.ResponseWriter, r *http.Request) {
 func (p *productAPI) UpdateData(w http.ResponseWriter, r *http.Request) {
 	productID := mux.Vars(r)["id"]
 	token := r.Header.Get("token")
-	credential, err := p.authUsecase.ValidateLogin(token)
+	apiKey := r.Header.Get("api-key")
+	credential, err := p.authUsecase.ValidateLoginOrAPIKey(token, apiKey)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
 	}
+	if err := p.authUsecase.VerifiedAPIKeyScope(credential, domain.API_KEY_SCOPE_PRODUCTS_WRITE); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
 	if err := p.authUsecase.VerifiedProductOwner(r.Context(), credential, productID); err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -140,7 +164,8 @@ func (p *productAPI) UpdateData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	product, err := p.productUsecase.UpdateData(r.Context(), input, productID)
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	product, err := p.productUsecase.UpdateData(ctx, input, productID)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -149,6 +174,40 @@ func (p *productAPI) UpdateData(w http.ResponseWriter, r *http.Request) {
 	http_response.SendOkJSON(w, http.StatusOK, product)
 }
 
+func (p *productAPI) BulkUpdateStockPrice(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("token")
+	apiKey := r.Header.Get("api-key")
+	credential, err := p.authUsecase.ValidateLoginOrAPIKey(token, apiKey)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := p.authUsecase.VerifiedAPIKeyScope(credential, domain.API_KEY_SCOPE_PRODUCTS_WRITE); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	input, err := p.serialize.DecodeBulkUpdateInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	results, err := p.productUsecase.BulkUpdateStockPrice(ctx, input)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, results)
+}
+
 func (p *productAPI) UploadPhotos(w http.ResponseWriter, r *http.Request) {
 	r.Body = http.MaxBytesReader(w, r.Body, 1*1024*1024)
 	if err := r.ParseMultipartForm(5 << 20); err != nil {
@@ -221,7 +280,184 @@ func (p *productAPI) DeleteOne(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	product, err := p.productUsecase.DeleteOne(r.Context(), productID)
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	product, err := p.productUsecase.DeleteOne(ctx, productID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, product)
+}
+
+func (p *productAPI) Restore(w http.ResponseWriter, r *http.Request) {
+	productID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := p.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := p.authUsecase.VerifiedProductOwner(r.Context(), credential, productID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	product, err := p.productUsecase.Restore(ctx, productID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, product)
+}
+
+func (p *productAPI) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+	productID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	apiKey := r.Header.Get("api-key")
+	credential, err := p.authUsecase.ValidateLoginOrAPIKey(token, apiKey)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := p.authUsecase.VerifiedAPIKeyScope(credential, domain.API_KEY_SCOPE_PRODUCTS_WRITE); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := p.authUsecase.VerifiedProductOwner(r.Context(), credential, productID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	input, err := p.serialize.DecodeStatusInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	product, err := p.productUsecase.UpdateStatus(ctx, input, productID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, product)
+}
+
+func (p *productAPI) TakeDown(w http.ResponseWriter, r *http.Request) {
+	productID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := p.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := p.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_MANAGE_PRODUCT); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	product, err := p.productUsecase.TakeDown(ctx, productID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, product)
+}
+
+func (p *productAPI) LiftTakeDown(w http.ResponseWriter, r *http.Request) {
+	productID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := p.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := p.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_MANAGE_PRODUCT); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	product, err := p.productUsecase.LiftTakeDown(ctx, productID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, product)
+}
+
+func (p *productAPI) AddDiscount(w http.ResponseWriter, r *http.Request) {
+	productID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	apiKey := r.Header.Get("api-key")
+	credential, err := p.authUsecase.ValidateLoginOrAPIKey(token, apiKey)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := p.authUsecase.VerifiedAPIKeyScope(credential, domain.API_KEY_SCOPE_PRODUCTS_WRITE); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := p.authUsecase.VerifiedProductOwner(r.Context(), credential, productID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	input, err := p.serialize.DecodeDiscountInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	product, err := p.productUsecase.AddDiscount(ctx, input, productID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, product)
+}
+
+func (p *productAPI) RemoveDiscount(w http.ResponseWriter, r *http.Request) {
+	productID := mux.Vars(r)["id"]
+	discountID := mux.Vars(r)["discountID"]
+	token := r.Header.Get("token")
+	apiKey := r.Header.Get("api-key")
+	credential, err := p.authUsecase.ValidateLoginOrAPIKey(token, apiKey)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := p.authUsecase.VerifiedAPIKeyScope(credential, domain.API_KEY_SCOPE_PRODUCTS_WRITE); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := p.authUsecase.VerifiedProductOwner(r.Context(), credential, productID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	product, err := p.productUsecase.RemoveDiscount(ctx, productID, discountID)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
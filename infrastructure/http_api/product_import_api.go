@@ -0,0 +1,166 @@
+package http_api
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/market-place/domain"
+	"github.com/market-place/infrastructure/http_api/http_response"
+	adapterCSV "github.com/market-place/usecase/adapter/csv"
+	"github.com/market-place/usecase/logic"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type ProductImportAPI interface {
+	Import(w http.ResponseWriter, r *http.Request)
+	GetJob(w http.ResponseWriter, r *http.Request)
+	Export(w http.ResponseWriter, r *http.Request)
+}
+
+type productImportAPI struct {
+	productImportUsecase logic.ProductImportUsecase
+	authUsecase          logic.AuthenticationUsecase
+	serialize            adapterCSV.AdapterProductCSV
+}
+
+func NewProductImportAPI(
+	productImportUsecase logic.ProductImportUsecase,
+	authUsecase logic.AuthenticationUsecase,
+) ProductImportAPI {
+	return &productImportAPI{
+		productImportUsecase: productImportUsecase,
+		authUsecase:          authUsecase,
+		serialize:            adapterCSV.AdapterProductCSV{},
+	}
+}
+
+func (p *productImportAPI) Import(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 10*1024*1024)
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "File",
+				Message: "File is too large",
+			},
+		}
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	merchantID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	apiKey := r.Header.Get("api-key")
+	credential, err := p.authUsecase.ValidateLoginOrAPIKey(token, apiKey)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := p.authUsecase.VerifiedAPIKeyScope(credential, domain.API_KEY_SCOPE_PRODUCTS_WRITE); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := p.authUsecase.VerifiedMerchantOwner(credential, merchantID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "File",
+				Message: "File is required",
+			},
+		}
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	defer file.Close()
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	rows, err := p.serialize.DecodeImportRows(content)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	job, err := p.productImportUsecase.Import(ctx, rows)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusAccepted, job)
+}
+
+func (p *productImportAPI) GetJob(w http.ResponseWriter, r *http.Request) {
+	merchantID := mux.Vars(r)["id"]
+	jobID := mux.Vars(r)["jobID"]
+	token := r.Header.Get("token")
+	apiKey := r.Header.Get("api-key")
+	credential, err := p.authUsecase.ValidateLoginOrAPIKey(token, apiKey)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := p.authUsecase.VerifiedAPIKeyScope(credential, domain.API_KEY_SCOPE_PRODUCTS_WRITE); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := p.authUsecase.VerifiedMerchantOwner(credential, merchantID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	job, err := p.productImportUsecase.GetJob(ctx, jobID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, job)
+}
+
+func (p *productImportAPI) Export(w http.ResponseWriter, r *http.Request) {
+	merchantID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	apiKey := r.Header.Get("api-key")
+	credential, err := p.authUsecase.ValidateLoginOrAPIKey(token, apiKey)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := p.authUsecase.VerifiedAPIKeyScope(credential, domain.API_KEY_SCOPE_PRODUCTS_WRITE); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := p.authUsecase.VerifiedMerchantOwner(credential, merchantID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	products, err := p.productImportUsecase.Export(ctx)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	content, err := p.serialize.EncodeProducts(products)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=products-%s.csv", merchantID))
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
@@ -3,8 +3,10 @@ package http_api
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
+	"github.com/market-place/domain"
 	"github.com/market-place/infrastructure/http_api/http_response"
 	"github.com/market-place/usecase/logic"
 )
@@ -54,8 +56,21 @@ func (s *searchAPI) ProductSearch(w http.ResponseWriter, r *http.Request) {
 	}
 	keyword := r.FormValue("keyword")
 	lastDate := r.FormValue("last")
+	//attributes filter is written as name=value separated by "|", e.g. ram=8 GB|cpu=intel i5
+	attributes := []domain.ProductAttribute{}
+	for _, item := range strings.Split(r.FormValue("attributes"), "|") {
+		if pair := strings.SplitN(item, "=", 2); len(pair) == 2 {
+			attributes = append(attributes, domain.ProductAttribute{
+				Name:  strings.TrimSpace(pair[0]),
+				Value: strings.TrimSpace(pair[1]),
+			})
+		}
+	}
+
+	//sort is price_asc, price_desc or discount, empty sort is by relevance
+	sort := r.FormValue("sort")
 
-	products, err := s.searchUsecase.ProductSearch(r.Context(), topCategory, secondCategory, thirdCategory, city, int64(min), int64(max), keyword, lastDate)
+	products, err := s.searchUsecase.ProductSearch(r.Context(), topCategory, secondCategory, thirdCategory, city, int64(min), int64(max), keyword, lastDate, attributes, sort)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
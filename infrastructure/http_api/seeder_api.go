@@ -16,6 +16,7 @@ import (
 type SeederAPI interface {
 	SeedSuperAdmin(w http.ResponseWriter, r *http.Request)
 	SeedShipping(w http.ResponseWriter, r *http.Request)
+	SeedCategory(w http.ResponseWriter, r *http.Request)
 	SeedCustomer(w http.ResponseWriter, r *http.Request)
 	SeedMerchant(w http.ResponseWriter, r *http.Request)
 	SeedProduct(w http.ResponseWriter, r *http.Request)
@@ -24,6 +25,7 @@ type SeederAPI interface {
 type seederAPI struct {
 	adminUsecase     logic.AdminUsecase
 	shippingUsecase  logic.ShippingUsecase
+	categoryUsecase  logic.CategoryUsecase
 	customerUsecase  logic.CustomerUsecase
 	merchantUsecase  logic.MerchantUsecase
 	productUsecase   logic.ProductUsecase
@@ -37,6 +39,7 @@ type seederAPI struct {
 func NewSeederAPI(
 	adminUsecase logic.AdminUsecase,
 	shippingUsecase logic.ShippingUsecase,
+	categoryUsecase logic.CategoryUsecase,
 	customerUsecase logic.CustomerUsecase,
 	merchantUsecase logic.MerchantUsecase,
 	productUsecase logic.ProductUsecase,
@@ -49,6 +52,7 @@ func NewSeederAPI(
 	return &seederAPI{
 		adminUsecase:     adminUsecase,
 		shippingUsecase:  shippingUsecase,
+		categoryUsecase:  categoryUsecase,
 		customerUsecase:  customerUsecase,
 		merchantUsecase:  merchantUsecase,
 		productUsecase:   productUsecase,
@@ -89,6 +93,21 @@ func (s *seederAPI) SeedShipping(w http.ResponseWriter, r *http.Request) {
 	http_response.SendOkJSON(w, http.StatusCreated, shippings)
 }
 
+func (s *seederAPI) SeedCategory(w http.ResponseWriter, r *http.Request) {
+	log.SetOutput(os.Stdout)
+	log.Println("Seed Category : starting!")
+
+	categories, err := seed.SeedCategory(s.categoryUsecase)
+	if err != nil {
+		log.Printf("Seed Category : failed cause, %s \n", err)
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	log.Println("Seed Category: success!")
+	http_response.SendOkJSON(w, http.StatusCreated, categories)
+}
+
 func (s *seederAPI) SeedCustomer(w http.ResponseWriter, r *http.Request) {
 
 	log.SetOutput(os.Stdout)
@@ -0,0 +1,118 @@
+package http_api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/market-place/infrastructure/http_api/http_response"
+	"github.com/market-place/usecase/logic"
+)
+
+type SessionAPI interface {
+	Fetch(w http.ResponseWriter, r *http.Request)
+	Revoke(w http.ResponseWriter, r *http.Request)
+	RevokeAll(w http.ResponseWriter, r *http.Request)
+}
+
+type sessionAPI struct {
+	sessionUsecase logic.SessionUsecase
+	authUsecase    logic.AuthenticationUsecase
+}
+
+func NewSessionAPI(
+	sessionUsecase logic.SessionUsecase,
+	authUsecase logic.AuthenticationUsecase,
+) SessionAPI {
+	return &sessionAPI{
+		sessionUsecase: sessionUsecase,
+		authUsecase:    authUsecase,
+	}
+}
+
+func (s *sessionAPI) Fetch(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := s.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := s.authUsecase.VerifiedAsCustomer(credential); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := s.authUsecase.VerifiedCustomerAuthor(credential, customerID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	var defaultNum int64 = 10
+	if num, err := strconv.Atoi(r.FormValue("num")); err == nil {
+		defaultNum = int64(num)
+	}
+
+	cursor := r.FormValue("cursor")
+	sessions, err := s.sessionUsecase.Fetch(r.Context(), cursor, defaultNum, customerID, credential.Id)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, sessions)
+}
+
+func (s *sessionAPI) Revoke(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	sessionID := mux.Vars(r)["sID"]
+	token := r.Header.Get("token")
+	credential, err := s.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := s.authUsecase.VerifiedAsCustomer(credential); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := s.authUsecase.VerifiedCustomerAuthor(credential, customerID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	session, err := s.sessionUsecase.Revoke(r.Context(), sessionID, customerID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, session)
+}
+
+func (s *sessionAPI) RevokeAll(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := s.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := s.authUsecase.VerifiedAsCustomer(credential); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := s.authUsecase.VerifiedCustomerAuthor(credential, customerID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	if err := s.sessionUsecase.RevokeAll(r.Context(), customerID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	res := map[string]interface{}{
+		"revoked": true,
+	}
+	http_response.SendOkJSON(w, http.StatusOK, res)
+}
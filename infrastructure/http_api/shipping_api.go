@@ -1,11 +1,13 @@
 package http_api
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"strconv"
 
 	"github.com/gorilla/mux"
+	"github.com/market-place/domain"
 	"github.com/market-place/infrastructure/http_api/http_response"
 	"github.com/market-place/usecase/adapter"
 	adapterJSON "github.com/market-place/usecase/adapter/json"
@@ -44,7 +46,7 @@ func (s *shippingAPI) Create(w http.ResponseWriter, r *http.Request) {
 		http_response.SendErrJSON(w, err)
 		return
 	}
-	if err := s.authUsecase.VerifiedAsAdmin(credential); err != nil {
+	if err := s.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_MANAGE_SHIPPING); err != nil {
 		http_response.SendErrJSON(w, err)
 		return
 	}
@@ -59,7 +61,8 @@ func (s *shippingAPI) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	shipping, err := s.shippingUsecase.Create(r.Context(), input)
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	shipping, err := s.shippingUsecase.Create(ctx, input)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -106,7 +109,7 @@ func (s *shippingAPI) UpdateOne(w http.ResponseWriter, r *http.Request) {
 		http_response.SendErrJSON(w, err)
 		return
 	}
-	if err := s.authUsecase.VerifiedAsAdmin(credential); err != nil {
+	if err := s.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_MANAGE_SHIPPING); err != nil {
 		http_response.SendErrJSON(w, err)
 		return
 	}
@@ -122,7 +125,8 @@ func (s *shippingAPI) UpdateOne(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	shipping, err := s.shippingUsecase.UpdateOne(r.Context(), input, shippingID)
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	shipping, err := s.shippingUsecase.UpdateOne(ctx, input, shippingID)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
@@ -139,12 +143,13 @@ func (s *shippingAPI) DeleteOne(w http.ResponseWriter, r *http.Request) {
 		http_response.SendErrJSON(w, err)
 		return
 	}
-	if err := s.authUsecase.VerifiedAsAdmin(credential); err != nil {
+	if err := s.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_MANAGE_SHIPPING); err != nil {
 		http_response.SendErrJSON(w, err)
 		return
 	}
 
-	shipping, err := s.shippingUsecase.DeleteOne(r.Context(), shippingID)
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	shipping, err := s.shippingUsecase.DeleteOne(ctx, shippingID)
 	if err != nil {
 		http_response.SendErrJSON(w, err)
 		return
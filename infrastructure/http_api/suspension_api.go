@@ -0,0 +1,153 @@
+package http_api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/market-place/domain"
+	"github.com/market-place/infrastructure/http_api/http_response"
+	adapterJSON "github.com/market-place/usecase/adapter/json"
+	"github.com/market-place/usecase/logic"
+)
+
+type SuspensionAPI interface {
+	SuspendCustomer(w http.ResponseWriter, r *http.Request)
+	LiftCustomer(w http.ResponseWriter, r *http.Request)
+	SuspendMerchant(w http.ResponseWriter, r *http.Request)
+	LiftMerchant(w http.ResponseWriter, r *http.Request)
+}
+
+type suspensionAPI struct {
+	suspensionUsecase logic.SuspensionUsecase
+	authUsecase       logic.AuthenticationUsecase
+	serialize         adapterJSON.AdapterSuspensionJSON
+}
+
+func NewSuspensionAPI(
+	suspensionUsecase logic.SuspensionUsecase,
+	authUsecase logic.AuthenticationUsecase,
+) SuspensionAPI {
+	return &suspensionAPI{
+		suspensionUsecase: suspensionUsecase,
+		authUsecase:       authUsecase,
+		serialize:         adapterJSON.AdapterSuspensionJSON{},
+	}
+}
+
+func (s *suspensionAPI) SuspendCustomer(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := s.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := s.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_MANAGE_SUSPENSION); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	input, err := s.serialize.DecodeSuspensionInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	customer, err := s.suspensionUsecase.SuspendCustomer(ctx, input, customerID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, customer)
+}
+
+func (s *suspensionAPI) LiftCustomer(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := s.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := s.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_MANAGE_SUSPENSION); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	customer, err := s.suspensionUsecase.LiftCustomer(ctx, customerID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, customer)
+}
+
+func (s *suspensionAPI) SuspendMerchant(w http.ResponseWriter, r *http.Request) {
+	merchantID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := s.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := s.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_MANAGE_SUSPENSION); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	input, err := s.serialize.DecodeSuspensionInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	merchant, err := s.suspensionUsecase.SuspendMerchant(ctx, input, merchantID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, merchant)
+}
+
+func (s *suspensionAPI) LiftMerchant(w http.ResponseWriter, r *http.Request) {
+	merchantID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := s.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := s.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_MANAGE_SUSPENSION); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	merchant, err := s.suspensionUsecase.LiftMerchant(ctx, merchantID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, merchant)
+}
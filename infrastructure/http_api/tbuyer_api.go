@@ -70,8 +70,8 @@ func (t *tbuyerAPI) Fetch(w http.ResponseWriter, r *http.Request) {
 			http_response.SendErrJSON(w, err)
 			return
 		}
-	} else if customerID == "" {
-		if err := t.authUsecase.VerifiedAsAdmin(credential); err != nil {
+	} else {
+		if err := t.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_READ_TRANSACTION); err != nil {
 			http_response.SendErrJSON(w, err)
 			return
 		}
@@ -176,7 +176,7 @@ func (t *tbuyerAPI) AcceptTransaction(w http.ResponseWriter, r *http.Request) {
 		http_response.SendErrJSON(w, err)
 		return
 	}
-	if err := t.authUsecase.VerifiedAsAdmin(credential); err != nil {
+	if err := t.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_MANAGE_TRANSACTION); err != nil {
 		http_response.SendErrJSON(w, err)
 		return
 	}
@@ -200,7 +200,7 @@ func (t *tbuyerAPI) RejectTransaction(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ctx := context.WithValue(r.Context(), "credential", credential)
-	if err := t.authUsecase.VerifiedAsAdmin(credential); err != nil {
+	if err := t.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_MANAGE_TRANSACTION); err != nil {
 		http_response.SendErrJSON(w, err)
 		return
 	}
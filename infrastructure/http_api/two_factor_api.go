@@ -0,0 +1,125 @@
+package http_api
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/market-place/infrastructure/http_api/http_response"
+	adapterJSON "github.com/market-place/usecase/adapter/json"
+	"github.com/market-place/usecase/logic"
+)
+
+type TwoFactorAPI interface {
+	Enroll(w http.ResponseWriter, r *http.Request)
+	Confirm(w http.ResponseWriter, r *http.Request)
+	Disable(w http.ResponseWriter, r *http.Request)
+}
+
+type twoFactorAPI struct {
+	twoFactorUsecase logic.TwoFactorUsecase
+	authUsecase      logic.AuthenticationUsecase
+	serialize        adapterJSON.AdapterAuthJSON
+}
+
+func NewTwoFactorAPI(
+	twoFactorUsecase logic.TwoFactorUsecase,
+	authUsecase logic.AuthenticationUsecase,
+) TwoFactorAPI {
+	return &twoFactorAPI{
+		twoFactorUsecase: twoFactorUsecase,
+		authUsecase:      authUsecase,
+		serialize:        adapterJSON.AdapterAuthJSON{},
+	}
+}
+
+func (t *twoFactorAPI) Enroll(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := t.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := t.authUsecase.VerifiedCustomerAuthor(credential, customerID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	enrollment, err := t.twoFactorUsecase.Enroll(r.Context(), customerID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusCreated, enrollment)
+}
+
+func (t *twoFactorAPI) Confirm(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := t.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := t.authUsecase.VerifiedCustomerAuthor(credential, customerID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	input, err := t.serialize.DecodeTwoFactorCodeInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	recoveryCodes, err := t.twoFactorUsecase.Confirm(r.Context(), input, customerID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	res := map[string]interface{}{
+		"recovery_codes": recoveryCodes,
+	}
+	http_response.SendOkJSON(w, http.StatusOK, res)
+}
+
+func (t *twoFactorAPI) Disable(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := t.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := t.authUsecase.VerifiedCustomerAuthor(credential, customerID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	input, err := t.serialize.DecodeTwoFactorCodeInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	customer, err := t.twoFactorUsecase.Disable(r.Context(), input, customerID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, customer)
+}
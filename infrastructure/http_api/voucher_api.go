@@ -0,0 +1,159 @@
+package http_api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/market-place/domain"
+	"github.com/market-place/infrastructure/http_api/http_response"
+	"github.com/market-place/usecase/adapter"
+	adapterJSON "github.com/market-place/usecase/adapter/json"
+	"github.com/market-place/usecase/logic"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type VoucherAPI interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	Fetch(w http.ResponseWriter, r *http.Request)
+	GetByID(w http.ResponseWriter, r *http.Request)
+	UpdateOne(w http.ResponseWriter, r *http.Request)
+	DeleteOne(w http.ResponseWriter, r *http.Request)
+}
+
+type voucherAPI struct {
+	voucherUsecase logic.VoucherUsecase
+	authUsecase    logic.AuthenticationUsecase
+	serialize      adapter.VoucherAdapter
+}
+
+func NewVoucherAPI(
+	voucherUsecase logic.VoucherUsecase,
+	authUsecase logic.AuthenticationUsecase,
+) VoucherAPI {
+	return &voucherAPI{
+		voucherUsecase: voucherUsecase,
+		authUsecase:    authUsecase,
+		serialize:      &adapterJSON.AdapterVoucherJSON{},
+	}
+}
+
+// validateVoucherManager accept admin with voucher permission for platform vouchers and merchant for its own vouchers
+func (v *voucherAPI) validateVoucherManager(r *http.Request) (domain.Credential, error) {
+	credential, err := v.authUsecase.ValidateLogin(r.Header.Get("token"))
+	if err != nil {
+		return credential, err
+	}
+	if credential.LoginType == domain.LOGIN_AS_ADMIN {
+		return credential, v.authUsecase.VerifiedAdminPermission(credential, domain.PERMISSION_MANAGE_VOUCHER)
+	}
+	if credential.MerchantID == "" {
+		return credential, usecase_error.ErrNotAuthorization
+	}
+	return credential, nil
+}
+
+func (v *voucherAPI) Create(w http.ResponseWriter, r *http.Request) {
+	credential, err := v.validateVoucherManager(r)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	input, err := v.serialize.DecodeCreateInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	voucher, err := v.voucherUsecase.Create(ctx, input)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusCreated, voucher)
+}
+
+// Fetch search platform vouchers when merchant_id is empty, manager of the vouchers also see vouchers which are not running
+func (v *voucherAPI) Fetch(w http.ResponseWriter, r *http.Request) {
+	search := adapter.VoucherSearchOptions{
+		MerchantID: r.FormValue("merchant_id"),
+		Code:       r.FormValue("code"),
+	}
+
+	ctx := r.Context()
+	if credential, err := v.validateVoucherManager(r); err == nil {
+		ctx = context.WithValue(ctx, "credential", credential)
+	}
+	vouchers, err := v.voucherUsecase.Fetch(ctx, search)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, vouchers)
+}
+
+func (v *voucherAPI) GetByID(w http.ResponseWriter, r *http.Request) {
+	voucherID := mux.Vars(r)["id"]
+	voucher, err := v.voucherUsecase.GetByID(r.Context(), voucherID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, voucher)
+}
+
+func (v *voucherAPI) UpdateOne(w http.ResponseWriter, r *http.Request) {
+	voucherID := mux.Vars(r)["id"]
+	credential, err := v.validateVoucherManager(r)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	input, err := v.serialize.DecodeUpdateInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	voucher, err := v.voucherUsecase.UpdateOne(ctx, input, voucherID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, voucher)
+}
+
+func (v *voucherAPI) DeleteOne(w http.ResponseWriter, r *http.Request) {
+	voucherID := mux.Vars(r)["id"]
+	credential, err := v.validateVoucherManager(r)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "credential", credential)
+	voucher, err := v.voucherUsecase.DeleteOne(ctx, voucherID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, voucher)
+}
@@ -0,0 +1,131 @@
+package http_api
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/market-place/infrastructure/http_api/http_response"
+	adapterJSON "github.com/market-place/usecase/adapter/json"
+	"github.com/market-place/usecase/logic"
+)
+
+type WishlistAPI interface {
+	Fetch(w http.ResponseWriter, r *http.Request)
+	Add(w http.ResponseWriter, r *http.Request)
+	Remove(w http.ResponseWriter, r *http.Request)
+}
+
+type wishlistAPI struct {
+	wishlistUsecase logic.WishlistUsecase
+	authUsecase     logic.AuthenticationUsecase
+	serialize       adapterJSON.AdapterWishlistJSON
+}
+
+func NewWishlistAPI(
+	wishlistUsecase logic.WishlistUsecase,
+	authUsecase logic.AuthenticationUsecase,
+) WishlistAPI {
+	return &wishlistAPI{
+		wishlistUsecase: wishlistUsecase,
+		authUsecase:     authUsecase,
+		serialize:       adapterJSON.AdapterWishlistJSON{},
+	}
+}
+
+func (wl *wishlistAPI) Fetch(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := wl.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := wl.authUsecase.VerifiedAsCustomer(credential); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := wl.authUsecase.VerifiedCustomerAuthor(credential, customerID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	var defaultNum int64 = 10
+	if num, err := strconv.Atoi(r.FormValue("num")); err == nil {
+		defaultNum = int64(num)
+	}
+
+	cursor := r.FormValue("cursor")
+	items, err := wl.wishlistUsecase.Fetch(r.Context(), cursor, defaultNum, customerID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, items)
+}
+
+func (wl *wishlistAPI) Add(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	token := r.Header.Get("token")
+	credential, err := wl.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := wl.authUsecase.VerifiedAsCustomer(credential); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := wl.authUsecase.VerifiedCustomerAuthor(credential, customerID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	input, err := wl.serialize.DecodeAddInput(requestBody)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	item, err := wl.wishlistUsecase.Add(r.Context(), input, customerID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusCreated, item)
+}
+
+func (wl *wishlistAPI) Remove(w http.ResponseWriter, r *http.Request) {
+	customerID := mux.Vars(r)["id"]
+	productID := mux.Vars(r)["pID"]
+	token := r.Header.Get("token")
+	credential, err := wl.authUsecase.ValidateLogin(token)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := wl.authUsecase.VerifiedAsCustomer(credential); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+	if err := wl.authUsecase.VerifiedCustomerAuthor(credential, customerID); err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	item, err := wl.wishlistUsecase.Remove(r.Context(), productID, customerID)
+	if err != nil {
+		http_response.SendErrJSON(w, err)
+		return
+	}
+
+	http_response.SendOkJSON(w, http.StatusOK, item)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"time"
@@ -60,6 +61,25 @@ func main() {
 	//logic or usecase
 	usecaseConfig := usecaseConfig.NewUsecaseConfig(repoConf)
 
+	//scheduled discounts start and end without request, stored discounted price is refreshed every minute.
+	//first refresh runs on startup so products saved before discounted price existed are backfilled
+	go func() {
+		productUsecase := usecaseConfig.GetProductUseCase()
+		refresh := func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			if refreshed, err := productUsecase.RefreshDiscountedPrices(ctx); err != nil {
+				log.Printf("Failed refreshing discounted prices : %s \n", err)
+			} else if refreshed > 0 {
+				log.Printf("Refreshed discounted prices of %d products", refreshed)
+			}
+		}
+		refresh()
+		for range time.Tick(time.Minute) {
+			refresh()
+		}
+	}()
+
 	//http
 	r := mux.NewRouter()
 	httpConfig := http_api.NewHttpAPI(r, usecaseConfig, infrastructureConf)
@@ -106,6 +106,25 @@ func CreateIndexMerchant(esClient *elasticsearch.Client) error {
 			 "location_point" : {
 			   "type" : "geo_point"
 			 },
+			 "suspension" : {
+			   "properties" : {
+				 "status" : {
+				   "type" : "keyword"
+				 },
+				 "reason" : {
+				   "type" : "text"
+				 },
+				 "until" : {
+				   "type" : "date"
+				 },
+				 "admin_id" : {
+				   "type" : "keyword"
+				 },
+				 "created_at" : {
+				   "type" : "date"
+				 }
+			   }
+			 },
 			 "created_at" : {
 			   "type" : "date"
 			 },
@@ -19,6 +19,15 @@ func CreateIndexProduct(esClient *elasticsearch.Client) error {
 	{
 		"mappings" : {
 			"properties" : {
+				"sku" : {
+					"type" : "keyword"
+				},
+				"status" : {
+					"type" : "keyword"
+				},
+				"deleted_at" : {
+					"type" : "date"
+				},
 				"name" : {
 					"type" : "search_as_you_type",
 					"doc_values" : false,
@@ -73,6 +82,125 @@ func CreateIndexProduct(esClient *elasticsearch.Client) error {
 				"stock" : {
 					"type" : "float"
 				},
+				"discounted_price" : {
+					"type" : "float"
+				},
+				"discount_percent" : {
+					"type" : "float"
+				},
+				"price_change_at" : {
+					"type" : "date"
+				},
+				"discounts" : {
+					"properties" : {
+						"_id" : {
+							"type" : "keyword"
+						},
+						"type" : {
+							"type" : "keyword"
+						},
+						"value" : {
+							"type" : "float"
+						},
+						"start_at" : {
+							"type" : "date"
+						},
+						"end_at" : {
+							"type" : "date"
+						}
+					}
+				},
+				"flash_sales" : {
+					"properties" : {
+						"_id" : {
+							"type" : "keyword"
+						},
+						"sku" : {
+							"type" : "keyword"
+						},
+						"price" : {
+							"type" : "float"
+						},
+						"start_at" : {
+							"type" : "date"
+						},
+						"end_at" : {
+							"type" : "date"
+						},
+						"sold_out" : {
+							"type" : "boolean"
+						}
+					}
+				},
+				"bundle_items" : {
+					"properties" : {
+						"product_id" : {
+							"type" : "keyword"
+						},
+						"sku" : {
+							"type" : "keyword"
+						},
+						"name" : {
+							"type" : "text"
+						},
+						"photo" : {
+							"type" : "keyword"
+						},
+						"price" : {
+							"type" : "float"
+						},
+						"quantity" : {
+							"type" : "long"
+						}
+					}
+				},
+				"wholesales" : {
+					"properties" : {
+						"min_quantity" : {
+							"type" : "long"
+						},
+						"price" : {
+							"type" : "float"
+						}
+					}
+				},
+				"attributes" : {
+					"type" : "nested",
+					"properties" : {
+						"name" : {
+							"type" : "keyword"
+						},
+						"value" : {
+							"type" : "keyword"
+						}
+					}
+				},
+				"variants" : {
+					"type" : "nested",
+					"properties" : {
+						"sku" : {
+							"type" : "keyword"
+						},
+						"color" : {
+							"type" : "keyword"
+						},
+						"size" : {
+							"type" : "keyword"
+						},
+						"price" : {
+							"type" : "float"
+						},
+						"stock" : {
+							"type" : "float"
+						},
+						"weight" : {
+							"type" : "float"
+						},
+						"photo" : {
+							"type" : "keyword"
+						}
+					}
+				},
 				"merchant" : {
 					"properties" : {
 						"_id" : {
@@ -141,7 +269,8 @@ func CreateIndexProduct(esClient *elasticsearch.Client) error {
 							}
 						},
 						"rating" : {"type" : "float"},
-						"num_review" : {"type" : "integer"}
+						"num_review" : {"type" : "integer"},
+						"suspended_until" : {"type" : "date"}
 					}
 				},
 				"rating" : {
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func CreateIndexVoucher(db *mongo.Database) error {
+	log.SetOutput(os.Stdout)
+	log.Println("Migration Voucher : starting!")
+
+	//buyer only input the code at checkout, so code is unique between every platform and merchant voucher
+	index := mongo.IndexModel{
+		Keys:    bson.M{"code": 1},
+		Options: options.Index().SetName("code_unique").SetUnique(true),
+	}
+	ctxCreateIndex, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := db.Collection("vouchers").Indexes().CreateOne(ctxCreateIndex, index); err != nil {
+		log.Printf("Migration voucher : failed cause, %s \n", err)
+		return err
+	}
+
+	log.Println("Migration Voucher : success!")
+	return nil
+}
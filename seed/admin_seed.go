@@ -41,6 +41,7 @@ func SeedSuperAdmin(adminUsecase logic.AdminUsecase) (domain.Admin, error) {
 		BirthDay: birthDay,
 		Phone:    "085273989895",
 		Gender:   "M",
+		Role:     domain.ADMIN_ROLE_SUPERADMIN,
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
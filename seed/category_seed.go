@@ -0,0 +1,91 @@
+package seed
+
+import (
+	"context"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/logic"
+)
+
+type categorySeed struct {
+	name     string
+	children []categorySeed
+}
+
+// defaultCategories is category tree used before categories were managed by admin
+var defaultCategories = []categorySeed{
+	{"elektronik", []categorySeed{
+		{"dapur", []categorySeed{{"blender", nil}, {"juicer", nil}, {"kompor listrik", nil}, {"kulkas", nil}, {"microwave", nil}, {"mixer", nil}}},
+		{"kantor", []categorySeed{{"mesin fax", nil}, {"mesin fotocopy", nil}, {"mesin hitung uang", nil}, {"mesin kasir", nil}}},
+		{"rumah", []categorySeed{{"mesin cuci", nil}, {"setrika", nil}}},
+		{"lainnya", []categorySeed{{"lain-lain", nil}}},
+	}},
+	{"komputer & laptop", []categorySeed{
+		{"komputer & laptop", []categorySeed{{"komputer", nil}, {"laptop", nil}}},
+		{"aksesoris", []categorySeed{{"keyboard", nil}, {"mouse", nil}, {"lain lain", nil}}},
+	}},
+	{"handphone & tablet", []categorySeed{
+		{"handphone & tablet", []categorySeed{{"handpone", nil}, {"tablet", nil}}},
+		{"aksesoris", []categorySeed{{"charger", nil}, {"casing", nil}, {"anti gores", nil}}},
+	}},
+	{"fashion pria", []categorySeed{
+		{"atasan pria", []categorySeed{{"kaos pria", nil}, {"kaos polo pria", nil}, {"kemeja pria", nil}}},
+		{"celana pria", []categorySeed{{"celana jeans pria", nil}, {"celana pendek pria", nil}, {"celana chino pria", nil}}},
+	}},
+	{"fashion wanita", []categorySeed{
+		{"atasan wanita", []categorySeed{{"kaos wanita", nil}, {"kaos polo wanita", nil}, {"kemeja wanita", nil}}},
+		{"celana wanita", []categorySeed{{"celana jeans wanita", nil}, {"celana pendek wanita", nil}, {"celana chino wanita", nil}}},
+	}},
+}
+
+var nonSlugCharacters = regexp.MustCompile(`[^a-z0-9]+`)
+
+func SeedCategory(categoryUsecase logic.CategoryUsecase) ([]domain.CategoryNode, error) {
+	log.SetOutput(os.Stdout)
+	log.Println("Seed Category : starting!")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var categories []domain.CategoryNode
+	var seedNodes func(parent domain.CategoryNode, seeds []categorySeed) error
+	seedNodes = func(parent domain.CategoryNode, seeds []categorySeed) error {
+		for order, seed := range seeds {
+			//slug is prefixed by parent slug, sub category name is repeated in other parent
+			slug := strings.Trim(nonSlugCharacters.ReplaceAllString(seed.name, "-"), "-")
+			if parent.Slug != "" {
+				slug = parent.Slug + "-" + slug
+			}
+			input := adapter.CategoryCreateInput{
+				ParentID: parent.ID,
+				Slug:     slug,
+				Name:     seed.name,
+				Order:    order,
+			}
+			category, err := categoryUsecase.Create(ctx, input)
+			if err != nil {
+				log.Printf("Seed Category : failed cause, %s \n", err)
+				return err
+			}
+			categories = append(categories, category)
+
+			if err := seedNodes(category, seed.children); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := seedNodes(domain.CategoryNode{}, defaultCategories); err != nil {
+		return categories, err
+	}
+
+	log.Println("Seed Category : success!")
+	return categories, nil
+}
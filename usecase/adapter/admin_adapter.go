@@ -16,6 +16,7 @@ type AdminCreateInput struct {
 	BirthDay   time.Time `json:"birth_day"`
 	Phone      string    `json:"phone"`
 	Gender     string    `json:"gender"`
+	Role       string    `json:"role"`
 }
 
 type AdminUpdateInput struct {
@@ -29,6 +30,7 @@ type AdminUpdateInput struct {
 type AdminSearchOptions struct {
 	Name  string `json:"name"`
 	Email string `json:"email"`
+	Role  string `json:"role"`
 }
 
 type AdminUpdatePasswordInput struct {
@@ -36,6 +38,10 @@ type AdminUpdatePasswordInput struct {
 	RePassword string `json:"re_password"`
 }
 
+type AdminUpdateRoleInput struct {
+	Role string `json:"role"`
+}
+
 type AdminAddressCreateInput struct {
 	City       domain.City `json:"city"`
 	Street     string      `json:"street"`
@@ -54,6 +60,7 @@ type AdminAdapter interface {
 	DecodeCreateInput([]byte) (AdminCreateInput, error)
 	DecodeUpdateInput([]byte) (AdminUpdateInput, error)
 	DecodeUpdatePasswordInput([]byte) (AdminUpdatePasswordInput, error)
+	DecodeUpdateRoleInput([]byte) (AdminUpdateRoleInput, error)
 	DecodeAddressInput([]byte) (AdminAddressCreateInput, error)
 	DecodeAddressUpdate([]byte) (AdminAddressUpdateInput, error)
 }
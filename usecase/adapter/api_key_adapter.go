@@ -0,0 +1,10 @@
+package adapter
+
+type APIKeyCreateInput struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type APIKeyAdapter interface {
+	DecodeCreateInput([]byte) (APIKeyCreateInput, error)
+}
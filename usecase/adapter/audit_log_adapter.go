@@ -0,0 +1,12 @@
+package adapter
+
+type AuditLogSearchOptions struct {
+	ActorID    string `json:"actor_id"`
+	Action     string `json:"action"`
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+	RequestID  string `json:"request_id"`
+	//RFC3339 formatted time
+	From string `json:"from"`
+	To   string `json:"to"`
+}
@@ -3,8 +3,24 @@ package adapter
 type LoginInput struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	//guest cart merged into customer cart after login
+	GuestCartToken string `json:"guest_cart_token"`
+}
+
+type LoginChallengeInput struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recovery_code"`
+	GuestCartToken string `json:"guest_cart_token"`
+}
+
+type TwoFactorCodeInput struct {
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
 }
 
 type AuthenticationAdapter interface {
 	DecodeLoginInput([]byte) (LoginInput, error)
+	DecodeLoginChallengeInput([]byte) (LoginChallengeInput, error)
+	DecodeTwoFactorCodeInput([]byte) (TwoFactorCodeInput, error)
 }
@@ -0,0 +1,42 @@
+package adapter
+
+import "github.com/market-place/domain"
+
+// BundleItemInput is product of the merchant put in bundle, sku is required when product has variants
+type BundleItemInput struct {
+	ProductID string `json:"product_id"`
+	SKU       string `json:"sku"`
+	Quantity  int64  `json:"quantity"`
+}
+
+type BundleCreateInput struct {
+	SKU         string          `json:"sku"`
+	Status      string          `json:"status"`
+	Name        string          `json:"name"`
+	Category    domain.Category `json:"category"`
+	Etalase     string          `json:"etalase"`
+	Tags        []string        `json:"tags"`
+	Description string          `json:"description"`
+	//bundle price must be less than total price of its products
+	Price float64 `json:"price"`
+	//attributes are checked against attributes defined by category
+	Attributes []domain.ProductAttribute `json:"attributes"`
+	Items      []BundleItemInput         `json:"items"`
+}
+
+type BundleUpdateInput struct {
+	SKU         string                    `json:"sku"`
+	Name        string                    `json:"name"`
+	Category    domain.Category           `json:"category"`
+	Etalase     string                    `json:"etalase"`
+	Tags        []string                  `json:"tags"`
+	Description string                    `json:"description"`
+	Price       float64                   `json:"price"`
+	Attributes  []domain.ProductAttribute `json:"attributes"`
+	Items       []BundleItemInput         `json:"items"`
+}
+
+type BundleAdapter interface {
+	DecodeCreateInput([]byte) (BundleCreateInput, error)
+	DecodeUpdateInput([]byte) (BundleUpdateInput, error)
+}
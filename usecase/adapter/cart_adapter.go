@@ -2,6 +2,7 @@ package adapter
 
 type CartAddItemInput struct {
 	ProductID string   `json:"product_id"`
+	SKU       string   `json:"sku"`
 	Quantity  int64    `json:"quantity"`
 	Note      string   `json:"note"`
 	Colors    []string `json:"colors"`
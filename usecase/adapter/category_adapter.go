@@ -0,0 +1,30 @@
+package adapter
+
+import "github.com/market-place/domain"
+
+type CategoryCreateInput struct {
+	//empty parent id create top level category
+	ParentID string `json:"parent_id"`
+	Slug     string `json:"slug"`
+	Name     string `json:"name"`
+	Icon     string `json:"icon"`
+	Order    int    `json:"order"`
+	//sub categories inherit attributes of their parents
+	Attributes []domain.CategoryAttribute `json:"attributes"`
+}
+
+type CategoryUpdateInput struct {
+	//category is moved when parent id changed, new parent must be in same level as current parent
+	ParentID string `json:"parent_id"`
+	Slug     string `json:"slug"`
+	Name     string `json:"name"`
+	Icon     string `json:"icon"`
+	Order    int    `json:"order"`
+	//sub categories inherit attributes of their parents
+	Attributes []domain.CategoryAttribute `json:"attributes"`
+}
+
+type CategoryAdapter interface {
+	DecodeCreateInput([]byte) (CategoryCreateInput, error)
+	DecodeUpdateInput([]byte) (CategoryUpdateInput, error)
+}
@@ -0,0 +1,173 @@
+package adapterCSV
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+const listSeparator = "|"
+
+type AdapterProductCSV struct{}
+
+// DecodeImportRows require header of domain.PRODUCT_CSV_HEADER in any order, value that is not valid number is reported per row
+func (a *AdapterProductCSV) DecodeImportRows(input []byte) ([]adapter.ProductImportRow, error) {
+	reader := csv.NewReader(bytes.NewReader(input))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		fmt.Printf("[CSV-PRODUCT-ADAPTER] : DECODE HEADER %#v \n", err)
+		return nil, usecase_error.ErrBadParamInput
+	}
+	columns := map[string]int{}
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	for _, column := range domain.PRODUCT_CSV_HEADER {
+		if _, ok := columns[column]; !ok {
+			err := usecase_error.ErrBadEntityInput{
+				usecase_error.ErrEntityField{
+					Field:   "File",
+					Message: "Column " + column + " is missing",
+				},
+			}
+			return nil, err
+		}
+	}
+
+	rows := []adapter.ProductImportRow{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		row := adapter.ProductImportRow{Row: line}
+		if err != nil {
+			//malformed line is reported, next line can still be read
+			row.Errors = append(row.Errors, usecase_error.ErrEntityField{
+				Field:   "Row",
+				Message: err.Error(),
+			})
+			rows = append(rows, row)
+			continue
+		}
+
+		value := func(column string) string {
+			index := columns[column]
+			if index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+		number := func(column, field string) float64 {
+			if value(column) == "" {
+				return 0
+			}
+			n, err := strconv.ParseFloat(value(column), 64)
+			if err != nil {
+				row.Errors = append(row.Errors, usecase_error.ErrEntityField{
+					Field:   field,
+					Message: field + " is not valid number",
+				})
+			}
+			return n
+		}
+
+		row.Input = adapter.ProductCreateInput{
+			SKU:         value("sku"),
+			Name:        value("name"),
+			Description: value("description"),
+			Etalase:     value("etalase"),
+			Category: domain.Category{
+				Top:       value("category_top"),
+				SecondSub: value("category_second_sub"),
+				ThirdSub:  value("category_third_sub"),
+			},
+			Tags:   splitList(value("tags")),
+			Colors: splitList(value("colors")),
+			Sizes:  splitList(value("sizes")),
+			Price:  number("price", "Price"),
+			Stock:  number("stock", "Stock"),
+			Weight: number("weight", "Weight"),
+			Width:  number("width", "Width"),
+			Height: number("height", "Height"),
+			Long:   number("long", "Long"),
+		}
+		row.Input.Attributes = []domain.ProductAttribute{}
+		for _, item := range splitList(value("attributes")) {
+			pair := strings.SplitN(item, "=", 2)
+			if len(pair) != 2 {
+				row.Errors = append(row.Errors, usecase_error.ErrEntityField{
+					Field:   "Attributes",
+					Message: "Attribute " + item + " must be written as name=value",
+				})
+				continue
+			}
+			row.Input.Attributes = append(row.Input.Attributes, domain.ProductAttribute{
+				Name:  strings.TrimSpace(pair[0]),
+				Value: strings.TrimSpace(pair[1]),
+			})
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func (a *AdapterProductCSV) EncodeProducts(products []domain.Product) ([]byte, error) {
+	var body bytes.Buffer
+	writer := csv.NewWriter(&body)
+	if err := writer.Write(domain.PRODUCT_CSV_HEADER); err != nil {
+		fmt.Printf("[CSV-PRODUCT-ADAPTER] : ENCODE HEADER %#v \n", err)
+		return nil, usecase_error.ErrInternalServerError
+	}
+
+	number := func(n float64) string {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	for _, product := range products {
+		attributes := []string{}
+		for _, attribute := range product.Attributes {
+			attributes = append(attributes, attribute.Name+"="+attribute.Value)
+		}
+		record := []string{
+			product.SKU, product.Name, product.Description, product.Etalase,
+			product.Category.Top, product.Category.SecondSub, product.Category.ThirdSub,
+			strings.Join(product.Tags, listSeparator),
+			strings.Join(product.Colors, listSeparator),
+			strings.Join(product.Sizes, listSeparator),
+			number(product.Price), number(product.Stock),
+			number(product.Weight), number(product.Width), number(product.Height), number(product.Long),
+			strings.Join(attributes, listSeparator),
+		}
+		if err := writer.Write(record); err != nil {
+			fmt.Printf("[CSV-PRODUCT-ADAPTER] : ENCODE PRODUCT %#v \n", err)
+			return nil, usecase_error.ErrInternalServerError
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		fmt.Printf("[CSV-PRODUCT-ADAPTER] : ENCODE FLUSH %#v \n", err)
+		return nil, usecase_error.ErrInternalServerError
+	}
+	return body.Bytes(), nil
+}
+
+func splitList(value string) []string {
+	list := []string{}
+	for _, item := range strings.Split(value, listSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -45,6 +45,10 @@ type CustomerUpdatePasswordInput struct {
 	RePassword string `json:"re_password"`
 }
 
+type PhoneOTPInput struct {
+	Code string `json:"code"`
+}
+
 type CustomerBankCreateInput struct {
 	Number   string `json:"number"`
 	BankCode string `json:"bank_code"`
@@ -68,4 +72,5 @@ type CustomerAdapter interface {
 	DecodeBankUpdate([]byte) (CustomerBankUpdateInput, error)
 	DecodeAddressInput([]byte) (CustomerAddressCreateInput, error)
 	DecodeAddressUpdate([]byte) (CustomerAddressUpdateInput, error)
+	DecodePhoneOTPInput([]byte) (PhoneOTPInput, error)
 }
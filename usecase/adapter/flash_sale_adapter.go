@@ -0,0 +1,23 @@
+package adapter
+
+import "time"
+
+// FlashSaleCreateInput schedule flash sale of product, sku is required when product has variants
+type FlashSaleCreateInput struct {
+	SKU   string  `json:"sku"`
+	Price float64 `json:"price"`
+	Quota int64   `json:"quota"`
+	//default to 1 when empty
+	LimitPerCustomer int64     `json:"limit_per_customer"`
+	StartAt          time.Time `json:"start_at"`
+	EndAt            time.Time `json:"end_at"`
+}
+
+type FlashSaleSearchOptions struct {
+	ProductID  string
+	MerchantID string
+}
+
+type FlashSaleAdapter interface {
+	DecodeCreateInput([]byte) (FlashSaleCreateInput, error)
+}
@@ -37,6 +37,15 @@ func (a *AdapterAdminJSON) DecodeUpdatePasswordInput(input []byte) (adapter.Admi
 	return password, nil
 }
 
+func (a *AdapterAdminJSON) DecodeUpdateRoleInput(input []byte) (adapter.AdminUpdateRoleInput, error) {
+	var role adapter.AdminUpdateRoleInput
+	if err := json.Unmarshal(input, &role); err != nil {
+		fmt.Printf("[JSON-ADMIN-ADAPTER] : DECODE UPDATE ROLE %#v \n", err)
+		return role, usecase_error.ErrBadParamInput
+	}
+	return role, nil
+}
+
 func (a *AdapterAdminJSON) DecodeAddressInput(input []byte) (adapter.AdminAddressCreateInput, error) {
 	var address adapter.AdminAddressCreateInput
 	if err := json.Unmarshal(input, &address); err != nil {
@@ -0,0 +1,20 @@
+package adapterJSON
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type AdapterAPIKeyJSON struct{}
+
+func (a *AdapterAPIKeyJSON) DecodeCreateInput(input []byte) (adapter.APIKeyCreateInput, error) {
+	var apiKey adapter.APIKeyCreateInput
+	if err := json.Unmarshal(input, &apiKey); err != nil {
+		fmt.Printf("[JSON-API-KEY-ADAPTER] : DECODE CREATE INPUT %#v \n", err)
+		return apiKey, usecase_error.ErrBadParamInput
+	}
+	return apiKey, nil
+}
@@ -16,3 +16,19 @@ func (a *AdapterAuthJSON) DecodeLoginInput(input []byte) (adapter.LoginInput, er
 	}
 	return login, nil
 }
+
+func (a *AdapterAuthJSON) DecodeLoginChallengeInput(input []byte) (adapter.LoginChallengeInput, error) {
+	var challenge adapter.LoginChallengeInput
+	if err := json.Unmarshal(input, &challenge); err != nil {
+		return challenge, usecase_error.ErrBadParamInput
+	}
+	return challenge, nil
+}
+
+func (a *AdapterAuthJSON) DecodeTwoFactorCodeInput(input []byte) (adapter.TwoFactorCodeInput, error) {
+	var code adapter.TwoFactorCodeInput
+	if err := json.Unmarshal(input, &code); err != nil {
+		return code, usecase_error.ErrBadParamInput
+	}
+	return code, nil
+}
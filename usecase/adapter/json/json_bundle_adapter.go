@@ -0,0 +1,29 @@
+package adapterJSON
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type AdapterBundleJSON struct{}
+
+func (a *AdapterBundleJSON) DecodeCreateInput(input []byte) (adapter.BundleCreateInput, error) {
+	var bundle adapter.BundleCreateInput
+	if err := json.Unmarshal(input, &bundle); err != nil {
+		fmt.Printf("[JSON-BUNDLE-ADAPTER] : DECODE CREATE INPUT %#v \n", err)
+		return bundle, usecase_error.ErrBadParamInput
+	}
+	return bundle, nil
+}
+
+func (a *AdapterBundleJSON) DecodeUpdateInput(input []byte) (adapter.BundleUpdateInput, error) {
+	var bundle adapter.BundleUpdateInput
+	if err := json.Unmarshal(input, &bundle); err != nil {
+		fmt.Printf("[JSON-BUNDLE-ADAPTER] : DECODE UPDATE INPUT %#v \n", err)
+		return bundle, usecase_error.ErrBadParamInput
+	}
+	return bundle, nil
+}
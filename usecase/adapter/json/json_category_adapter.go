@@ -0,0 +1,29 @@
+package adapterJSON
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type AdapterCategoryJSON struct{}
+
+func (a *AdapterCategoryJSON) DecodeCreateInput(input []byte) (adapter.CategoryCreateInput, error) {
+	var category adapter.CategoryCreateInput
+	if err := json.Unmarshal(input, &category); err != nil {
+		fmt.Printf("[JSON-CATEGORY-ADAPTER] : DECODE CREATE INPUT %#v \n", err)
+		return category, usecase_error.ErrBadParamInput
+	}
+	return category, nil
+}
+
+func (a *AdapterCategoryJSON) DecodeUpdateInput(input []byte) (adapter.CategoryUpdateInput, error) {
+	var category adapter.CategoryUpdateInput
+	if err := json.Unmarshal(input, &category); err != nil {
+		fmt.Printf("[JSON-CATEGORY-ADAPTER] : DECODE UPDATE INPUT %#v \n", err)
+		return category, usecase_error.ErrBadParamInput
+	}
+	return category, nil
+}
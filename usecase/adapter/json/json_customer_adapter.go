@@ -66,3 +66,11 @@ func (a *AdapaterCustomerJSON) DecodeAddressUpdate(input []byte) (adapter.Custom
 	}
 	return address, nil
 }
+
+func (a *AdapaterCustomerJSON) DecodePhoneOTPInput(input []byte) (adapter.PhoneOTPInput, error) {
+	var otp adapter.PhoneOTPInput
+	if err := json.Unmarshal(input, &otp); err != nil {
+		return otp, usecase_error.ErrBadParamInput
+	}
+	return otp, nil
+}
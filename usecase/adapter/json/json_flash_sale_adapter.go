@@ -0,0 +1,20 @@
+package adapterJSON
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type AdapterFlashSaleJSON struct{}
+
+func (a *AdapterFlashSaleJSON) DecodeCreateInput(input []byte) (adapter.FlashSaleCreateInput, error) {
+	var flashSale adapter.FlashSaleCreateInput
+	if err := json.Unmarshal(input, &flashSale); err != nil {
+		fmt.Printf("[JSON-FLASH-SALE-ADAPTER] : DECODE CREATE INPUT %#v \n", err)
+		return flashSale, usecase_error.ErrBadParamInput
+	}
+	return flashSale, nil
+}
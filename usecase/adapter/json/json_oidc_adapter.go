@@ -0,0 +1,29 @@
+package adapterJSON
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type AdapterOIDCJSON struct{}
+
+func (a *AdapterOIDCJSON) DecodeCallbackInput(input []byte) (adapter.OIDCCallbackInput, error) {
+	var callback adapter.OIDCCallbackInput
+	if err := json.Unmarshal(input, &callback); err != nil {
+		fmt.Printf("[JSON-OIDC-ADAPTER] : DECODE CALLBACK INPUT %#v \n", err)
+		return callback, usecase_error.ErrBadParamInput
+	}
+	return callback, nil
+}
+
+func (a *AdapterOIDCJSON) DecodeProfileInput(input []byte) (adapter.OIDCProfileInput, error) {
+	var profile adapter.OIDCProfileInput
+	if err := json.Unmarshal(input, &profile); err != nil {
+		fmt.Printf("[JSON-OIDC-ADAPTER] : DECODE PROFILE INPUT %#v \n", err)
+		return profile, usecase_error.ErrBadParamInput
+	}
+	return profile, nil
+}
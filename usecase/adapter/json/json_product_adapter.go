@@ -28,3 +28,30 @@ func (a *AdapterProductJSON) DecodeUpdateInput(input []byte) (adapter.ProductUpd
 	}
 	return product, nil
 }
+
+func (a *AdapterProductJSON) DecodeBulkUpdateInput(input []byte) (adapter.ProductBulkUpdateInput, error) {
+	var bulk adapter.ProductBulkUpdateInput
+	if err := json.Unmarshal(input, &bulk); err != nil {
+		fmt.Printf("[JSON-PRODUCT-ADAPTER] : DECODE BULK UPDATE INPUT %#v \n", err)
+		return bulk, usecase_error.ErrBadParamInput
+	}
+	return bulk, nil
+}
+
+func (a *AdapterProductJSON) DecodeStatusInput(input []byte) (adapter.ProductStatusInput, error) {
+	var status adapter.ProductStatusInput
+	if err := json.Unmarshal(input, &status); err != nil {
+		fmt.Printf("[JSON-PRODUCT-ADAPTER] : DECODE STATUS INPUT %#v \n", err)
+		return status, usecase_error.ErrBadParamInput
+	}
+	return status, nil
+}
+
+func (a *AdapterProductJSON) DecodeDiscountInput(input []byte) (adapter.ProductDiscountInput, error) {
+	var discount adapter.ProductDiscountInput
+	if err := json.Unmarshal(input, &discount); err != nil {
+		fmt.Printf("[JSON-PRODUCT-ADAPTER] : DECODE DISCOUNT INPUT %#v \n", err)
+		return discount, usecase_error.ErrBadParamInput
+	}
+	return discount, nil
+}
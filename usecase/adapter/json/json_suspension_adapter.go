@@ -0,0 +1,20 @@
+package adapterJSON
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type AdapterSuspensionJSON struct{}
+
+func (a *AdapterSuspensionJSON) DecodeSuspensionInput(input []byte) (adapter.SuspensionInput, error) {
+	var suspension adapter.SuspensionInput
+	if err := json.Unmarshal(input, &suspension); err != nil {
+		fmt.Printf("[JSON-SUSPENSION-ADAPTER] : DECODE SUSPENSION INPUT %#v \n", err)
+		return suspension, usecase_error.ErrBadParamInput
+	}
+	return suspension, nil
+}
@@ -0,0 +1,29 @@
+package adapterJSON
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type AdapterVoucherJSON struct{}
+
+func (a *AdapterVoucherJSON) DecodeCreateInput(input []byte) (adapter.VoucherCreateInput, error) {
+	var voucher adapter.VoucherCreateInput
+	if err := json.Unmarshal(input, &voucher); err != nil {
+		fmt.Printf("[JSON-VOUCHER-ADAPTER] : DECODE CREATE INPUT %#v \n", err)
+		return voucher, usecase_error.ErrBadParamInput
+	}
+	return voucher, nil
+}
+
+func (a *AdapterVoucherJSON) DecodeUpdateInput(input []byte) (adapter.VoucherUpdateInput, error) {
+	var voucher adapter.VoucherUpdateInput
+	if err := json.Unmarshal(input, &voucher); err != nil {
+		fmt.Printf("[JSON-VOUCHER-ADAPTER] : DECODE UPDATE INPUT %#v \n", err)
+		return voucher, usecase_error.ErrBadParamInput
+	}
+	return voucher, nil
+}
@@ -0,0 +1,20 @@
+package adapterJSON
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type AdapterWishlistJSON struct{}
+
+func (a *AdapterWishlistJSON) DecodeAddInput(input []byte) (adapter.WishlistAddInput, error) {
+	var wishlist adapter.WishlistAddInput
+	if err := json.Unmarshal(input, &wishlist); err != nil {
+		fmt.Printf("[JSON-WISHLIST-ADAPTER] : DECODE ADD INPUT %#v \n", err)
+		return wishlist, usecase_error.ErrBadParamInput
+	}
+	return wishlist, nil
+}
@@ -0,0 +1,25 @@
+package adapter
+
+import (
+	"time"
+)
+
+type OIDCCallbackInput struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+	//guest cart merged into customer cart after login
+	GuestCartToken string `json:"guest_cart_token"`
+}
+
+type OIDCProfileInput struct {
+	Addresses []Address `json:"addresses"`
+	Born      string    `json:"born"`
+	BirthDay  time.Time `json:"birth_day"`
+	Phone     string    `json:"phone"`
+	Gender    string    `json:"gender"`
+}
+
+type OIDCAdapter interface {
+	DecodeCallbackInput([]byte) (OIDCCallbackInput, error)
+	DecodeProfileInput([]byte) (OIDCProfileInput, error)
+}
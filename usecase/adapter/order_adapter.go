@@ -2,6 +2,7 @@ package adapter
 
 type ProductOrder struct {
 	ProductID string   `json:"product_id"`
+	SKU       string   `json:"sku"`
 	Quantity  int64    `json:"quantity"`
 	BuyerNote string   `json:"buyer_note"`
 	Colors    []string `json:"colors"`
@@ -9,18 +10,23 @@ type ProductOrder struct {
 }
 
 type Order struct {
-	MerchantID      string         `json:"merchant_id"`
-	ReceiverName    string         `json:"receiver_name"`
-	ReceiverPhone   string         `json:"receiver_phone"`
-	ReceiverAddress Address        `json:"receiver_address"`
-	ShippingID      string         `json:"shipping_id"`
-	ShippingCost    int64          `json:"shipping_cost"`
-	ServiceName     string         `json:"service_name"`
-	Products        []ProductOrder `json:"products"`
+	MerchantID      string  `json:"merchant_id"`
+	ReceiverName    string  `json:"receiver_name"`
+	ReceiverPhone   string  `json:"receiver_phone"`
+	ReceiverAddress Address `json:"receiver_address"`
+	ShippingID      string  `json:"shipping_id"`
+	ShippingCost    int64   `json:"shipping_cost"`
+	ServiceName     string  `json:"service_name"`
+	PaymentMethod   string  `json:"payment_method"`
+	//voucher of the order merchant, optional
+	VoucherCode string         `json:"voucher_code"`
+	Products    []ProductOrder `json:"products"`
 }
 
 type OrderCreateInput struct {
 	Orders []Order `json:"orders"`
+	//platform voucher split between orders it applies to, optional
+	VoucherCode string `json:"voucher_code"`
 }
 
 type OrderResiInput struct {
@@ -1,8 +1,14 @@
 package adapter
 
-import "github.com/market-place/domain"
+import (
+	"time"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/usecase_error"
+)
 
 type ProductUpdateInput struct {
+	SKU         string          `json:"sku"`
 	Name        string          `json:"name"`
 	Category    domain.Category `json:"category"`
 	Tags        []string        `json:"tags"`
@@ -16,9 +22,17 @@ type ProductUpdateInput struct {
 	Description string          `json:"description"`
 	Price       float64         `json:"price"`
 	Stock       float64         `json:"stock"`
+	//price, stock, colors and sizes are taken from variants when product has variants
+	Variants []domain.Variant `json:"variants"`
+	//attributes are checked against attributes defined by category
+	Attributes []domain.ProductAttribute `json:"attributes"`
+	//wholesale tiers must have increasing min quantity and decreasing price
+	Wholesales []domain.WholesaleTier `json:"wholesales"`
 }
 
 type ProductCreateInput struct {
+	SKU         string          `json:"sku"`
+	Status      string          `json:"status"`
 	Name        string          `json:"name"`
 	Category    domain.Category `json:"category"`
 	Etalase     string          `json:"etalase"`
@@ -32,9 +46,19 @@ type ProductCreateInput struct {
 	Description string          `json:"description"`
 	Price       float64         `json:"price"`
 	Stock       float64         `json:"stock"`
+	//price, stock, colors and sizes are taken from variants when product has variants
+	Variants []domain.Variant `json:"variants"`
+	//attributes are checked against attributes defined by category
+	Attributes []domain.ProductAttribute `json:"attributes"`
+	//wholesale tiers must have increasing min quantity and decreasing price
+	Wholesales []domain.WholesaleTier `json:"wholesales"`
 }
 
 type ProductSearchOptions struct {
+	//product's status equals to search status, only owner merchant can search other than ACTIVE
+	Status string
+	//only owner merchant can search its soft deleted products
+	Deleted bool
 	//product's name contains regex search name keyword
 	Name string
 	//product's categories have item with category name contains regex search category keyword
@@ -50,7 +74,46 @@ type ProductSearchOptions struct {
 	City string
 }
 
+// ProductDiscountInput schedule discount of product, value is percent for PERCENT type and amount for FIXED type
+type ProductDiscountInput struct {
+	Type    string    `json:"type"`
+	Value   float64   `json:"value"`
+	StartAt time.Time `json:"start_at"`
+	EndAt   time.Time `json:"end_at"`
+}
+
+type ProductStatusInput struct {
+	Status string `json:"status"`
+}
+
+// ProductStockPriceInput change stock and or price of product, or of its variant when sku is filled
+type ProductStockPriceInput struct {
+	ProductID string   `json:"product_id"`
+	SKU       string   `json:"sku"`
+	Stock     *float64 `json:"stock"`
+	Price     *float64 `json:"price"`
+}
+
+type ProductBulkUpdateInput struct {
+	Items []ProductStockPriceInput `json:"items"`
+}
+
 type ProductAdapter interface {
 	DecodeCreateInput([]byte) (ProductCreateInput, error)
 	DecodeUpdateInput([]byte) (ProductUpdateInput, error)
+	DecodeBulkUpdateInput([]byte) (ProductBulkUpdateInput, error)
+	DecodeStatusInput([]byte) (ProductStatusInput, error)
+	DecodeDiscountInput([]byte) (ProductDiscountInput, error)
+}
+
+// ProductImportRow is one csv line, row with Errors could not be parsed and is not imported
+type ProductImportRow struct {
+	Row    int
+	Input  ProductCreateInput
+	Errors usecase_error.ErrBadEntityInput
+}
+
+type ProductCSVAdapter interface {
+	DecodeImportRows([]byte) ([]ProductImportRow, error)
+	EncodeProducts([]domain.Product) ([]byte, error)
 }
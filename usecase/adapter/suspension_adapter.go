@@ -0,0 +1,14 @@
+package adapter
+
+import "time"
+
+// SuspensionInput zero until means suspension has no end date, ban never has end date
+type SuspensionInput struct {
+	Status string    `json:"status"`
+	Reason string    `json:"reason"`
+	Until  time.Time `json:"until"`
+}
+
+type SuspensionAdapter interface {
+	DecodeSuspensionInput([]byte) (SuspensionInput, error)
+}
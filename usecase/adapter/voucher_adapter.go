@@ -0,0 +1,52 @@
+package adapter
+
+import (
+	"time"
+
+	"github.com/market-place/domain"
+)
+
+type VoucherCreateInput struct {
+	Code string `json:"code"`
+	//FIXED, PERCENT or FREE_SHIPPING
+	Type        string `json:"type"`
+	Value       int64  `json:"value"`
+	MaxDiscount int64  `json:"max_discount"`
+	MinSpend    int64  `json:"min_spend"`
+	//category path, empty second sub or third sub cover every sub category
+	Categories []domain.Category `json:"categories"`
+	//only used by platform voucher
+	MerchantIDs      []string  `json:"merchant_ids"`
+	NewCustomerOnly  bool      `json:"new_customer_only"`
+	Quota            int64     `json:"quota"`
+	QuotaPerCustomer int64     `json:"quota_per_customer"`
+	StartAt          time.Time `json:"start_at"`
+	EndAt            time.Time `json:"end_at"`
+}
+
+type VoucherUpdateInput struct {
+	Code            string            `json:"code"`
+	Type            string            `json:"type"`
+	Value           int64             `json:"value"`
+	MaxDiscount     int64             `json:"max_discount"`
+	MinSpend        int64             `json:"min_spend"`
+	Categories      []domain.Category `json:"categories"`
+	MerchantIDs     []string          `json:"merchant_ids"`
+	NewCustomerOnly bool              `json:"new_customer_only"`
+	//quota could not be less than used quota
+	Quota            int64     `json:"quota"`
+	QuotaPerCustomer int64     `json:"quota_per_customer"`
+	StartAt          time.Time `json:"start_at"`
+	EndAt            time.Time `json:"end_at"`
+}
+
+type VoucherSearchOptions struct {
+	//empty merchant id search platform vouchers
+	MerchantID string
+	Code       string
+}
+
+type VoucherAdapter interface {
+	DecodeCreateInput([]byte) (VoucherCreateInput, error)
+	DecodeUpdateInput([]byte) (VoucherUpdateInput, error)
+}
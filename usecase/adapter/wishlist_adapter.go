@@ -0,0 +1,11 @@
+package adapter
+
+type WishlistAddInput struct {
+	ProductID string `json:"product_id"`
+	SKU       string `json:"sku"`
+	Note      string `json:"note"`
+}
+
+type WishlistAdapter interface {
+	DecodeAddInput(input []byte) (WishlistAddInput, error)
+}
@@ -0,0 +1,37 @@
+package helper
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/market-place/usecase/usecase_error"
+)
+
+const (
+	apiKeyPrefixSize = 4
+	apiKeySecretSize = 24
+)
+
+// GenerateAPIKey return plain key with format mp_<prefix>_<secret>, prefix is saved to help merchant recognize the key
+func GenerateAPIKey() (string, string, error) {
+	prefix := make([]byte, apiKeyPrefixSize)
+	secret := make([]byte, apiKeySecretSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return "", "", usecase_error.ErrInternalServerError
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", usecase_error.ErrInternalServerError
+	}
+
+	encodedPrefix := hex.EncodeToString(prefix)
+	key := fmt.Sprintf("mp_%s_%s", encodedPrefix, hex.EncodeToString(secret))
+	return key, encodedPrefix, nil
+}
+
+// HashAPIKey use sha256, key is random with high entropy so slow hash is not needed and hash can be looked up
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,53 @@
+package helper
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// AuditDiff return only top level fields that changed between before and after.
+// Entity is compared by its json form so fields hidden from json (password, secret) never enter the log.
+func AuditDiff(before, after interface{}) (map[string]interface{}, map[string]interface{}) {
+	beforeFields := toAuditFields(before)
+	afterFields := toAuditFields(after)
+
+	changedBefore := map[string]interface{}{}
+	changedAfter := map[string]interface{}{}
+	for field, value := range beforeFields {
+		if field == "updated_at" {
+			continue
+		}
+		if afterValue, ok := afterFields[field]; !ok || !reflect.DeepEqual(value, afterValue) {
+			changedBefore[field] = value
+		}
+	}
+	for field, value := range afterFields {
+		if field == "updated_at" {
+			continue
+		}
+		if beforeValue, ok := beforeFields[field]; !ok || !reflect.DeepEqual(value, beforeValue) {
+			changedAfter[field] = value
+		}
+	}
+
+	return changedBefore, changedAfter
+}
+
+// AuditSnapshot copy entity state before mutation, entity slices may be changed in place afterwards
+func AuditSnapshot(entity interface{}) map[string]interface{} {
+	return toAuditFields(entity)
+}
+
+func toAuditFields(entity interface{}) map[string]interface{} {
+	fields := map[string]interface{}{}
+	if entity == nil {
+		return fields
+	}
+
+	raw, err := json.Marshal(entity)
+	if err != nil {
+		return fields
+	}
+	json.Unmarshal(raw, &fields)
+	return fields
+}
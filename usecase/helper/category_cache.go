@@ -0,0 +1,86 @@
+package helper
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/market-place/domain"
+)
+
+// CATEGORY_CACHE_TTL is how long category tree is kept before reloaded,
+// category changed in other instance is seen by this instance after this duration
+var CATEGORY_CACHE_TTL = 5 * time.Minute
+
+// CategoryLoader read every category node from storage
+type CategoryLoader func() ([]domain.CategoryNode, error)
+
+type categoryCache struct {
+	mutex    sync.RWMutex
+	loader   CategoryLoader
+	paths    map[domain.Category][]domain.CategoryAttribute
+	loadedAt time.Time
+}
+
+var categories = &categoryCache{}
+
+// SetCategoryLoader register loader used by category validator, cached categories are dropped
+func SetCategoryLoader(loader CategoryLoader) {
+	categories.mutex.Lock()
+	defer categories.mutex.Unlock()
+	categories.loader = loader
+	categories.loadedAt = time.Time{}
+}
+
+// InvalidateCategoryCache make next category lookup read categories from storage
+func InvalidateCategoryCache() {
+	categories.mutex.Lock()
+	defer categories.mutex.Unlock()
+	categories.loadedAt = time.Time{}
+}
+
+// IsRegisteredCategory is true when category is path of a third sub level category node
+func IsRegisteredCategory(category domain.Category) bool {
+	_, ok := CategoryAttributes(category)
+	return ok
+}
+
+// CategoryAttributes is attribute schemas of registered category, ok is false when category is not registered
+func CategoryAttributes(category domain.Category) ([]domain.CategoryAttribute, bool) {
+	categories.mutex.RLock()
+	fresh := !categories.loadedAt.IsZero() && time.Since(categories.loadedAt) < CATEGORY_CACHE_TTL
+	paths := categories.paths
+	categories.mutex.RUnlock()
+
+	if !fresh {
+		paths = categories.reload()
+	}
+	attributes, ok := paths[category]
+	return attributes, ok
+}
+
+// reload keep previous paths when loader fail, so validation still work while storage is unavailable.
+// failed load is stamped too, so storage is tried again after ttl instead of on every lookup
+func (c *categoryCache) reload() map[domain.Category][]domain.CategoryAttribute {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.loader == nil {
+		return c.paths
+	}
+
+	nodes, err := c.loader()
+	if err != nil {
+		fmt.Printf("[CATEGORY CACHE] : RELOAD %#v \n", err)
+		c.loadedAt = time.Now()
+		return c.paths
+	}
+	paths := map[domain.Category][]domain.CategoryAttribute{}
+	for _, node := range nodes {
+		if node.Level == domain.CATEGORY_LEVEL_THIRD_SUB {
+			paths[domain.CategoryPath(nodes, node.ID)] = domain.CategoryAttributes(nodes, node.ID)
+		}
+	}
+	c.paths = paths
+	c.loadedAt = time.Now()
+	return paths
+}
@@ -1,33 +1,94 @@
 package helper
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/market-place/usecase/usecase_error"
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	HASH_ALGORITHM_BCRYPT   = "bcrypt"
+	HASH_ALGORITHM_ARGON2ID = "argon2id"
+)
+
+const (
+	defaultBcryptCost     = 12
+	defaultArgon2Memory   = 64 * 1024
+	defaultArgon2Time     = 3
+	defaultArgon2Threads  = 2
+	argon2SaltSize        = 16
+	argon2KeySize         = 32
+	argon2HashPrefixStart = "$argon2id$"
+)
+
 type Encription interface {
 	Encrypt(pass []byte) (string, error)
 	Compare(hashedPassword, password []byte) bool
+	// NeedsRehash is true when hash is made with other algorithm or parameter than configured
+	NeedsRehash(hashedPassword []byte) bool
+	// HashPrefix is the prefix shared by every hash made with configured algorithm and parameter
+	HashPrefix() string
 }
 
+// NewEncription read algorithm and parameter from env :
+// PASSWORD_HASH_ALGORITHM (bcrypt | argon2id), PASSWORD_BCRYPT_COST,
+// PASSWORD_ARGON2_MEMORY (KiB), PASSWORD_ARGON2_TIME, PASSWORD_ARGON2_THREADS
 func NewEncription() Encription {
-	return &bcryptEncription{
-		cost: bcrypt.MinCost,
+	bcryptCost := envInt("PASSWORD_BCRYPT_COST", defaultBcryptCost)
+	if bcryptCost < bcrypt.MinCost || bcryptCost > bcrypt.MaxCost {
+		bcryptCost = defaultBcryptCost
+	}
+
+	return &passwordEncription{
+		algorithm:     strings.ToLower(os.Getenv("PASSWORD_HASH_ALGORITHM")),
+		bcryptCost:    bcryptCost,
+		argon2Memory:  uint32(envInt("PASSWORD_ARGON2_MEMORY", defaultArgon2Memory)),
+		argon2Time:    uint32(envInt("PASSWORD_ARGON2_TIME", defaultArgon2Time)),
+		argon2Threads: uint8(envInt("PASSWORD_ARGON2_THREADS", defaultArgon2Threads)),
+	}
+}
+
+func envInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
 	}
+	return value
 }
 
-type bcryptEncription struct {
-	cost int
+type passwordEncription struct {
+	algorithm     string
+	bcryptCost    int
+	argon2Memory  uint32
+	argon2Time    uint32
+	argon2Threads uint8
 }
 
-func (b *bcryptEncription) Encrypt(pass []byte) (string, error) {
+func (p *passwordEncription) Encrypt(pass []byte) (string, error) {
 	log.SetOutput(os.Stdout)
 
+	if p.algorithm == HASH_ALGORITHM_ARGON2ID {
+		salt := make([]byte, argon2SaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			log.Printf("Encryp password : %s \n", err)
+			return "", usecase_error.ErrInternalServerError
+		}
+		key := argon2.IDKey(pass, salt, p.argon2Time, p.argon2Memory, p.argon2Threads, argon2KeySize)
+		encoding := base64.RawStdEncoding
+		return p.HashPrefix() + encoding.EncodeToString(salt) + "$" + encoding.EncodeToString(key), nil
+	}
+
 	var passwordEncrypted string
-	hash, err := bcrypt.GenerateFromPassword(pass, bcrypt.MinCost)
+	hash, err := bcrypt.GenerateFromPassword(pass, p.bcryptCost)
 	if err != nil {
 		log.Printf("Encryp password : %s \n", err)
 		return passwordEncrypted, usecase_error.ErrInternalServerError
@@ -37,8 +98,14 @@ func (b *bcryptEncription) Encrypt(pass []byte) (string, error) {
 	return passwordEncrypted, nil
 }
 
-func (b *bcryptEncription) Compare(hashedPassword, password []byte) bool {
+// Compare detect hash format, so password saved with previous algorithm can still login
+func (p *passwordEncription) Compare(hashedPassword, password []byte) bool {
 	log.SetOutput(os.Stdout)
+
+	if strings.HasPrefix(string(hashedPassword), argon2HashPrefixStart) {
+		return p.compareArgon2(string(hashedPassword), password)
+	}
+
 	var passwordCorrect bool = false
 	err := bcrypt.CompareHashAndPassword(hashedPassword, password)
 	if err != nil {
@@ -49,3 +116,45 @@ func (b *bcryptEncription) Compare(hashedPassword, password []byte) bool {
 	passwordCorrect = true
 	return passwordCorrect
 }
+
+func (p *passwordEncription) compareArgon2(hashedPassword string, password []byte) bool {
+	// $argon2id$v=19$m=65536,t=3,p=2$salt$key
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 {
+		log.Printf("Decrypt password : %s \n", "argon2id hash format not valid")
+		return false
+	}
+
+	var memory, time uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		log.Printf("Decrypt password : %s \n", err)
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		log.Printf("Decrypt password : %s \n", err)
+		return false
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		log.Printf("Decrypt password : %s \n", err)
+		return false
+	}
+
+	compared := argon2.IDKey(password, salt, time, memory, threads, uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, compared) == 1
+}
+
+func (p *passwordEncription) NeedsRehash(hashedPassword []byte) bool {
+	return !strings.HasPrefix(string(hashedPassword), p.HashPrefix())
+}
+
+func (p *passwordEncription) HashPrefix() string {
+	if p.algorithm == HASH_ALGORITHM_ARGON2ID {
+		return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$", argon2HashPrefixStart, argon2.Version, p.argon2Memory, p.argon2Time, p.argon2Threads)
+	}
+
+	// golang bcrypt always write 2a version with two digit cost
+	return fmt.Sprintf("$2a$%02d$", p.bcryptCost)
+}
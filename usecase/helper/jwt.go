@@ -43,12 +43,95 @@ func DecodeToken(token string) (domain.Credential, error) {
 	if !ok || !jwtToken.Valid {
 		return credential, usecase_error.ErrNotAuthentication
 	}
+	//login challenge and guest cart token cannot be used as credential
+	if subject, _ := claims["sub"].(string); subject == domain.LOGIN_CHALLENGE_SUBJECT || subject == domain.GUEST_CART_SUBJECT {
+		return credential, usecase_error.ErrNotAuthentication
+	}
 
-	credential.Email = claims["email"].(string)
-	credential.Issuer = claims["iss"].(string)
-	credential.UserID = claims["user_id"].(string)
-	credential.CartID = claims["cart_id"].(string)
-	credential.LoginType = claims["login_type"].(string)
+	var okEmail, okIssuer, okUserID, okCartID, okLoginType bool
+	credential.Email, okEmail = claims["email"].(string)
+	credential.Issuer, okIssuer = claims["iss"].(string)
+	credential.UserID, okUserID = claims["user_id"].(string)
+	credential.CartID, okCartID = claims["cart_id"].(string)
+	credential.LoginType, okLoginType = claims["login_type"].(string)
+	if !okEmail || !okIssuer || !okUserID || !okCartID || !okLoginType {
+		return domain.Credential{}, usecase_error.ErrNotAuthentication
+	}
+	//session id, token issued before session exist has no jti claim
+	if sessionID, ok := claims["jti"].(string); ok {
+		credential.Id = sessionID
+	}
+	//token issued before admin roles exist has no role claim
+	if role, ok := claims["role"].(string); ok {
+		credential.Role = role
+	}
+	if impersonatorID, ok := claims["impersonator_id"].(string); ok {
+		credential.ImpersonatorID = impersonatorID
+	}
 
 	return credential, nil
 }
+
+func EncodeChallengeToken(challenge domain.LoginChallenge) (string, error) {
+	var token string
+	unSingnedToken := jwt.NewWithClaims(JWT_SIGNING_METHOD, challenge)
+	token, err := unSingnedToken.SignedString(JWT_SIGNATURE_KEY)
+	if err != nil {
+		return token, usecase_error.ErrInternalServerError
+	}
+	return token, nil
+}
+
+func DecodeChallengeToken(token string) (domain.LoginChallenge, error) {
+	var challenge domain.LoginChallenge
+
+	jwtToken, err := jwt.ParseWithClaims(token, &challenge, func(token *jwt.Token) (interface{}, error) {
+		method, ok := token.Method.(*jwt.SigningMethodHMAC)
+		if !ok || method != JWT_SIGNING_METHOD {
+			err := errors.New("Token method is not match")
+			return nil, err
+		}
+
+		return JWT_SIGNATURE_KEY, nil
+	})
+	if err != nil || !jwtToken.Valid {
+		return challenge, usecase_error.ErrNotAuthentication
+	}
+	if challenge.Subject != domain.LOGIN_CHALLENGE_SUBJECT {
+		return challenge, usecase_error.ErrNotAuthentication
+	}
+
+	return challenge, nil
+}
+
+func EncodeGuestCartToken(guestCart domain.GuestCartToken) (string, error) {
+	var token string
+	unSingnedToken := jwt.NewWithClaims(JWT_SIGNING_METHOD, guestCart)
+	token, err := unSingnedToken.SignedString(JWT_SIGNATURE_KEY)
+	if err != nil {
+		return token, usecase_error.ErrInternalServerError
+	}
+	return token, nil
+}
+
+func DecodeGuestCartToken(token string) (domain.GuestCartToken, error) {
+	var guestCart domain.GuestCartToken
+
+	jwtToken, err := jwt.ParseWithClaims(token, &guestCart, func(token *jwt.Token) (interface{}, error) {
+		method, ok := token.Method.(*jwt.SigningMethodHMAC)
+		if !ok || method != JWT_SIGNING_METHOD {
+			err := errors.New("Token method is not match")
+			return nil, err
+		}
+
+		return JWT_SIGNATURE_KEY, nil
+	})
+	if err != nil || !jwtToken.Valid {
+		return guestCart, usecase_error.ErrNotAuthentication
+	}
+	if guestCart.Subject != domain.GUEST_CART_SUBJECT || guestCart.CartID == "" {
+		return guestCart, usecase_error.ErrNotAuthentication
+	}
+
+	return guestCart, nil
+}
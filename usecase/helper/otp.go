@@ -0,0 +1,41 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/market-place/usecase/usecase_error"
+)
+
+const phoneOTPDigits = 6
+
+// GeneratePhoneOTP return random numeric code
+func GeneratePhoneOTP() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < phoneOTPDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", usecase_error.ErrInternalServerError
+	}
+	return fmt.Sprintf("%0*d", phoneOTPDigits, n.Int64()), nil
+}
+
+// HashPhoneOTP use hmac with server secret, six digit code is too short for plain hash.
+// Code is bound to customer and phone so it can not be used after phone is changed
+func HashPhoneOTP(customerID, phone, code string) string {
+	mac := hmac.New(sha256.New, JWT_SIGNATURE_KEY)
+	mac.Write([]byte(customerID + ":" + phone + ":" + code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func ComparePhoneOTP(hashedCode, customerID, phone, code string) bool {
+	return subtle.ConstantTimeCompare([]byte(hashedCode), []byte(HashPhoneOTP(customerID, phone, code))) == 1
+}
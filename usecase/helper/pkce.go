@@ -0,0 +1,40 @@
+package helper
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"github.com/market-place/usecase/usecase_error"
+)
+
+const (
+	pkceVerifierSize = 32
+	oidcTokenSize    = 24
+)
+
+// GeneratePKCE return code verifier and its S256 code challenge (RFC 7636)
+func GeneratePKCE() (string, string, error) {
+	verifier, err := GenerateOIDCToken(pkceVerifierSize)
+	if err != nil {
+		return "", "", err
+	}
+
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+	return verifier, challenge, nil
+}
+
+// GenerateOIDCToken return url safe random string used for state, nonce and random password,
+// size zero use default size
+func GenerateOIDCToken(size int) (string, error) {
+	if size == 0 {
+		size = oidcTokenSize
+	}
+
+	random := make([]byte, size)
+	if _, err := rand.Read(random); err != nil {
+		return "", usecase_error.ErrInternalServerError
+	}
+	return base64.RawURLEncoding.EncodeToString(random), nil
+}
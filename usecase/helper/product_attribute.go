@@ -0,0 +1,71 @@
+package helper
+
+import (
+	"strconv"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+// ValidateProductAttributes check attribute values of product against attribute schemas of its category,
+// attribute which is not defined by category is rejected
+func ValidateProductAttributes(category domain.Category, attributes []domain.ProductAttribute) error {
+	schemas, _ := CategoryAttributes(category)
+	schemaByName := map[string]domain.CategoryAttribute{}
+	for _, schema := range schemas {
+		schemaByName[schema.Name] = schema
+	}
+
+	var entityErrs usecase_error.ErrBadEntityInput
+	filled := map[string]bool{}
+	for _, attribute := range attributes {
+		schema, ok := schemaByName[attribute.Name]
+		if !ok {
+			entityErrs = append(entityErrs, usecase_error.ErrEntityField{
+				Field:   "Attributes",
+				Message: "Attribute " + attribute.Name + " is not defined by category",
+			})
+			continue
+		}
+		if attribute.Value == "" {
+			continue
+		}
+		filled[attribute.Name] = true
+
+		valid := true
+		switch schema.Type {
+		case domain.ATTRIBUTE_TYPE_NUMBER:
+			_, err := strconv.ParseFloat(attribute.Value, 64)
+			valid = err == nil
+		case domain.ATTRIBUTE_TYPE_BOOLEAN:
+			valid = attribute.Value == "true" || attribute.Value == "false"
+		case domain.ATTRIBUTE_TYPE_OPTION:
+			valid = false
+			for _, value := range schema.Values {
+				if value == attribute.Value {
+					valid = true
+					break
+				}
+			}
+		}
+		if !valid {
+			entityErrs = append(entityErrs, usecase_error.ErrEntityField{
+				Field:   "Attributes",
+				Message: "Attribute " + attribute.Name + " is not valid " + schema.Type,
+			})
+		}
+	}
+	for _, schema := range schemas {
+		if schema.Required && !filled[schema.Name] {
+			entityErrs = append(entityErrs, usecase_error.ErrEntityField{
+				Field:   "Attributes",
+				Message: "Attribute " + schema.Name + " is required",
+			})
+		}
+	}
+
+	if len(entityErrs) != 0 {
+		return entityErrs
+	}
+	return nil
+}
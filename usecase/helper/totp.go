@@ -0,0 +1,106 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+const (
+	totpDigits         = 6
+	totpPeriod         = 30
+	totpSkew           = 1
+	totpSecretSize     = 20
+	recoveryCodeSize   = 5
+	recoveryCodesCount = 10
+)
+
+// TOTP implements RFC 6238 time based one time password with SHA1, 6 digits and 30 seconds period
+type TOTP interface {
+	GenerateSecret() (string, error)
+	ProvisioningURI(secret, accountName string) string
+	// Validate returns the matched time step, so caller can reject a reused code
+	Validate(secret, code string, at time.Time) (int64, bool)
+	GenerateRecoveryCodes() ([]string, error)
+}
+
+func NewTOTP() TOTP {
+	return &totp{
+		encoding: base32.StdEncoding.WithPadding(base32.NoPadding),
+	}
+}
+
+type totp struct {
+	encoding *base32.Encoding
+}
+
+func (t *totp) GenerateSecret() (string, error) {
+	secret := make([]byte, totpSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", usecase_error.ErrInternalServerError
+	}
+	return t.encoding.EncodeToString(secret), nil
+}
+
+func (t *totp) ProvisioningURI(secret, accountName string) string {
+	label := url.PathEscape(fmt.Sprintf("%s:%s", domain.APPLICATION_NAME, accountName))
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", domain.APPLICATION_NAME)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprintf("%d", totpDigits))
+	query.Set("period", fmt.Sprintf("%d", totpPeriod))
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, query.Encode())
+}
+
+func (t *totp) Validate(secret, code string, at time.Time) (int64, bool) {
+	key, err := t.encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+
+	step := at.Unix() / totpPeriod
+	for i := int64(-totpSkew); i <= totpSkew; i++ {
+		expected := t.generate(key, step+i)
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step + i, true
+		}
+	}
+	return 0, false
+}
+
+func (t *totp) generate(key []byte, step int64) string {
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000)
+}
+
+func (t *totp) GenerateRecoveryCodes() ([]string, error) {
+	codes := []string{}
+	for i := 0; i < recoveryCodesCount; i++ {
+		raw := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, usecase_error.ErrInternalServerError
+		}
+		code := strings.ToLower(t.encoding.EncodeToString(raw))
+		codes = append(codes, fmt.Sprintf("%s-%s", code[:4], code[4:]))
+	}
+	return codes, nil
+}
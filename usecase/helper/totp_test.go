@@ -0,0 +1,67 @@
+package helper
+
+import (
+	"testing"
+	"time"
+)
+
+// secret of RFC 6238 test vectors, ASCII "12345678901234567890" encoded with base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPValidateRFC6238(t *testing.T) {
+	//SHA1 vectors of RFC 6238 appendix B, truncated to 6 digits
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	totp := NewTOTP()
+	for _, test := range tests {
+		at := time.Unix(test.unix, 0)
+		step, ok := totp.Validate(rfc6238Secret, test.code, at)
+		if !ok {
+			t.Errorf("Validate(%s) at %d is rejected", test.code, test.unix)
+			continue
+		}
+		if want := test.unix / totpPeriod; step != want {
+			t.Errorf("Validate(%s) at %d step = %d, want %d", test.code, test.unix, step, want)
+		}
+	}
+}
+
+func TestTOTPValidate(t *testing.T) {
+	at := time.Unix(59, 0)
+	tests := []struct {
+		name   string
+		secret string
+		code   string
+		at     time.Time
+		ok     bool
+		step   int64
+	}{
+		{"previous step is accepted", rfc6238Secret, "287082", at.Add(totpPeriod * time.Second), true, 1},
+		{"next step is accepted", rfc6238Secret, "287082", at.Add(-totpPeriod * time.Second), true, 1},
+		{"two steps late is rejected", rfc6238Secret, "287082", at.Add(2 * totpPeriod * time.Second), false, 0},
+		{"wrong code", rfc6238Secret, "287083", at, false, 0},
+		{"short code", rfc6238Secret, "28708", at, false, 0},
+		{"lower case secret", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", "287082", at, true, 1},
+		{"invalid secret", "not-base32!", "287082", at, false, 0},
+	}
+
+	totp := NewTOTP()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			step, ok := totp.Validate(test.secret, test.code, test.at)
+			if ok != test.ok || step != test.step {
+				t.Errorf("Validate() = (%d, %v), want (%d, %v)", step, ok, test.step, test.ok)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"reflect"
 	"regexp"
 
 	"github.com/go-playground/locales"
@@ -20,6 +21,8 @@ var (
 
 type ValidationEntity interface {
 	Validate(value interface{}) error
+	// ValidateExcept validate struct without given fields, used when entity is saved before those fields are known
+	ValidateExcept(value interface{}, fields ...string) error
 }
 
 type validator10 struct {
@@ -49,9 +52,17 @@ func NewValidationEntity() ValidationEntity {
 }
 
 func (v *validator10) Validate(value interface{}) error {
+	return v.entityError(v.validation.Struct(value))
+}
+
+func (v *validator10) ValidateExcept(value interface{}, fields ...string) error {
+	return v.entityError(v.validation.StructExcept(value, fields...))
+}
+
+func (v *validator10) entityError(err error) error {
 	log.SetOutput(os.Stdout)
 
-	if err := v.validation.Struct(value); err != nil {
+	if err != nil {
 		var entityErrs usecase_error.ErrBadEntityInput
 		errs := err.(validator.ValidationErrors)
 		for _, err := range errs {
@@ -170,6 +181,13 @@ func (v *validator10) RegisterTranslation() {
 		return t
 	})
 
+	v.validation.RegisterTranslation("voucher_value", v.trans, func(ut ut.Translator) error {
+		return ut.Add("voucher_value", "{0} of percent voucher must be between 1 and 100", true)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("voucher_value", fe.Field())
+		return t
+	})
+
 	v.validation.RegisterTranslation("unique_addresses", v.trans, func(ut ut.Translator) error {
 		return ut.Add("unique_addresses", "{0} is not unique", true)
 	}, func(ut ut.Translator, fe validator.FieldError) string {
@@ -212,6 +230,20 @@ func (v *validator10) RegisterTranslation() {
 		return t
 	})
 
+	v.validation.RegisterTranslation("unique_variants", v.trans, func(ut ut.Translator) error {
+		return ut.Add("unique_variants", "{0} sku and option combination must be unique", true)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("unique_variants", fe.Field())
+		return t
+	})
+
+	v.validation.RegisterTranslation("unique_attributes", v.trans, func(ut ut.Translator) error {
+		return ut.Add("unique_attributes", "{0} name must be unique", true)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("unique_attributes", fe.Field())
+		return t
+	})
+
 	v.validation.RegisterTranslation("category", v.trans, func(ut ut.Translator) error {
 		return ut.Add("category", "category is not valid", true)
 	}, func(ut ut.Translator, fe validator.FieldError) string {
@@ -219,6 +251,20 @@ func (v *validator10) RegisterTranslation() {
 		return t
 	})
 
+	v.validation.RegisterTranslation("admin_role", v.trans, func(ut ut.Translator) error {
+		return ut.Add("admin_role", "{0} is not registered role", true)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("admin_role", fe.Field())
+		return t
+	})
+
+	v.validation.RegisterTranslation("api_key_scopes", v.trans, func(ut ut.Translator) error {
+		return ut.Add("api_key_scopes", "{0} contains not registered scope", true)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("api_key_scopes", fe.Field())
+		return t
+	})
+
 	v.validation.RegisterTranslation("unique_etalase", v.trans, func(ut ut.Translator) error {
 		return ut.Add("unique_etalase", "Etalase is not unique", true)
 	}, func(ut ut.Translator, fe validator.FieldError) string {
@@ -242,8 +288,38 @@ func (v *validator10) RegisterValidation() {
 	v.validation.RegisterValidation("unique_colors", uniqueColors)
 	v.validation.RegisterValidation("unique_sizes", uniqueSizes)
 	v.validation.RegisterValidation("unique_items", uniqueItems)
+	v.validation.RegisterValidation("unique_variants", uniqueVariants)
+	v.validation.RegisterValidation("unique_attributes", uniqueAttributes)
 	v.validation.RegisterValidation("category", registeredCategories)
 	v.validation.RegisterValidation("unique_etalase", uniqueEtalase)
+	v.validation.RegisterValidation("admin_role", adminRole)
+	v.validation.RegisterValidation("api_key_scopes", apiKeyScopes)
+	v.validation.RegisterValidation("voucher_value", voucherValue)
+}
+
+// voucherValue limit value of percent voucher between 1 and 100, value of other voucher types is not a percent
+func voucherValue(fl validator.FieldLevel) bool {
+	if reflect.Indirect(fl.Parent()).FieldByName("Type").String() != domain.VOUCHER_TYPE_PERCENT {
+		return true
+	}
+	value := fl.Field().Int()
+	return value >= 1 && value <= 100
+}
+
+func apiKeyScopes(fl validator.FieldLevel) bool {
+	scopes := fl.Field().Interface().([]string)
+	counter := map[string]int{}
+	for _, scope := range scopes {
+		if !domain.RegisteredAPIKeyScopes[scope] || counter[scope] != 0 {
+			return false
+		}
+		counter[scope] = 1
+	}
+	return true
+}
+
+func adminRole(fl validator.FieldLevel) bool {
+	return domain.IsRegisteredAdminRole(fl.Field().String())
 }
 
 func uniqueEtalase(fl validator.FieldLevel) bool {
@@ -260,85 +336,10 @@ func uniqueEtalase(fl validator.FieldLevel) bool {
 	return true
 }
 
+// registeredCategories check category against admin managed category tree, see IsRegisteredCategory
 func registeredCategories(fl validator.FieldLevel) bool {
-	categories := map[string]map[string]map[string]bool{
-		"elektronik": map[string]map[string]bool{
-			"dapur": map[string]bool{
-				"blender":        true,
-				"juicer":         true,
-				"kompor listrik": true,
-				"kulkas":         true,
-				"microwave":      true,
-				"mixer":          true,
-			},
-			"kantor": map[string]bool{
-				"mesin fax":         true,
-				"mesin fotocopy":    true,
-				"mesin hitung uang": true,
-				"mesin kasir":       true,
-			},
-			"rumah": map[string]bool{
-				"mesin cuci": true,
-				"setrika":    true,
-			},
-			"lainnya": map[string]bool{
-				"lain-lain": true,
-			},
-		},
-		"komputer & laptop": map[string]map[string]bool{
-			"komputer & laptop": map[string]bool{
-				"komputer": true,
-				"laptop":   true,
-			},
-			"aksesoris": map[string]bool{
-				"keyboard":  true,
-				"mouse":     true,
-				"lain lain": true,
-			},
-		},
-		"handphone & tablet": map[string]map[string]bool{
-			"handphone & tablet": map[string]bool{
-				"handpone": true,
-				"tablet":   true,
-			},
-			"aksesoris": map[string]bool{
-				"charger":    true,
-				"casing":     true,
-				"anti gores": true,
-			},
-		},
-		"fashion pria": map[string]map[string]bool{
-			"atasan pria": map[string]bool{
-				"kaos pria":      true,
-				"kaos polo pria": true,
-				"kemeja pria":    true,
-			},
-			"celana pria": map[string]bool{
-				"celana jeans pria":  true,
-				"celana pendek pria": true,
-				"celana chino pria":  true,
-			},
-		},
-		"fashion wanita": map[string]map[string]bool{
-			"atasan wanita": map[string]bool{
-				"kaos wanita":      true,
-				"kaos polo wanita": true,
-				"kemeja wanita":    true,
-			},
-			"celana wanita": map[string]bool{
-				"celana jeans wanita":  true,
-				"celana pendek wanita": true,
-				"celana chino wanita":  true,
-			},
-		},
-	}
-
 	category := fl.Field().Interface().(domain.Category)
-	if categories[category.Top] != nil && categories[category.Top][category.SecondSub] != nil && categories[category.Top][category.SecondSub][category.ThirdSub] {
-		return true
-	}
-
-	return false
+	return IsRegisteredCategory(category)
 }
 
 func uniqueBankAccounts(fl validator.FieldLevel) bool {
@@ -420,7 +421,7 @@ func uniqueItems(fl validator.FieldLevel) bool {
 	items := fl.Field().Interface().([]domain.Item)
 	counter := map[string]int{}
 	for _, item := range items {
-		key := fmt.Sprintf("%s", item.Product.ID)
+		key := fmt.Sprintf("%s-%s", item.Product.ID, item.SKU)
 		if counter[key] != 0 {
 			return false
 		}
@@ -431,6 +432,46 @@ func uniqueItems(fl validator.FieldLevel) bool {
 	return true
 }
 
+func uniqueVariants(fl validator.FieldLevel) bool {
+	variants := fl.Field().Interface().([]domain.Variant)
+	skus := map[string]int{}
+	options := map[string]int{}
+	for _, variant := range variants {
+		option := fmt.Sprintf("%s-%s", variant.Color, variant.Size)
+		if skus[variant.SKU] != 0 || options[option] != 0 {
+			return false
+		}
+
+		skus[variant.SKU] = 1
+		options[option] = 1
+	}
+	return true
+}
+
+// uniqueAttributes is used by category attribute schemas and product attribute values
+func uniqueAttributes(fl validator.FieldLevel) bool {
+	names := []string{}
+	switch attributes := fl.Field().Interface().(type) {
+	case []domain.CategoryAttribute:
+		for _, attribute := range attributes {
+			names = append(names, attribute.Name)
+		}
+	case []domain.ProductAttribute:
+		for _, attribute := range attributes {
+			names = append(names, attribute.Name)
+		}
+	}
+
+	seen := map[string]int{}
+	for _, name := range names {
+		if seen[name] != 0 {
+			return false
+		}
+		seen[name] = 1
+	}
+	return true
+}
+
 func bankProvider(fl validator.FieldLevel) bool {
 	const (
 		BCA     = "014"
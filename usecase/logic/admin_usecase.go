@@ -21,23 +21,31 @@ type AdminUsecase interface {
 	UpdateBiodata(ctx context.Context, adminBiodata adapter.AdminUpdateInput, adminID string) (domain.Admin, error)
 	UploadAvatar(ctx context.Context, fileName, adminID string) (domain.Admin, error)
 	UpdatePassword(ctx context.Context, input adapter.AdminUpdatePasswordInput, adminID string) (domain.Admin, error)
+	UpdateRole(ctx context.Context, input adapter.AdminUpdateRoleInput, adminID string) (domain.Admin, error)
 	AddAddress(ctx context.Context, address adapter.AdminAddressCreateInput, adminID string) (domain.Admin, error)
 	UpdateAddress(ctx context.Context, input adapter.AdminAddressUpdateInput, addresID, adminID string) (domain.Admin, error)
 	RemoveAddress(ctx context.Context, addressID, adminID string) (domain.Admin, error)
 	DeleteOne(ctx context.Context, admin domain.Admin) (domain.Admin, error)
+	AssignLegacyRole(ctx context.Context) (int64, error)
 }
 
 type adminUsecase struct {
 	adminRepo      repository.AdminRepository
+	sessionRepo    repository.SessionRepository
+	auditLogger    auditLogger
 	contextTimeout time.Duration
 }
 
 func NewAdminUsecase(
 	adminRepo repository.AdminRepository,
+	sessionRepo repository.SessionRepository,
+	auditLogRepo repository.AuditLogRepository,
 	contextTimeout time.Duration,
 ) AdminUsecase {
 	return &adminUsecase{
 		adminRepo:      adminRepo,
+		sessionRepo:    sessionRepo,
+		auditLogger:    newAuditLogger(auditLogRepo),
 		contextTimeout: contextTimeout,
 	}
 }
@@ -104,6 +112,7 @@ func (a *adminUsecase) Create(ctx context.Context, input adapter.AdminCreateInpu
 	admin.BirthDay = input.BirthDay
 	admin.Phone = input.Phone
 	admin.Gender = input.Gender
+	admin.Role = input.Role
 	admin.Avatar = "https://storage.googleapis.com/ecommerce_s2l_assets/default-user.png"
 	admin.CreatedAt = time.Now().Truncate(time.Millisecond)
 	admin.UpdatedAt = time.Now().Truncate(time.Millisecond)
@@ -141,7 +150,13 @@ func (a *adminUsecase) Create(ctx context.Context, input adapter.AdminCreateInpu
 
 	ctx, cancel := context.WithTimeout(ctx, a.contextTimeout)
 	defer cancel()
-	return a.adminRepo.Create(ctx, admin)
+	admin, err = a.adminRepo.Create(ctx, admin)
+	if err != nil {
+		return admin, err
+	}
+
+	a.auditLogger.record(ctx, domain.AUDIT_ACTION_ADMIN_CREATE, domain.AUDIT_TARGET_ADMIN, admin.ID, nil, admin)
+	return admin, nil
 }
 
 func (a *adminUsecase) GetByID(ctx context.Context, adminID string) (domain.Admin, error) {
@@ -157,6 +172,7 @@ func (a *adminUsecase) Fetch(ctx context.Context, cursor string, num int64, opti
 	search := domain.AdminSearchOptions{
 		Name:  options.Name,
 		Email: options.Email,
+		Role:  options.Role,
 	}
 
 	admins, err := a.adminRepo.Fetch(ctx, cursor, num, search)
@@ -229,7 +245,55 @@ func (a *adminUsecase) UpdatePassword(ctx context.Context, input adapter.AdminUp
 	}
 	admin.Password = hashedPassword
 
-	return a.adminRepo.UpdateOne(ctx, admin)
+	admin, err = a.adminRepo.UpdateOne(ctx, admin)
+	if err != nil {
+		return admin, err
+	}
+	if err := a.sessionRepo.RevokeAll(ctx, admin.ID, domain.LOGIN_AS_ADMIN, time.Now()); err != nil {
+		return admin, err
+	}
+
+	return admin, nil
+}
+
+func (a *adminUsecase) UpdateRole(ctx context.Context, input adapter.AdminUpdateRoleInput, adminID string) (domain.Admin, error) {
+	credential := ctx.Value("credential")
+	if credential == nil {
+		return domain.Admin{}, usecase_error.ErrNotAuthorization
+	}
+	userInfo := credential.(domain.Credential)
+
+	ctx, cancel := context.WithTimeout(ctx, a.contextTimeout)
+	defer cancel()
+	admin, err := a.adminRepo.GetByID(ctx, adminID)
+	if err != nil {
+		return admin, err
+	}
+
+	//prevent superadmin locking himself out
+	if userInfo.UserID == admin.ID {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Role",
+				Message: "Role cannot be changed by the admin himself",
+			},
+		}
+		return admin, err
+	}
+
+	before := helper.AuditSnapshot(admin)
+	admin.Role = input.Role
+	if err := a.validate(admin); err != nil {
+		return admin, err
+	}
+
+	admin, err = a.adminRepo.UpdateOne(ctx, admin)
+	if err != nil {
+		return admin, err
+	}
+
+	a.auditLogger.record(ctx, domain.AUDIT_ACTION_ADMIN_UPDATE_ROLE, domain.AUDIT_TARGET_ADMIN, admin.ID, before, admin)
+	return admin, nil
 }
 
 func (a *adminUsecase) AddAddress(ctx context.Context, input adapter.AdminAddressCreateInput, adminID string) (domain.Admin, error) {
@@ -316,3 +380,13 @@ func (a *adminUsecase) RemoveAddress(ctx context.Context, addressID, adminID str
 func (a *adminUsecase) DeleteOne(ctx context.Context, admin domain.Admin) (domain.Admin, error) {
 	return admin, nil
 }
+
+// AssignLegacyRole give superadmin role to admin registered before admin roles exist,
+// every admin had full access then and admin without role fails validation of every update.
+// It is run once by migration, role is required for every admin created afterward
+func (a *adminUsecase) AssignLegacyRole(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.contextTimeout)
+	defer cancel()
+
+	return a.adminRepo.AssignMissingRole(ctx, domain.ADMIN_ROLE_SUPERADMIN)
+}
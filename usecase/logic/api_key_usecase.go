@@ -0,0 +1,121 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	guuid "github.com/google/uuid"
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/helper"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type APIKeyUsecase interface {
+	Create(ctx context.Context, input adapter.APIKeyCreateInput, merchantID string) (domain.APIKey, string, error)
+	Fetch(ctx context.Context, cursor string, num int64, merchantID string) ([]domain.APIKey, error)
+	Revoke(ctx context.Context, apiKeyID, merchantID string) (domain.APIKey, error)
+}
+
+type apiKeyUsecase struct {
+	apiKeyRepo     repository.APIKeyRepository
+	merchantRepo   repository.MerchantRepository
+	auditLogger    auditLogger
+	contextTimeout time.Duration
+}
+
+func NewAPIKeyUsecase(
+	apiKeyRepo repository.APIKeyRepository,
+	merchantRepo repository.MerchantRepository,
+	auditLogRepo repository.AuditLogRepository,
+	contextTimeout time.Duration,
+) APIKeyUsecase {
+	return &apiKeyUsecase{
+		apiKeyRepo:     apiKeyRepo,
+		merchantRepo:   merchantRepo,
+		auditLogger:    newAuditLogger(auditLogRepo),
+		contextTimeout: contextTimeout,
+	}
+}
+
+func (a *apiKeyUsecase) validate(value interface{}) error {
+	if entityErr := helper.NewValidationEntity().Validate(value); entityErr != nil {
+		return entityErr
+	}
+
+	return nil
+}
+
+// Create return saved key and the plain key, plain key is only shown once
+func (a *apiKeyUsecase) Create(ctx context.Context, input adapter.APIKeyCreateInput, merchantID string) (domain.APIKey, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.contextTimeout)
+	defer cancel()
+
+	if _, err := a.merchantRepo.GetByID(ctx, merchantID); err != nil {
+		return domain.APIKey{}, "", err
+	}
+
+	plainKey, prefix, err := helper.GenerateAPIKey()
+	if err != nil {
+		return domain.APIKey{}, "", err
+	}
+
+	apiKey := domain.APIKey{}
+	apiKey.ID = guuid.New().String()
+	apiKey.MerchantID = merchantID
+	apiKey.Name = input.Name
+	apiKey.Prefix = prefix
+	apiKey.HashedKey = helper.HashAPIKey(plainKey)
+	apiKey.Scopes = input.Scopes
+	apiKey.CreatedAt = time.Now().Truncate(time.Millisecond)
+	apiKey.UpdatedAt = time.Now().Truncate(time.Millisecond)
+	if err := a.validate(apiKey); err != nil {
+		return apiKey, "", err
+	}
+
+	apiKey, err = a.apiKeyRepo.Create(ctx, apiKey)
+	if err != nil {
+		return apiKey, "", err
+	}
+
+	a.auditLogger.record(ctx, domain.AUDIT_ACTION_API_KEY_CREATE, domain.AUDIT_TARGET_API_KEY, apiKey.ID, nil, apiKey)
+	return apiKey, plainKey, nil
+}
+
+func (a *apiKeyUsecase) Fetch(ctx context.Context, cursor string, num int64, merchantID string) ([]domain.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.contextTimeout)
+	defer cancel()
+
+	search := domain.APIKeySearchOptions{
+		MerchantID: merchantID,
+	}
+	return a.apiKeyRepo.Fetch(ctx, cursor, num, search)
+}
+
+func (a *apiKeyUsecase) Revoke(ctx context.Context, apiKeyID, merchantID string) (domain.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.contextTimeout)
+	defer cancel()
+
+	apiKey, err := a.apiKeyRepo.GetByID(ctx, apiKeyID)
+	if err != nil {
+		return apiKey, err
+	}
+	if apiKey.MerchantID != merchantID {
+		return apiKey, usecase_error.ErrNotFound
+	}
+	if apiKey.Revoked {
+		return apiKey, nil
+	}
+
+	before := helper.AuditSnapshot(apiKey)
+	apiKey.Revoked = true
+	apiKey.RevokedAt = time.Now().Truncate(time.Millisecond)
+	apiKey, err = a.apiKeyRepo.UpdateOne(ctx, apiKey)
+	if err != nil {
+		return apiKey, err
+	}
+
+	a.auditLogger.record(ctx, domain.AUDIT_ACTION_API_KEY_REVOKE, domain.AUDIT_TARGET_API_KEY, apiKey.ID, before, apiKey)
+	return apiKey, nil
+}
@@ -0,0 +1,110 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/helper"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type AuditLogUsecase interface {
+	Fetch(ctx context.Context, cursor string, num int64, options adapter.AuditLogSearchOptions) ([]domain.AuditLog, error)
+}
+
+type auditLogUsecase struct {
+	auditLogRepo   repository.AuditLogRepository
+	contextTimeout time.Duration
+}
+
+func NewAuditLogUsecase(
+	auditLogRepo repository.AuditLogRepository,
+	contextTimeout time.Duration,
+) AuditLogUsecase {
+	return &auditLogUsecase{
+		auditLogRepo:   auditLogRepo,
+		contextTimeout: contextTimeout,
+	}
+}
+
+func (a *auditLogUsecase) Fetch(ctx context.Context, cursor string, num int64, options adapter.AuditLogSearchOptions) ([]domain.AuditLog, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.contextTimeout)
+	defer cancel()
+
+	search := domain.AuditLogSearchOptions{
+		ActorID:    options.ActorID,
+		Action:     options.Action,
+		TargetType: options.TargetType,
+		TargetID:   options.TargetID,
+		RequestID:  options.RequestID,
+	}
+	if options.From != "" {
+		from, err := time.Parse(time.RFC3339, options.From)
+		if err != nil {
+			return nil, usecase_error.ErrBadParamInput
+		}
+		search.From = from
+	}
+	if options.To != "" {
+		to, err := time.Parse(time.RFC3339, options.To)
+		if err != nil {
+			return nil, usecase_error.ErrBadParamInput
+		}
+		search.To = to
+	}
+
+	return a.auditLogRepo.Fetch(ctx, cursor, num, search)
+}
+
+// auditLogger is embedded by usecases which mutate data on behalf of admin or merchant owner.
+// Actor is taken from credential in context, ip and request id are set by http middleware.
+type auditLogger struct {
+	auditLogRepo repository.AuditLogRepository
+}
+
+func newAuditLogger(auditLogRepo repository.AuditLogRepository) auditLogger {
+	return auditLogger{
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+// record never fail the mutation, it is called after the change is saved
+func (a auditLogger) record(ctx context.Context, action, targetType, targetID string, before, after interface{}) {
+	if a.auditLogRepo == nil {
+		return
+	}
+
+	auditLog := domain.AuditLog{
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+	}
+	if credential, ok := ctx.Value("credential").(domain.Credential); ok {
+		auditLog.ActorID = credential.UserID
+		auditLog.ActorType = credential.LoginType
+		auditLog.ActorRole = credential.Role
+		auditLog.MerchantID = credential.MerchantID
+		//real actor of impersonation is the admin
+		if credential.IsImpersonation() {
+			auditLog.ActorID = credential.ImpersonatorID
+			auditLog.ActorType = domain.LOGIN_AS_ADMIN
+			auditLog.ActorRole = ""
+			auditLog.MerchantID = ""
+		}
+	}
+	if ip, ok := ctx.Value("ip").(string); ok {
+		auditLog.IP = ip
+	}
+	if requestID, ok := ctx.Value("request_id").(string); ok {
+		auditLog.RequestID = requestID
+	}
+	auditLog.Before, auditLog.After = helper.AuditDiff(before, after)
+
+	if _, err := a.auditLogRepo.Create(ctx, auditLog); err != nil {
+		fmt.Printf("[AUDIT LOG] : RECORD %s %s %#v \n", action, targetID, err)
+	}
+}
@@ -13,11 +13,17 @@ import (
 )
 
 type AuthenticationUsecase interface {
-	LoginCustomer(context.Context, adapter.LoginInput) (domain.Credential, error)
-	LoginAdmin(context.Context, adapter.LoginInput) (domain.Credential, error)
+	LoginCustomer(context.Context, adapter.LoginInput) (domain.Credential, domain.LoginChallenge, error)
+	LoginAdmin(context.Context, adapter.LoginInput) (domain.LoginChallenge, domain.TwoFactorEnrollment, error)
+	VerifyLoginChallenge(context.Context, adapter.LoginChallengeInput) (domain.Credential, []string, error)
+	LegacyPasswordHashCount(context.Context) (map[string]int64, error)
 	ValidateLogin(token string) (domain.Credential, error)
+	ValidateAPIKey(apiKey string) (domain.Credential, error)
+	ValidateLoginOrAPIKey(token, apiKey string) (domain.Credential, error)
 	VerifiedAsCustomer(domain.Credential) error
 	VerifiedAsAdmin(domain.Credential) error
+	VerifiedAdminPermission(domain.Credential, string) error
+	VerifiedAPIKeyScope(domain.Credential, string) error
 	VerifiedCustomerAuthor(domain.Credential, string) error
 	VerifiedAdminAuthor(domain.Credential, string) error
 	VerifiedMerchantOwner(domain.Credential, string) error
@@ -30,27 +36,45 @@ type AuthenticationUsecase interface {
 
 type authenticationUseCase struct {
 	customerRepo   repository.CustomerRepository
+	merchantRepo   repository.MerchantRepository
 	adminRepo      repository.AdminRepository
 	productRepo    repository.ProductRepository
 	tbuyerRepo     repository.TBuyerRepository
 	orderRepo      repository.OrderRepository
+	apiKeyRepo     repository.APIKeyRepository
+	sessionRepo    repository.SessionRepository
+	cartRepo       repository.CartRepository
+	guestCartRepo  repository.GuestCartRepository
+	twoFactorLimit twoFactorLimiter
 	contextTimeout time.Duration
 }
 
 func NewAuthenticationUseCase(
 	customerRepo repository.CustomerRepository,
+	merchantRepo repository.MerchantRepository,
 	adminRepo repository.AdminRepository,
 	productRepo repository.ProductRepository,
 	tbuyerRepo repository.TBuyerRepository,
 	orderRepo repository.OrderRepository,
+	apiKeyRepo repository.APIKeyRepository,
+	sessionRepo repository.SessionRepository,
+	cartRepo repository.CartRepository,
+	guestCartRepo repository.GuestCartRepository,
+	twoFactorAttemptRepo repository.TwoFactorAttemptRepository,
 	contextTimeout time.Duration,
 ) AuthenticationUsecase {
 	return &authenticationUseCase{
 		customerRepo:   customerRepo,
+		merchantRepo:   merchantRepo,
 		adminRepo:      adminRepo,
 		productRepo:    productRepo,
 		tbuyerRepo:     tbuyerRepo,
 		orderRepo:      orderRepo,
+		apiKeyRepo:     apiKeyRepo,
+		sessionRepo:    sessionRepo,
+		cartRepo:       cartRepo,
+		guestCartRepo:  guestCartRepo,
+		twoFactorLimit: newTwoFactorLimiter(twoFactorAttemptRepo),
 		contextTimeout: contextTimeout,
 	}
 }
@@ -64,6 +88,10 @@ func (a *authenticationUseCase) ValidateLogin(token string) (domain.Credential,
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
+	if err := a.validateSession(ctx, credential); err != nil {
+		fmt.Printf("[AUTHENTICATION] : VALIDATE SESSION %#v \n", err)
+		return credential, err
+	}
 	switch credential.LoginType {
 	case domain.LOGIN_AS_ADMIN:
 		if err := a.validateAdminActive(ctx, &credential); err != nil {
@@ -75,11 +103,91 @@ func (a *authenticationUseCase) ValidateLogin(token string) (domain.Credential,
 			fmt.Printf("[AUTHENTICATION] : VALIDATE CUSTOMER ACTIVE %#v \n", err)
 			return credential, err
 		}
+		if credential.IsImpersonation() {
+			if err := a.validateImpersonator(ctx, credential.ImpersonatorID); err != nil {
+				fmt.Printf("[AUTHENTICATION] : VALIDATE IMPERSONATOR %#v \n", err)
+				return credential, err
+			}
+		}
 	}
 
 	return credential, err
 }
 
+// validateSession reject token whose session is revoked, idle too long or issued before session exist
+func (a *authenticationUseCase) validateSession(ctx context.Context, credential domain.Credential) error {
+	if credential.Id == "" {
+		return usecase_error.ErrNotAuthentication
+	}
+
+	session, err := a.sessionRepo.GetByID(ctx, credential.Id)
+	if err != nil {
+		if err == usecase_error.ErrNotFound {
+			return usecase_error.ErrNotAuthentication
+		}
+		return err
+	}
+	now := time.Now()
+	if session.UserID != credential.UserID || session.LoginType != credential.LoginType || !session.IsActive(now) {
+		return usecase_error.ErrNotAuthentication
+	}
+
+	if now.Sub(session.LastActivityAt) > domain.SESSION_ACTIVITY_INTERVAL {
+		if err := a.sessionRepo.UpdateLastActivity(ctx, session.ID, now); err != nil {
+			fmt.Printf("[AUTHENTICATION] : VALIDATE SESSION UPDATE LAST ACTIVITY %#v \n", err)
+		}
+	}
+	return nil
+}
+
+// startSession save new session for the login and put its id into credential
+func (a *authenticationUseCase) startSession(ctx context.Context, credential *domain.Credential) error {
+	return startLoginSession(ctx, a.sessionRepo, credential)
+}
+
+// ValidateAPIKey build merchant credential from api key, the credential only carry merchant id and key scopes
+func (a *authenticationUseCase) ValidateAPIKey(apiKey string) (domain.Credential, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), a.contextTimeout)
+	defer cancel()
+
+	search := domain.APIKeySearchOptions{
+		HashedKey: helper.HashAPIKey(apiKey),
+	}
+	apiKeys, err := a.apiKeyRepo.Fetch(ctx, "", 1, search)
+	if err != nil || len(apiKeys) == 0 {
+		fmt.Printf("[AUTHENTICATION] : VALIDATE API KEY %#v \n", "API KEY NOT FOUND")
+		return domain.Credential{}, usecase_error.ErrNotAuthentication
+	}
+	key := apiKeys[0]
+	if key.Revoked {
+		fmt.Printf("[AUTHENTICATION] : VALIDATE API KEY %#v \n", "API KEY REVOKED")
+		return domain.Credential{}, usecase_error.ErrNotAuthentication
+	}
+	merchant, err := a.merchantRepo.GetByID(ctx, key.MerchantID)
+	if err != nil || merchant.Suspension.IsActive(time.Now()) {
+		fmt.Printf("[AUTHENTICATION] : VALIDATE API KEY %#v \n", "MERCHANT NOT ACTIVE")
+		return domain.Credential{}, usecase_error.ErrNotAuthentication
+	}
+
+	if err := a.apiKeyRepo.UpdateLastUsed(ctx, key.ID, time.Now().Truncate(time.Millisecond)); err != nil {
+		fmt.Printf("[AUTHENTICATION] : VALIDATE API KEY UPDATE LAST USED %#v \n", err)
+	}
+
+	credential := domain.NewCredential(key.ID, "", key.MerchantID, "", domain.LOGIN_AS_API_KEY, "")
+	credential.Scopes = key.Scopes
+	return credential, nil
+}
+
+// ValidateLoginOrAPIKey is used by endpoint that can be called by merchant integration,
+// api key is checked only when login token is empty
+func (a *authenticationUseCase) ValidateLoginOrAPIKey(token, apiKey string) (domain.Credential, error) {
+	if token == "" && apiKey != "" {
+		return a.ValidateAPIKey(apiKey)
+	}
+
+	return a.ValidateLogin(token)
+}
+
 func (a *authenticationUseCase) validateCustomerActive(ctx context.Context, credential *domain.Credential) error {
 	ctx, cancel := context.WithTimeout(ctx, a.contextTimeout)
 	defer cancel()
@@ -87,12 +195,28 @@ func (a *authenticationUseCase) validateCustomerActive(ctx context.Context, cred
 	if err != nil {
 		return err
 	}
+	//impersonation is read only, so support can still look into suspended account
+	now := time.Now()
+	if customer.Suspension.IsActive(now) && !credential.IsImpersonation() {
+		return usecase_error.ErrNotAuthentication
+	}
 
 	credential.UserID = customer.ID
 	credential.MerchantID = customer.MerchantID
+	//owner of suspended merchant can still act as customer
+	if customer.MerchantID != "" {
+		merchant, err := a.merchantRepo.GetByID(ctx, customer.MerchantID)
+		if err != nil && err != usecase_error.ErrNotFound {
+			return err
+		}
+		if err != nil || merchant.Suspension.IsActive(now) {
+			credential.MerchantID = ""
+		}
+	}
 	credential.CartID = customer.CartID
 	credential.Email = customer.Email
 	credential.LoginType = domain.LOGIN_AS_CUSTOMER
+	credential.Role = ""
 
 	return nil
 }
@@ -110,17 +234,33 @@ func (a *authenticationUseCase) validateAdminActive(ctx context.Context, credent
 	credential.CartID = ""
 	credential.Email = admin.Email
 	credential.LoginType = domain.LOGIN_AS_ADMIN
+	credential.Role = admin.Role
 
 	return nil
 }
 
-func (a *authenticationUseCase) LoginCustomer(ctx context.Context, input adapter.LoginInput) (domain.Credential, error) {
+// validateImpersonator reject impersonation when the admin is removed or lost the permission
+func (a *authenticationUseCase) validateImpersonator(ctx context.Context, adminID string) error {
+	ctx, cancel := context.WithTimeout(ctx, a.contextTimeout)
+	defer cancel()
+	admin, err := a.adminRepo.GetByID(ctx, adminID)
+	if err != nil {
+		return usecase_error.ErrNotAuthentication
+	}
+	if !domain.HasPermission(admin.Role, domain.PERMISSION_IMPERSONATE_CUSTOMER) {
+		return usecase_error.ErrNotAuthentication
+	}
+
+	return nil
+}
+
+func (a *authenticationUseCase) LoginCustomer(ctx context.Context, input adapter.LoginInput) (domain.Credential, domain.LoginChallenge, error) {
 	ctx, cancel := context.WithTimeout(ctx, a.contextTimeout)
 	defer cancel()
 
 	if input.Email == "" {
 		fmt.Printf("[AUTHENTICATION] : LOGIN CUSTOMER %#v \n", "EMAIL EMPTY")
-		return domain.Credential{}, usecase_error.ErrLoginField{
+		return domain.Credential{}, domain.LoginChallenge{}, usecase_error.ErrLoginField{
 			Field:   "Email",
 			Message: "Email is not registered",
 		}
@@ -134,28 +274,41 @@ func (a *authenticationUseCase) LoginCustomer(ctx context.Context, input adapter
 	customers, err := a.customerRepo.Fetch(ctx, noCursor, numReturned, search)
 	if err != nil {
 		fmt.Printf("[AUTHENTICATION] : LOGIN CUSTOMER %#v \n", err)
-		return domain.Credential{}, usecase_error.ErrInternalServerError
+		return domain.Credential{}, domain.LoginChallenge{}, usecase_error.ErrInternalServerError
 	}
 	if len(customers) != 1 {
 		fmt.Printf("[AUTHENTICATION] : LOGIN CUSTOMER %#v \n", "EMAIL NOT REGISTERED")
-		return domain.Credential{}, usecase_error.ErrLoginField{
+		return domain.Credential{}, domain.LoginChallenge{}, usecase_error.ErrLoginField{
 			Field:   "Email",
 			Message: "Email is not registered",
 		}
 	}
 
 	customer := customers[0]
-	isMatch := helper.NewEncription().Compare(
+	encription := helper.NewEncription()
+	isMatch := encription.Compare(
 		[]byte(customer.Password),
 		[]byte(input.Password),
 	)
 	if !isMatch {
 		fmt.Printf("[AUTHENTICATION] : LOGIN CUSTOMER %#v \n", "PASSWORD NOT MATCH")
-		return domain.Credential{}, usecase_error.ErrLoginField{
+		return domain.Credential{}, domain.LoginChallenge{}, usecase_error.ErrLoginField{
 			Field:   "Password",
 			Message: "Password is wrong",
 		}
 	}
+	if err := verifyNotSuspended(customer.Suspension); err != nil {
+		fmt.Printf("[AUTHENTICATION] : LOGIN CUSTOMER %#v \n", "ACCOUNT SUSPENDED")
+		return domain.Credential{}, domain.LoginChallenge{}, err
+	}
+	if encription.NeedsRehash([]byte(customer.Password)) {
+		customer = a.rehashCustomerPassword(ctx, encription, customer, input.Password)
+	}
+
+	if customer.TwoFactor.Enabled {
+		challenge := domain.NewLoginChallenge(customer.ID, domain.LOGIN_AS_CUSTOMER, true)
+		return domain.Credential{}, challenge, nil
+	}
 
 	credential := domain.NewCredential(
 		customer.ID,
@@ -163,12 +316,24 @@ func (a *authenticationUseCase) LoginCustomer(ctx context.Context, input adapter
 		customer.MerchantID,
 		customer.Email,
 		domain.LOGIN_AS_CUSTOMER,
+		"",
 	)
+	if err := a.startSession(ctx, &credential); err != nil {
+		return domain.Credential{}, domain.LoginChallenge{}, err
+	}
+	a.mergeGuestCart(ctx, input.GuestCartToken, credential)
 
-	return credential, nil
+	return credential, domain.LoginChallenge{}, nil
 }
 
-func (a *authenticationUseCase) LoginAdmin(ctx context.Context, input adapter.LoginInput) (domain.Credential, error) {
+// mergeGuestCart failure is only logged, guest items must not block login
+func (a *authenticationUseCase) mergeGuestCart(ctx context.Context, guestCartToken string, credential domain.Credential) {
+	if err := mergeGuestCart(ctx, a.guestCartRepo, a.cartRepo, a.productRepo, guestCartToken, credential); err != nil {
+		fmt.Printf("[AUTHENTICATION] : MERGE GUEST CART %#v \n", err)
+	}
+}
+
+func (a *authenticationUseCase) LoginAdmin(ctx context.Context, input adapter.LoginInput) (domain.LoginChallenge, domain.TwoFactorEnrollment, error) {
 
 	ctx, cancel := context.WithTimeout(ctx, a.contextTimeout)
 	defer cancel()
@@ -176,7 +341,7 @@ func (a *authenticationUseCase) LoginAdmin(ctx context.Context, input adapter.Lo
 	if input.Email == "" {
 		fmt.Printf("[AUTHENTICATION] : LOGIN ADMIN %#v \n", "EMAIL EMPTY")
 
-		return domain.Credential{}, usecase_error.ErrLoginField{
+		return domain.LoginChallenge{}, domain.TwoFactorEnrollment{}, usecase_error.ErrLoginField{
 			Field:   "Email",
 			Message: "Email is not registered",
 		}
@@ -190,37 +355,184 @@ func (a *authenticationUseCase) LoginAdmin(ctx context.Context, input adapter.Lo
 	admins, err := a.adminRepo.Fetch(ctx, noCursor, numReturned, search)
 	if err != nil {
 		fmt.Printf("[AUTHENTICATION] : LOGIN ADMIN FETCH %#v \n", err)
-		return domain.Credential{}, usecase_error.ErrInternalServerError
+		return domain.LoginChallenge{}, domain.TwoFactorEnrollment{}, usecase_error.ErrInternalServerError
 	}
 	if len(admins) != 1 {
 		fmt.Printf("[AUTHENTICATION] : LOGIN ADMIN %#v \n", "EMAIL NOT REGISTERED")
-		return domain.Credential{}, usecase_error.ErrLoginField{
+		return domain.LoginChallenge{}, domain.TwoFactorEnrollment{}, usecase_error.ErrLoginField{
 			Field:   "Email",
 			Message: "Email is not registered",
 		}
 	}
 
-	customer := admins[0]
-	isMatch := helper.NewEncription().Compare(
-		[]byte(customer.Password),
+	admin := admins[0]
+	encription := helper.NewEncription()
+	isMatch := encription.Compare(
+		[]byte(admin.Password),
 		[]byte(input.Password),
 	)
 	if !isMatch {
 		fmt.Printf("[AUTHENTICATION] : LOGIN ADMIN %#v \n", "PASSWORD NOT MATCH")
-		return domain.Credential{}, usecase_error.ErrLoginField{
+		return domain.LoginChallenge{}, domain.TwoFactorEnrollment{}, usecase_error.ErrLoginField{
 			Field:   "Password",
 			Message: "Password is wrong",
 		}
 	}
+	if encription.NeedsRehash([]byte(admin.Password)) {
+		admin = a.rehashAdminPassword(ctx, encription, admin, input.Password)
+	}
 
-	credential := domain.NewCredential(
-		customer.ID,
-		"",
-		"",
-		customer.Email,
-		domain.LOGIN_AS_ADMIN,
-	)
-	return credential, nil
+	//two factor is mandatory for admin, admin who has not enrolled finish enrollment within the challenge
+	var enrollment domain.TwoFactorEnrollment
+	if !admin.TwoFactor.Enabled {
+		enrollment, err = startTwoFactorEnrollment(&admin.TwoFactor, admin.Email)
+		if err != nil {
+			return domain.LoginChallenge{}, enrollment, err
+		}
+		if _, err := a.adminRepo.UpdateOne(ctx, admin); err != nil {
+			fmt.Printf("[AUTHENTICATION] : LOGIN ADMIN ENROLLMENT %#v \n", err)
+			return domain.LoginChallenge{}, domain.TwoFactorEnrollment{}, err
+		}
+	}
+
+	challenge := domain.NewLoginChallenge(admin.ID, domain.LOGIN_AS_ADMIN, admin.TwoFactor.Enabled)
+	return challenge, enrollment, nil
+}
+
+// rehash is done when plain password is known at login, failure is only logged so login is not blocked
+func (a *authenticationUseCase) rehashCustomerPassword(ctx context.Context, encription helper.Encription, customer domain.Customer, password string) domain.Customer {
+	hashedPassword, err := encription.Encrypt([]byte(password))
+	if err != nil {
+		return customer
+	}
+
+	customer.Password = hashedPassword
+	updatedCustomer, err := a.customerRepo.UpdateOne(ctx, customer)
+	if err != nil {
+		fmt.Printf("[AUTHENTICATION] : REHASH CUSTOMER PASSWORD %#v \n", err)
+		return customer
+	}
+	return updatedCustomer
+}
+
+func (a *authenticationUseCase) rehashAdminPassword(ctx context.Context, encription helper.Encription, admin domain.Admin, password string) domain.Admin {
+	hashedPassword, err := encription.Encrypt([]byte(password))
+	if err != nil {
+		return admin
+	}
+
+	admin.Password = hashedPassword
+	updatedAdmin, err := a.adminRepo.UpdateOne(ctx, admin)
+	if err != nil {
+		fmt.Printf("[AUTHENTICATION] : REHASH ADMIN PASSWORD %#v \n", err)
+		return admin
+	}
+	return updatedAdmin
+}
+
+// LegacyPasswordHashCount return number of password not hashed with configured algorithm, per login type
+func (a *authenticationUseCase) LegacyPasswordHashCount(ctx context.Context) (map[string]int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.contextTimeout)
+	defer cancel()
+
+	hashPrefix := helper.NewEncription().HashPrefix()
+	customerCount, err := a.customerRepo.CountLegacyPassword(ctx, hashPrefix)
+	if err != nil {
+		return nil, err
+	}
+	adminCount, err := a.adminRepo.CountLegacyPassword(ctx, hashPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]int64{
+		domain.LOGIN_AS_CUSTOMER: customerCount,
+		domain.LOGIN_AS_ADMIN:    adminCount,
+	}, nil
+}
+
+func (a *authenticationUseCase) VerifyLoginChallenge(ctx context.Context, input adapter.LoginChallengeInput) (domain.Credential, []string, error) {
+	challenge, err := helper.DecodeChallengeToken(input.ChallengeToken)
+	if err != nil {
+		fmt.Printf("[AUTHENTICATION] : VERIFY LOGIN CHALLENGE %#v \n", err)
+		return domain.Credential{}, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, a.contextTimeout)
+	defer cancel()
+
+	switch challenge.LoginType {
+	case domain.LOGIN_AS_ADMIN:
+		admin, err := a.adminRepo.GetByID(ctx, challenge.UserID)
+		if err != nil {
+			return domain.Credential{}, nil, usecase_error.ErrNotAuthentication
+		}
+		recoveryCodes, err := a.verifyChallengeCode(ctx, domain.LOGIN_AS_ADMIN, admin.ID, &admin.TwoFactor, input)
+		if err != nil {
+			return domain.Credential{}, nil, err
+		}
+		if _, err := a.adminRepo.UpdateOne(ctx, admin); err != nil {
+			return domain.Credential{}, nil, err
+		}
+
+		credential := domain.NewCredential(
+			admin.ID,
+			"",
+			"",
+			admin.Email,
+			domain.LOGIN_AS_ADMIN,
+			admin.Role,
+		)
+		if err := a.startSession(ctx, &credential); err != nil {
+			return domain.Credential{}, nil, err
+		}
+		return credential, recoveryCodes, nil
+	case domain.LOGIN_AS_CUSTOMER:
+		customer, err := a.customerRepo.GetByID(ctx, challenge.UserID)
+		if err != nil {
+			return domain.Credential{}, nil, usecase_error.ErrNotAuthentication
+		}
+		if err := verifyNotSuspended(customer.Suspension); err != nil {
+			return domain.Credential{}, nil, err
+		}
+		recoveryCodes, err := a.verifyChallengeCode(ctx, domain.LOGIN_AS_CUSTOMER, customer.ID, &customer.TwoFactor, input)
+		if err != nil {
+			return domain.Credential{}, nil, err
+		}
+		if _, err := a.customerRepo.UpdateOne(ctx, customer); err != nil {
+			return domain.Credential{}, nil, err
+		}
+
+		credential := domain.NewCredential(
+			customer.ID,
+			customer.CartID,
+			customer.MerchantID,
+			customer.Email,
+			domain.LOGIN_AS_CUSTOMER,
+			"",
+		)
+		if err := a.startSession(ctx, &credential); err != nil {
+			return domain.Credential{}, nil, err
+		}
+		a.mergeGuestCart(ctx, input.GuestCartToken, credential)
+		return credential, recoveryCodes, nil
+	}
+
+	return domain.Credential{}, nil, usecase_error.ErrNotAuthentication
+}
+
+func (a *authenticationUseCase) verifyChallengeCode(ctx context.Context, loginType, userID string, twoFactor *domain.TwoFactor, input adapter.LoginChallengeInput) ([]string, error) {
+	var recoveryCodes []string
+	err := a.twoFactorLimit.check(ctx, loginType, userID, func() error {
+		if !twoFactor.Enabled {
+			var err error
+			recoveryCodes, err = confirmTwoFactor(twoFactor, input.Code)
+			return err
+		}
+		return verifyTwoFactor(twoFactor, input.Code, input.RecoveryCode)
+	})
+
+	return recoveryCodes, err
 }
 
 func (a *authenticationUseCase) VerifiedAsCustomer(credential domain.Credential) error {
@@ -239,6 +551,36 @@ func (a *authenticationUseCase) VerifiedAsAdmin(credential domain.Credential) er
 	return nil
 }
 
+func (a *authenticationUseCase) VerifiedAdminPermission(credential domain.Credential, permission string) error {
+	if err := a.VerifiedAsAdmin(credential); err != nil {
+		return err
+	}
+	//token issued before admin roles exist has no role, admin has to login again after migration assign the role
+	if credential.Role == "" {
+		fmt.Printf("[AUTHENTICATION] :VALIDATE ADMIN PERMISSION %#v \n", "ADMIN HAS NO ROLE")
+		return usecase_error.ErrNotAuthorization
+	}
+	if !domain.HasPermission(credential.Role, permission) {
+		fmt.Printf("[AUTHENTICATION] :VALIDATE ADMIN PERMISSION %#v \n", "ROLE HAS NO PERMISSION "+permission)
+		return usecase_error.ErrNotAuthorization
+	}
+	return nil
+}
+
+// VerifiedAPIKeyScope only restrict api key credential, login credential is checked by other verification
+func (a *authenticationUseCase) VerifiedAPIKeyScope(credential domain.Credential, scope string) error {
+	if credential.LoginType != domain.LOGIN_AS_API_KEY {
+		return nil
+	}
+	for _, credentialScope := range credential.Scopes {
+		if credentialScope == scope {
+			return nil
+		}
+	}
+	fmt.Printf("[AUTHENTICATION] :VALIDATE API KEY SCOPE %#v \n", "API KEY HAS NO SCOPE "+scope)
+	return usecase_error.ErrNotAuthorization
+}
+
 func (a *authenticationUseCase) VerifiedCustomerAuthor(credential domain.Credential, customerID string) error {
 	if credential.UserID != customerID {
 		fmt.Printf("[AUTHENTICATION] :VALIDATE CUSTOMER AUTHOR %#v \n", "CREDENTIAL NOT VALID")
@@ -0,0 +1,380 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	guuid "github.com/google/uuid"
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/helper"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+// BundleUsecase save bundle as product with bundle items, so bundle is searched, carted and reviewed like other products.
+// Bundle is read, deleted and has its photos, status and discounts changed through product usecase
+type BundleUsecase interface {
+	Create(ctx context.Context, input adapter.BundleCreateInput) (domain.Product, error)
+	UpdateOne(ctx context.Context, input adapter.BundleUpdateInput, bundleID string) (domain.Product, error)
+}
+
+type bundleUsecase struct {
+	productRepo    repository.ProductRepository
+	merchantRepo   repository.MerchantRepository
+	auditLogger    auditLogger
+	notifier       wishlistNotifier
+	contextTimeout time.Duration
+}
+
+func NewBundleUsecase(
+	productRepo repository.ProductRepository,
+	merchantRepo repository.MerchantRepository,
+	auditLogRepo repository.AuditLogRepository,
+	wishlistRepo repository.WishlistRepository,
+	notificationRepo repository.NotificationRepository,
+	contextTimeout time.Duration,
+) BundleUsecase {
+	return &bundleUsecase{
+		productRepo:    productRepo,
+		merchantRepo:   merchantRepo,
+		auditLogger:    newAuditLogger(auditLogRepo),
+		notifier:       newWishlistNotifier(wishlistRepo, notificationRepo),
+		contextTimeout: contextTimeout,
+	}
+}
+
+func (b *bundleUsecase) validate(value interface{}) error {
+	if entityErr := helper.NewValidationEntity().Validate(value); entityErr != nil {
+		return entityErr
+	}
+
+	return nil
+}
+
+func bundleError(field string, message string) error {
+	return usecase_error.ErrBadEntityInput{
+		usecase_error.ErrEntityField{
+			Field:   field,
+			Message: message,
+		},
+	}
+}
+
+// newBundleItems fetch products of bundle items, they must be active products of the merchant and could not be bundles
+func (b *bundleUsecase) newBundleItems(ctx context.Context, merchantID string, inputs []adapter.BundleItemInput) ([]domain.BundleItem, map[string]domain.Product, error) {
+	if len(inputs) < 2 {
+		return nil, nil, bundleError("Items", "Bundle must contain at least 2 products")
+	}
+
+	ids := []string{}
+	for i, input := range inputs {
+		for _, other := range inputs[:i] {
+			if other.ProductID == input.ProductID && other.SKU == input.SKU {
+				return nil, nil, bundleError("Items", "Product is already in bundle : "+input.ProductID)
+			}
+		}
+		ids = append(ids, input.ProductID)
+	}
+	notDeleted := false
+	products, err := b.productRepo.Fetch(ctx, "", 0, domain.ProductSearchOptions{
+		IDs:        ids,
+		MerchantID: merchantID,
+		Deleted:    &notDeleted,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	productByID := map[string]domain.Product{}
+	for _, product := range products {
+		productByID[product.ID] = product
+	}
+
+	items := []domain.BundleItem{}
+	for _, input := range inputs {
+		product, ok := productByID[input.ProductID]
+		snapshot := product.DenormalizationData()
+		if !ok || !product.IsActive() || snapshot.IsBundle() {
+			return nil, nil, bundleError("Items", "Product is not available : "+input.ProductID)
+		}
+		if _, ok := snapshot.Variant(input.SKU); (len(product.Variants) != 0 || input.SKU != "") && !ok {
+			return nil, nil, bundleError("Items", "Variant is not found : "+product.Name)
+		}
+
+		item := domain.BundleItem{
+			ProductID: product.ID,
+			SKU:       input.SKU,
+			Name:      product.Name,
+			Price:     snapshot.OriginalPriceOf(input.SKU),
+			Quantity:  input.Quantity,
+		}
+		if len(product.Photos) != 0 {
+			item.Photo = product.Photos[0]
+		}
+		items = append(items, item)
+	}
+	return items, productByID, nil
+}
+
+// applyBundleItems set stock and dimension of bundle from its products, bundle must be cheaper than buying them one by one
+func applyBundleItems(product *domain.Product, items []domain.BundleItem, productByID map[string]domain.Product) error {
+	product.BundleItems = items
+	product.Variants = []domain.Variant{}
+	product.Stock = domain.BundleStock(items, productByID)
+	product.Weight, product.Width, product.Height, product.Long = 0, 0, 0, 0
+
+	total := float64(0)
+	for _, item := range items {
+		component := productByID[item.ProductID]
+		snapshot := component.DenormalizationData()
+		total += item.Price * float64(item.Quantity)
+		product.Weight += snapshot.WeightOf(item.SKU) * float64(item.Quantity)
+		if snapshot.Width > product.Width {
+			product.Width = snapshot.Width
+		}
+		if snapshot.Height > product.Height {
+			product.Height = snapshot.Height
+		}
+		if snapshot.Long > product.Long {
+			product.Long = snapshot.Long
+		}
+	}
+	if product.Price >= total {
+		return bundleError("Price", "Bundle price must be less than total price of its products")
+	}
+	return nil
+}
+
+func (b *bundleUsecase) Create(ctx context.Context, input adapter.BundleCreateInput) (domain.Product, error) {
+	var product domain.Product
+
+	credential, ok := ctx.Value("credential").(domain.Credential)
+	if !ok {
+		return product, usecase_error.ErrNotAuthentication
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, b.contextTimeout)
+	defer cancel()
+
+	merchant, err := b.merchantRepo.GetByID(ctx, credential.MerchantID)
+	if err != nil {
+		return product, err
+	}
+	if err := verifyUniqueSKU(ctx, b.productRepo, merchant.ID, "", input.SKU); err != nil {
+		return product, err
+	}
+
+	status := input.Status
+	if status == "" {
+		status = domain.PRODUCT_STATUS_ACTIVE
+	}
+	if status != domain.PRODUCT_STATUS_DRAFT && status != domain.PRODUCT_STATUS_ACTIVE {
+		return product, bundleError("Status", "Status must be DRAFT or ACTIVE")
+	}
+
+	items, productByID, err := b.newBundleItems(ctx, merchant.ID, input.Items)
+	if err != nil {
+		return product, err
+	}
+
+	product.ID = guuid.New().String()
+	product.SKU = input.SKU
+	product.Status = status
+	product.Merchant = merchant.DenomarlizationData()
+	product.Name = input.Name
+	product.Description = input.Description
+	product.Category = input.Category
+	product.Etalase = input.Etalase
+	product.Tags = input.Tags
+	product.Colors = []string{}
+	product.Sizes = []string{}
+	product.Photos = []string{DEFAULT_PRODUCT_PHOTO}
+	product.Price = input.Price
+	product.Attributes = newAttributes(input.Attributes)
+	product.Discounts = []domain.Discount{}
+	if err := applyBundleItems(&product, items, productByID); err != nil {
+		return product, err
+	}
+	product.SyncDiscount(time.Now())
+	product.CreatedAt = time.Now().Truncate(time.Millisecond)
+	product.UpdatedAt = time.Now().Truncate(time.Millisecond)
+
+	if entityErr := b.validate(product); entityErr != nil {
+		return product, entityErr
+	}
+	if entityErr := helper.ValidateProductAttributes(product.Category, product.Attributes); entityErr != nil {
+		return product, entityErr
+	}
+
+	return b.productRepo.Create(ctx, product)
+}
+
+func (b *bundleUsecase) UpdateOne(ctx context.Context, input adapter.BundleUpdateInput, bundleID string) (domain.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, b.contextTimeout)
+	defer cancel()
+
+	product, err := b.productRepo.GetByID(ctx, bundleID)
+	if err != nil {
+		return product, err
+	}
+	if product.DeletedAt != nil || !product.DenormalizationData().IsBundle() {
+		return domain.Product{}, usecase_error.ErrNotFound
+	}
+	previous := product
+	before := helper.AuditSnapshot(product)
+	merchant, err := b.merchantRepo.GetByID(ctx, product.Merchant.ID)
+	if err != nil {
+		return product, err
+	}
+	if err := verifyUniqueSKU(ctx, b.productRepo, merchant.ID, product.ID, input.SKU); err != nil {
+		return product, err
+	}
+
+	items, productByID, err := b.newBundleItems(ctx, merchant.ID, input.Items)
+	if err != nil {
+		return product, err
+	}
+
+	product.SKU = input.SKU
+	product.Name = input.Name
+	product.Description = input.Description
+	product.Category = input.Category
+	product.Etalase = input.Etalase
+	product.Tags = input.Tags
+	product.Price = input.Price
+	product.Attributes = newAttributes(input.Attributes)
+	if err := applyBundleItems(&product, items, productByID); err != nil {
+		return product, err
+	}
+	product.SyncDiscount(time.Now())
+	product.UpdatedAt = time.Now().Truncate(time.Millisecond)
+
+	if entityErr := b.validate(product); entityErr != nil {
+		return product, entityErr
+	}
+	if entityErr := helper.ValidateProductAttributes(product.Category, product.Attributes); entityErr != nil {
+		return product, entityErr
+	}
+	if entityErr := verifyDiscounts(product); entityErr != nil {
+		return product, entityErr
+	}
+
+	for i, mProduct := range merchant.Products {
+		if mProduct.ID == product.ID {
+			merchant.Products[i] = product
+		}
+	}
+	if _, err = b.merchantRepo.UpdateOne(ctx, merchant); err != nil {
+		return product, err
+	}
+	product, err = b.productRepo.UpdateOne(ctx, product)
+	if err != nil {
+		return product, err
+	}
+
+	b.auditLogger.record(ctx, domain.AUDIT_ACTION_PRODUCT_UPDATE, domain.AUDIT_TARGET_PRODUCT, product.ID, before, product)
+	b.notifier.notify(ctx, previous, product)
+	return product, nil
+}
+
+// syncBundleStocks set stock of bundles from current stock of their products,
+// stored stock of bundle is only refreshed when bundle is saved
+func syncBundleStocks(ctx context.Context, productRepo repository.ProductRepository, products []domain.Product) error {
+	ids := []string{}
+	for _, product := range products {
+		for _, item := range product.BundleItems {
+			ids = append(ids, item.ProductID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	components, err := productRepo.Fetch(ctx, "", 0, domain.ProductSearchOptions{IDs: ids})
+	if err != nil {
+		return err
+	}
+	productByID := map[string]domain.Product{}
+	for _, component := range components {
+		productByID[component.ID] = component
+	}
+	for i, product := range products {
+		if len(product.BundleItems) != 0 {
+			products[i].Stock = domain.BundleStock(product.BundleItems, productByID)
+		}
+	}
+	return nil
+}
+
+// expandBundle replace ordered bundle with order items of its current products, see splitBundle
+func expandBundle(ctx context.Context, productRepo repository.ProductRepository, merchantID string, bundle domain.OrderItems) ([]domain.OrderItems, error) {
+	ids := []string{}
+	for _, item := range bundle.Product.BundleItems {
+		ids = append(ids, item.ProductID)
+	}
+	products, err := productRepo.Fetch(ctx, "", 0, domain.ProductSearchOptions{IDs: ids, MerchantID: merchantID})
+	if err != nil {
+		return nil, err
+	}
+	productByID := map[string]domain.Product{}
+	for _, product := range products {
+		productByID[product.ID] = product
+	}
+
+	snapshots := []domain.DenormalizationProduct{}
+	for _, item := range bundle.Product.BundleItems {
+		product, ok := productByID[item.ProductID]
+		snapshot := product.DenormalizationData()
+		if _, variantOk := snapshot.Variant(item.SKU); item.SKU != "" && !variantOk {
+			ok = false
+		}
+		if !ok || !product.IsActive() {
+			return nil, bundleError("Product", "Product of bundle is not available : "+bundle.Product.Name)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return splitBundle(bundle, snapshots), nil
+}
+
+// splitBundle allocate bundle price to snapshots of its products, proportional to original price of every product.
+// Unit price is whole number, so product whose share is not divisible by its quantity is split into two items
+// one rupiah apart, keeping total of items equal to bundle price
+func splitBundle(bundle domain.OrderItems, snapshots []domain.DenormalizationProduct) []domain.OrderItems {
+	weights := []int64{}
+	for i, item := range bundle.Product.BundleItems {
+		weights = append(weights, int64(snapshots[i].OriginalPriceOf(item.SKU))*item.Quantity)
+	}
+
+	items := []domain.OrderItems{}
+	shares := splitByWeight(bundle.Price, weights)
+	for i, bundleItem := range bundle.Product.BundleItems {
+		unitPrice := shares[i] / bundleItem.Quantity
+		remainder := shares[i] - unitPrice*bundleItem.Quantity
+		item := domain.OrderItems{
+			Product:    snapshots[i],
+			SKU:        bundleItem.SKU,
+			Quantity:   (bundleItem.Quantity - remainder) * bundle.Quantity,
+			BuyerNote:  bundle.BuyerNote,
+			Colors:     []string{},
+			Sizes:      []string{},
+			Price:      unitPrice,
+			BundleID:   bundle.Product.ID,
+			BundleName: bundle.Product.Name,
+		}
+		if variant, ok := snapshots[i].Variant(bundleItem.SKU); ok {
+			if variant.Color != "" {
+				item.Colors = []string{variant.Color}
+			}
+			if variant.Size != "" {
+				item.Sizes = []string{variant.Size}
+			}
+		}
+		items = append(items, item)
+		if remainder > 0 {
+			item.Quantity = remainder * bundle.Quantity
+			item.Price = unitPrice + 1
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,145 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/market-place/domain"
+)
+
+func TestSplitByWeight(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  int64
+		weights []int64
+		want    []int64
+	}{
+		{"proportional", 100, []int64{1, 1, 2}, []int64{25, 25, 50}},
+		{"remainder goes to last weighted", 10, []int64{1, 1, 1}, []int64{3, 3, 4}},
+		{"trailing zero weight", 10, []int64{1, 2, 0}, []int64{3, 7, 0}},
+		{"zero weights", 100, []int64{0, 0}, []int64{0, 0}},
+		{"no weight", 100, []int64{}, []int64{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := splitByWeight(test.amount, test.weights)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("splitByWeight(%d, %v) = %v, want %v", test.amount, test.weights, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSplitBundle(t *testing.T) {
+	type line struct {
+		productID string
+		sku       string
+		quantity  int64
+		price     int64
+	}
+	productA := domain.DenormalizationProduct{ID: "A", Price: 10}
+	productB := domain.DenormalizationProduct{ID: "B", Price: 10}
+	productC := domain.DenormalizationProduct{
+		ID:    "C",
+		Price: 10,
+		Variants: []domain.Variant{
+			{SKU: "C-RED", Color: "red", Price: 30},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		price     int64
+		quantity  int64
+		items     []domain.BundleItem
+		snapshots []domain.DenormalizationProduct
+		want      []line
+	}{
+		{
+			name:     "divisible shares",
+			price:    300,
+			quantity: 1,
+			items: []domain.BundleItem{
+				{ProductID: "A", Quantity: 1},
+				{ProductID: "B", Quantity: 2},
+			},
+			snapshots: []domain.DenormalizationProduct{productA, productB},
+			want: []line{
+				{"A", "", 1, 100},
+				{"B", "", 2, 100},
+			},
+		},
+		{
+			name:     "remainder split into unit price plus one",
+			price:    50,
+			quantity: 1,
+			items: []domain.BundleItem{
+				{ProductID: "A", Quantity: 3},
+				{ProductID: "B", Quantity: 1},
+			},
+			snapshots: []domain.DenormalizationProduct{productA, productB},
+			want: []line{
+				{"A", "", 2, 12},
+				{"A", "", 1, 13},
+				{"B", "", 1, 13},
+			},
+		},
+		{
+			name:     "remainder lines follow ordered bundle quantity",
+			price:    50,
+			quantity: 2,
+			items: []domain.BundleItem{
+				{ProductID: "A", Quantity: 3},
+				{ProductID: "B", Quantity: 1},
+			},
+			snapshots: []domain.DenormalizationProduct{productA, productB},
+			want: []line{
+				{"A", "", 4, 12},
+				{"A", "", 2, 13},
+				{"B", "", 2, 13},
+			},
+		},
+		{
+			name:     "variant weighted by its price",
+			price:    35,
+			quantity: 1,
+			items: []domain.BundleItem{
+				{ProductID: "A", Quantity: 1},
+				{ProductID: "C", SKU: "C-RED", Quantity: 1},
+			},
+			snapshots: []domain.DenormalizationProduct{productA, productC},
+			want: []line{
+				{"A", "", 1, 8},
+				{"C", "C-RED", 1, 27},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bundle := domain.OrderItems{
+				Product:  domain.DenormalizationProduct{ID: "BUNDLE", Name: "Bundle", BundleItems: test.items},
+				Quantity: test.quantity,
+				Price:    test.price,
+			}
+			items := splitBundle(bundle, test.snapshots)
+
+			got := []line{}
+			total := int64(0)
+			for _, item := range items {
+				got = append(got, line{item.Product.ID, item.SKU, item.Quantity, item.Price})
+				total += item.Price * item.Quantity
+				if item.BundleID != "BUNDLE" || item.BundleName != "Bundle" {
+					t.Errorf("item %s is not marked as part of bundle", item.Product.ID)
+				}
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("splitBundle() = %v, want %v", got, test.want)
+			}
+			if total != test.price*test.quantity {
+				t.Errorf("total of items = %d, want bundle price %d", total, test.price*test.quantity)
+			}
+		})
+	}
+}
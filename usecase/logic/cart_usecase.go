@@ -3,6 +3,9 @@ package logic
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/market-place/domain"
@@ -15,25 +18,42 @@ import (
 type CartUsecase interface {
 	GetByID(ctx context.Context, cartID string) (domain.Cart, error)
 	AddProduct(ctx context.Context, input adapter.CartAddItemInput, cartID string) (domain.Cart, error)
-	UpdateItemInCart(ctx context.Context, itemData adapter.CartUpdateItemInput, productID string, cartID string) (domain.Cart, error)
-	RemoveProduct(ctx context.Context, productID string, cartID string) (domain.Cart, error)
+	UpdateItemInCart(ctx context.Context, itemData adapter.CartUpdateItemInput, productID string, sku string, cartID string) (domain.Cart, error)
+	RemoveProduct(ctx context.Context, productID string, sku string, cartID string) (domain.Cart, error)
 	ClearProduct(ctx context.Context, cartID string) (domain.Cart, error)
+	// AcknowledgeChanges clear changed flag of items so cart can be checked out
+	AcknowledgeChanges(ctx context.Context, cartID string) (domain.Cart, error)
+	// Summary group items by merchant with shipping quote to customer address, empty address id use first address
+	Summary(ctx context.Context, cartID, addressID string) (domain.CartSummary, error)
+	// SaveForLater move item from cart to customer wishlist
+	SaveForLater(ctx context.Context, productID string, sku string, cartID string) (domain.WishlistItem, error)
+	// MoveToCart move wishlisted product into cart, stock is checked like adding product
+	MoveToCart(ctx context.Context, productID string, cartID string) (domain.Cart, error)
 }
 
 type cartUsecase struct {
 	cartRepo       repository.CartRepository
 	productRepo    repository.ProductRepository
+	customerRepo   repository.CustomerRepository
+	wishlistRepo   repository.WishlistRepository
+	ongkirUsecase  OngkirUsecase
 	contextTimeout time.Duration
 }
 
 func NewCartUsecase(
 	cartRepo repository.CartRepository,
 	productRepo repository.ProductRepository,
+	customerRepo repository.CustomerRepository,
+	wishlistRepo repository.WishlistRepository,
+	ongkirUsecase OngkirUsecase,
 	contextTimeout time.Duration,
 ) CartUsecase {
 	return &cartUsecase{
 		cartRepo:       cartRepo,
 		productRepo:    productRepo,
+		customerRepo:   customerRepo,
+		wishlistRepo:   wishlistRepo,
+		ongkirUsecase:  ongkirUsecase,
 		contextTimeout: contextTimeout,
 	}
 }
@@ -49,17 +69,224 @@ func (c *cartUsecase) validate(value interface{}) error {
 func (c *cartUsecase) GetByID(ctx context.Context, cartID string) (domain.Cart, error) {
 	ctx, cancel := context.WithTimeout(ctx, c.contextTimeout)
 	defer cancel()
-	return c.cartRepo.GetByID(ctx, cartID)
+	cart, err := c.cartRepo.GetByID(ctx, cartID)
+	if err != nil {
+		return cart, err
+	}
+
+	cart, changed, err := reconcileCart(ctx, c.productRepo, cart)
+	if err != nil {
+		return cart, err
+	}
+	if changed {
+		return c.cartRepo.UpdateOne(ctx, cart)
+	}
+	return cart, nil
 }
 
-func (c *cartUsecase) AddProduct(ctx context.Context, input adapter.CartAddItemInput, cartID string) (domain.Cart, error) {
+func (c *cartUsecase) AcknowledgeChanges(ctx context.Context, cartID string) (domain.Cart, error) {
 	ctx, cancel := context.WithTimeout(ctx, c.contextTimeout)
 	defer cancel()
 	cart, err := c.cartRepo.GetByID(ctx, cartID)
 	if err != nil {
 		return cart, err
 	}
-	product, err := c.productRepo.GetByID(ctx, input.ProductID)
+
+	//buyer acknowledge current product, not the snapshot
+	cart, _, err = reconcileCart(ctx, c.productRepo, cart)
+	if err != nil {
+		return cart, err
+	}
+	for i := range cart.Items {
+		cart.Items[i].Updated = false
+		cart.Items[i].Message = ""
+	}
+
+	return c.cartRepo.UpdateOne(ctx, cart)
+}
+
+func (c *cartUsecase) Summary(ctx context.Context, cartID, addressID string) (domain.CartSummary, error) {
+	credential, ok := ctx.Value("credential").(domain.Credential)
+	if !ok {
+		return domain.CartSummary{}, usecase_error.ErrNotAuthorization
+	}
+	customer, err := c.customerRepo.GetByID(ctx, credential.UserID)
+	if err != nil {
+		return domain.CartSummary{}, err
+	}
+	address, found := domain.Address{}, false
+	for _, customerAddress := range customer.Addresses {
+		if addressID == "" || customerAddress.ID == addressID {
+			address, found = customerAddress, true
+			break
+		}
+	}
+	if !found {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "AddressID",
+				Message: "Address is not found",
+			},
+		}
+		return domain.CartSummary{}, err
+	}
+
+	cart, err := c.GetByID(ctx, cartID)
+	if err != nil {
+		return domain.CartSummary{}, err
+	}
+
+	summary := domain.CartSummary{
+		CartID:    cart.ID,
+		Address:   address,
+		Merchants: []domain.CartMerchantGroup{},
+	}
+	indexByMerchant := map[string]int{}
+	for _, item := range cart.Items {
+		item.SyncPrice()
+		index, ok := indexByMerchant[item.Merchant.ID]
+		if !ok {
+			summary.Merchants = append(summary.Merchants, domain.CartMerchantGroup{
+				Merchant:  item.Merchant,
+				Items:     []domain.Item{},
+				Shippings: item.Merchant.Shippings,
+				Ongkirs:   []domain.Ongkir{},
+			})
+			index = len(summary.Merchants) - 1
+			indexByMerchant[item.Merchant.ID] = index
+		}
+
+		group := &summary.Merchants[index]
+		group.Items = append(group.Items, item)
+		group.Subtotal += item.Price * float64(item.Quantity)
+		group.Weight += item.Product.WeightOf(item.SKU) * float64(item.Quantity)
+		if item.Updated {
+			summary.HasChanges = true
+		}
+	}
+
+	//quote failure only leave the group without quote, cart is still shown
+	var wg sync.WaitGroup
+	for i := range summary.Merchants {
+		wg.Add(1)
+		go func(group *domain.CartMerchantGroup) {
+			defer wg.Done()
+			providers := []string{}
+			for _, shipping := range group.Shippings {
+				providers = append(providers, shipping.ID)
+			}
+			if len(providers) == 0 {
+				return
+			}
+
+			ongkirs, err := c.ongkirUsecase.GetOngkir(ctx, group.Merchant.Address.City.CityID, address.City.CityID, group.Weight, providers)
+			if err != nil {
+				fmt.Printf("[CART USECASE] : SUMMARY ONGKIR %#v \n", err)
+				return
+			}
+			group.Ongkirs = ongkirs
+			group.ShippingEstimate = cheapestOngkir(ongkirs)
+		}(&summary.Merchants[i])
+	}
+	wg.Wait()
+
+	for _, group := range summary.Merchants {
+		summary.Subtotal += group.Subtotal
+		summary.ShippingEstimate += group.ShippingEstimate
+	}
+	summary.GrandTotal = summary.Subtotal + summary.ShippingEstimate
+
+	return summary, nil
+}
+
+func cheapestOngkir(ongkirs []domain.Ongkir) float64 {
+	cheapest := int64(-1)
+	for _, ongkir := range ongkirs {
+		for _, service := range ongkir.Services {
+			if cheapest == -1 || service.Cost < cheapest {
+				cheapest = service.Cost
+			}
+		}
+	}
+	if cheapest == -1 {
+		return 0
+	}
+	return float64(cheapest)
+}
+
+// reconcileCart compare every item snapshot with current product and refresh the snapshot.
+// Item is flagged when price changed, stock is not enough, product or variant is deleted or merchant is suspended.
+// Flag is kept until acknowledged, but problem that still exist is flagged again on next read
+func reconcileCart(ctx context.Context, productRepo repository.ProductRepository, cart domain.Cart) (domain.Cart, bool, error) {
+	if len(cart.Items) == 0 {
+		return cart, false, nil
+	}
+
+	ids := []string{}
+	for _, item := range cart.Items {
+		ids = append(ids, item.Product.ID)
+	}
+	products, err := productRepo.Fetch(ctx, "", 0, domain.ProductSearchOptions{IDs: ids})
+	if err != nil {
+		return cart, false, err
+	}
+	if err := syncBundleStocks(ctx, productRepo, products); err != nil {
+		return cart, false, err
+	}
+	productByID := map[string]domain.Product{}
+	for _, product := range products {
+		productByID[product.ID] = product
+	}
+
+	now := time.Now()
+	changed := false
+	for i, item := range cart.Items {
+		messages := []string{}
+		product, ok := productByID[item.Product.ID]
+		current := product.DenormalizationData()
+		if _, variantOk := current.Variant(item.SKU); ok && item.SKU != "" && !variantOk {
+			ok = false
+		}
+		if ok && !product.IsActive() {
+			ok = false
+		}
+		if !ok {
+			messages = append(messages, "Product is no longer available")
+		} else {
+			price, previousPrice := current.PriceOf(item.SKU), item.Product.PriceOf(item.SKU)
+			if price != previousPrice {
+				messages = append(messages, fmt.Sprintf("Price changed from %.0f to %.0f", previousPrice, price))
+			}
+			if product.Merchant.SuspendedUntil.After(now) {
+				messages = append(messages, "Merchant is suspended")
+			}
+			if stock := current.StockOf(item.SKU); stock <= 0 {
+				messages = append(messages, "Product is out of stock")
+			} else if stock < float64(item.Quantity) {
+				messages = append(messages, fmt.Sprintf("Only %.0f left in stock", stock))
+			}
+			item.Product = current
+			item.Merchant = product.Merchant
+		}
+		if len(messages) != 0 {
+			item.Updated = true
+			item.Message = strings.Join(messages, ", ")
+		}
+
+		previous := cart.Items[i]
+		if previous.Updated != item.Updated || previous.Message != item.Message || !reflect.DeepEqual(previous.Product, item.Product) {
+			changed = true
+		}
+		cart.Items[i] = item
+	}
+
+	return cart, changed, nil
+}
+
+func (c *cartUsecase) AddProduct(ctx context.Context, input adapter.CartAddItemInput, cartID string) (domain.Cart, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.contextTimeout)
+	defer cancel()
+	cart, err := c.cartRepo.GetByID(ctx, cartID)
 	if err != nil {
 		return cart, err
 	}
@@ -69,7 +296,159 @@ func (c *cartUsecase) AddProduct(ctx context.Context, input adapter.CartAddItemI
 		return cart, usecase_error.ErrNotAuthorization
 	}
 	userInfo := credential.(domain.Credential)
-	if userInfo.MerchantID == product.Merchant.ID {
+
+	cart, err = addCartItem(ctx, c.productRepo, cart, input, userInfo.MerchantID)
+	if err != nil {
+		return cart, err
+	}
+
+	return c.cartRepo.UpdateOne(ctx, cart)
+}
+
+func (c *cartUsecase) UpdateItemInCart(ctx context.Context, input adapter.CartUpdateItemInput, productID string, sku string, cartID string) (domain.Cart, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.contextTimeout)
+	defer cancel()
+	cart, err := c.cartRepo.GetByID(ctx, cartID)
+	if err != nil {
+		return cart, err
+	}
+
+	cart, err = updateCartItem(ctx, c.productRepo, cart, input, productID, sku)
+	if err != nil {
+		return cart, err
+	}
+
+	return c.cartRepo.UpdateOne(ctx, cart)
+}
+
+func (c *cartUsecase) RemoveProduct(ctx context.Context, productID string, sku string, cartID string) (domain.Cart, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.contextTimeout)
+	defer cancel()
+	cart, err := c.cartRepo.GetByID(ctx, cartID)
+	if err != nil {
+		return cart, err
+	}
+
+	cart, err = removeCartItem(cart, productID, sku)
+	if err != nil {
+		return cart, err
+	}
+
+	return c.cartRepo.UpdateOne(ctx, cart)
+}
+
+func (c *cartUsecase) SaveForLater(ctx context.Context, productID string, sku string, cartID string) (domain.WishlistItem, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.contextTimeout)
+	defer cancel()
+	credential, ok := ctx.Value("credential").(domain.Credential)
+	if !ok {
+		return domain.WishlistItem{}, usecase_error.ErrNotAuthorization
+	}
+	cart, err := c.cartRepo.GetByID(ctx, cartID)
+	if err != nil {
+		return domain.WishlistItem{}, err
+	}
+
+	index := -1
+	for i, item := range cart.Items {
+		if item.Product.ID == productID && item.SKU == sku {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return domain.WishlistItem{}, usecase_error.ErrNotFound
+	}
+	cartItem := cart.Items[index]
+
+	wishlistItem, err := getWishlistItem(ctx, c.wishlistRepo, credential.UserID, productID)
+	if err == usecase_error.ErrNotFound {
+		wishlistItem, err = saveWishlistItem(ctx, c.wishlistRepo, domain.WishlistItem{
+			CustomerID: credential.UserID,
+			Product:    cartItem.Product,
+			SKU:        cartItem.SKU,
+			AddedPrice: cartItem.Product.PriceOf(cartItem.SKU),
+			Quantity:   cartItem.Quantity,
+			Sizes:      cartItem.Sizes,
+			Colors:     cartItem.Colors,
+			Note:       cartItem.Note,
+		})
+	}
+	if err != nil {
+		return wishlistItem, err
+	}
+
+	cart, err = removeCartItem(cart, productID, sku)
+	if err != nil {
+		return wishlistItem, err
+	}
+	if _, err := c.cartRepo.UpdateOne(ctx, cart); err != nil {
+		return wishlistItem, err
+	}
+	return wishlistItem, nil
+}
+
+func (c *cartUsecase) MoveToCart(ctx context.Context, productID string, cartID string) (domain.Cart, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.contextTimeout)
+	defer cancel()
+	credential, ok := ctx.Value("credential").(domain.Credential)
+	if !ok {
+		return domain.Cart{}, usecase_error.ErrNotAuthorization
+	}
+	cart, err := c.cartRepo.GetByID(ctx, cartID)
+	if err != nil {
+		return cart, err
+	}
+	wishlistItem, err := getWishlistItem(ctx, c.wishlistRepo, credential.UserID, productID)
+	if err != nil {
+		return cart, err
+	}
+
+	input := adapter.CartAddItemInput{
+		ProductID: wishlistItem.Product.ID,
+		SKU:       wishlistItem.SKU,
+		Quantity:  wishlistItem.Quantity,
+		Note:      wishlistItem.Note,
+		Colors:    wishlistItem.Colors,
+		Sizes:     wishlistItem.Sizes,
+	}
+	cart, err = addCartItem(ctx, c.productRepo, cart, input, credential.MerchantID)
+	if err != nil {
+		return cart, err
+	}
+	cart, err = c.cartRepo.UpdateOne(ctx, cart)
+	if err != nil {
+		return cart, err
+	}
+
+	if _, err := c.wishlistRepo.DeleteOne(ctx, wishlistItem); err != nil {
+		return cart, err
+	}
+	return cart, nil
+}
+
+// addCartItem is shared by customer and guest cart, guest has no merchant so ownerMerchantID is empty
+func addCartItem(ctx context.Context, productRepo repository.ProductRepository, cart domain.Cart, input adapter.CartAddItemInput, ownerMerchantID string) (domain.Cart, error) {
+	product, err := productRepo.GetByID(ctx, input.ProductID)
+	if err != nil {
+		return cart, err
+	}
+	products := []domain.Product{product}
+	if err := syncBundleStocks(ctx, productRepo, products); err != nil {
+		return cart, err
+	}
+	product = products[0]
+	if !product.IsActive() {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Items",
+				Message: "Product is not available",
+			},
+		}
+		return cart, err
+	}
+
+	if ownerMerchantID != "" && ownerMerchantID == product.Merchant.ID {
 		fmt.Printf("[USECASE-VALIDATION] : CART  %#v \n", "ADD OWN PRODUCT")
 		err := usecase_error.ErrBadEntityInput{
 			usecase_error.ErrEntityField{
@@ -87,10 +466,22 @@ func (c *cartUsecase) AddProduct(ctx context.Context, input adapter.CartAddItemI
 	item.Quantity = input.Quantity
 	item.Colors = input.Colors
 	item.Sizes = input.Sizes
+	if err := applyCartItemVariant(&item, input.SKU); err != nil {
+		return cart, err
+	}
+	if item.Product.IsBundle() && item.Product.Stock < float64(item.Quantity) {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Quantity",
+				Message: "Stock product tidak mencukupi",
+			},
+		}
+		return cart, err
+	}
 
 	indexItemInCart := -1
 	for index, itemInCart := range cart.Items {
-		if item.Product.ID == itemInCart.Product.ID {
+		if item.Product.ID == itemInCart.Product.ID && item.SKU == itemInCart.SKU {
 			indexItemInCart = index
 			break
 		}
@@ -101,28 +492,64 @@ func (c *cartUsecase) AddProduct(ctx context.Context, input adapter.CartAddItemI
 		cart.Items = append(cart.Items, item)
 	}
 
-	if err := c.validate(item); err != nil {
+	validator := helper.NewValidationEntity()
+	if err := validator.Validate(item); err != nil {
 		return cart, err
 	}
-	if err := c.validate(cart); err != nil {
+	if err := validator.Validate(cart); err != nil {
 		return cart, err
 	}
 
-	return c.cartRepo.UpdateOne(ctx, cart)
+	return cart, nil
 }
 
-func (c *cartUsecase) UpdateItemInCart(ctx context.Context, input adapter.CartUpdateItemInput, productID string, cartID string) (domain.Cart, error) {
-	ctx, cancel := context.WithTimeout(ctx, c.contextTimeout)
-	defer cancel()
-	cart, err := c.cartRepo.GetByID(ctx, cartID)
-	if err != nil {
-		return cart, err
+// applyCartItemVariant set chosen variant of product with variants, options of item follow the variant
+func applyCartItemVariant(item *domain.Item, sku string) error {
+	if len(item.Product.Variants) == 0 {
+		if sku != "" {
+			return usecase_error.ErrBadEntityInput{
+				usecase_error.ErrEntityField{
+					Field:   "SKU",
+					Message: "Product has no variant",
+				},
+			}
+		}
+		return nil
+	}
+
+	variant, ok := item.Product.Variant(sku)
+	if !ok {
+		return usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "SKU",
+				Message: "Variant is not found",
+			},
+		}
+	}
+	if variant.Stock < float64(item.Quantity) {
+		return usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Quantity",
+				Message: "Stock product tidak mencukupi",
+			},
+		}
 	}
+	item.SKU = variant.SKU
+	item.Colors, item.Sizes = []string{}, []string{}
+	if variant.Color != "" {
+		item.Colors = []string{variant.Color}
+	}
+	if variant.Size != "" {
+		item.Sizes = []string{variant.Size}
+	}
+	return nil
+}
 
+func updateCartItem(ctx context.Context, productRepo repository.ProductRepository, cart domain.Cart, input adapter.CartUpdateItemInput, productID string, sku string) (domain.Cart, error) {
 	index := 0
 	found := false
 	for i, item := range cart.Items {
-		if item.Product.ID == productID {
+		if item.Product.ID == productID && item.SKU == sku {
 			index = i
 			found = true
 		}
@@ -132,11 +559,17 @@ func (c *cartUsecase) UpdateItemInCart(ctx context.Context, input adapter.CartUp
 	}
 
 	item := cart.Items[index]
-	product, err := c.productRepo.GetByID(ctx, item.Product.ID)
+	product, err := productRepo.GetByID(ctx, item.Product.ID)
 	if err != nil {
 		return cart, err
 	}
-	if int64(product.Stock) < input.Quantity {
+	products := []domain.Product{product}
+	if err := syncBundleStocks(ctx, productRepo, products); err != nil {
+		return cart, err
+	}
+	product = products[0]
+	current := product.DenormalizationData()
+	if int64(current.StockOf(item.SKU)) < input.Quantity {
 		err := usecase_error.ErrBadEntityInput{
 			usecase_error.ErrEntityField{
 				Field:   "Quantity",
@@ -151,30 +584,28 @@ func (c *cartUsecase) UpdateItemInCart(ctx context.Context, input adapter.CartUp
 	item.Colors = input.Colors
 	item.Sizes = input.Sizes
 	item.Note = input.Note
+	//variant is chosen by sku, its options can not be changed
+	if item.SKU != "" {
+		item.Colors, item.Sizes = cart.Items[index].Colors, cart.Items[index].Sizes
+	}
 
 	cart.Items[index] = item
-	if err := c.validate(item); err != nil {
+	validator := helper.NewValidationEntity()
+	if err := validator.Validate(item); err != nil {
 		return cart, err
 	}
-	if err := c.validate(cart); err != nil {
+	if err := validator.Validate(cart); err != nil {
 		return cart, err
 	}
 
-	return c.cartRepo.UpdateOne(ctx, cart)
+	return cart, nil
 }
 
-func (c *cartUsecase) RemoveProduct(ctx context.Context, productID string, cartID string) (domain.Cart, error) {
-	ctx, cancel := context.WithTimeout(ctx, c.contextTimeout)
-	defer cancel()
-	cart, err := c.cartRepo.GetByID(ctx, cartID)
-	if err != nil {
-		return cart, err
-	}
-
+func removeCartItem(cart domain.Cart, productID string, sku string) (domain.Cart, error) {
 	index := 0
 	found := false
 	for i, item := range cart.Items {
-		if item.Product.ID == productID {
+		if item.Product.ID == productID && item.SKU == sku {
 			index = i
 			found = true
 		}
@@ -189,11 +620,79 @@ func (c *cartUsecase) RemoveProduct(ctx context.Context, productID string, cartI
 		cart.Items = append(cart.Items[:index], cart.Items[index+1:]...)
 	}
 
-	if err := c.validate(cart); err != nil {
+	if err := helper.NewValidationEntity().Validate(cart); err != nil {
 		return cart, err
 	}
 
-	return c.cartRepo.UpdateOne(ctx, cart)
+	return cart, nil
+}
+
+// mergeCartItems move guest items into customer cart. Quantity of product that is in both cart is summed,
+// quantity is capped by current stock, and deleted or own product is dropped
+func mergeCartItems(ctx context.Context, productRepo repository.ProductRepository, cart domain.Cart, guestCart domain.Cart, ownerMerchantID string) (domain.Cart, error) {
+	if len(guestCart.Items) == 0 {
+		return cart, nil
+	}
+
+	ids := []string{}
+	for _, item := range guestCart.Items {
+		ids = append(ids, item.Product.ID)
+	}
+	products, err := productRepo.Fetch(ctx, "", 0, domain.ProductSearchOptions{IDs: ids})
+	if err != nil {
+		return cart, err
+	}
+	if err := syncBundleStocks(ctx, productRepo, products); err != nil {
+		return cart, err
+	}
+	productByID := map[string]domain.Product{}
+	for _, product := range products {
+		productByID[product.ID] = product
+	}
+
+	for _, guestItem := range guestCart.Items {
+		product, ok := productByID[guestItem.Product.ID]
+		if !ok || (ownerMerchantID != "" && product.Merchant.ID == ownerMerchantID) {
+			continue
+		}
+		current := product.DenormalizationData()
+		if _, ok := current.Variant(guestItem.SKU); guestItem.SKU != "" && !ok {
+			continue
+		}
+
+		index := -1
+		for i, item := range cart.Items {
+			if item.Product.ID == guestItem.Product.ID && item.SKU == guestItem.SKU {
+				index = i
+				break
+			}
+		}
+		item := guestItem
+		if index != -1 {
+			item = cart.Items[index]
+			item.Quantity += guestItem.Quantity
+		}
+		//quantity already in customer cart is never lowered by merge
+		if stock := int64(current.StockOf(item.SKU)); item.Quantity > stock {
+			item.Quantity = stock
+			if index != -1 && item.Quantity < cart.Items[index].Quantity {
+				item.Quantity = cart.Items[index].Quantity
+			}
+		}
+		item.Product = current
+		item.Merchant = product.Merchant
+
+		if index != -1 {
+			cart.Items[index] = item
+		} else if item.Quantity >= 1 {
+			cart.Items = append(cart.Items, item)
+		}
+	}
+
+	if err := helper.NewValidationEntity().Validate(cart); err != nil {
+		return cart, err
+	}
+	return cart, nil
 }
 
 func (c *cartUsecase) ClearProduct(ctx context.Context, cartID string) (domain.Cart, error) {
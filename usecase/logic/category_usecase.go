@@ -0,0 +1,333 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	guuid "github.com/google/uuid"
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/helper"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type CategoryUsecase interface {
+	Create(ctx context.Context, input adapter.CategoryCreateInput) (domain.CategoryNode, error)
+	FetchTree(ctx context.Context) ([]domain.CategoryNode, error)
+	GetByID(ctx context.Context, categoryID string) (domain.CategoryNode, error)
+	UpdateOne(ctx context.Context, input adapter.CategoryUpdateInput, categoryID string) (domain.CategoryNode, error)
+	DeleteOne(ctx context.Context, categoryID string) (domain.CategoryNode, error)
+}
+
+type categoryUsecase struct {
+	categoryRepo   repository.CategoryRepository
+	productRepo    repository.ProductRepository
+	auditLogger    auditLogger
+	contextTimeout time.Duration
+}
+
+// NewCategoryUsecase also register category repository as loader of product category validator
+func NewCategoryUsecase(
+	categoryRepo repository.CategoryRepository,
+	productRepo repository.ProductRepository,
+	auditLogRepo repository.AuditLogRepository,
+	contextTimeout time.Duration,
+) CategoryUsecase {
+	helper.SetCategoryLoader(func() ([]domain.CategoryNode, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+		defer cancel()
+		return categoryRepo.Fetch(ctx, domain.CategorySearchOptions{})
+	})
+
+	return &categoryUsecase{
+		categoryRepo:   categoryRepo,
+		productRepo:    productRepo,
+		auditLogger:    newAuditLogger(auditLogRepo),
+		contextTimeout: contextTimeout,
+	}
+}
+
+func (c *categoryUsecase) validate(value interface{}) error {
+	if entityErr := helper.NewValidationEntity().Validate(value); entityErr != nil {
+		return entityErr
+	}
+
+	return nil
+}
+
+// verifyCategoryNode slug is unique in whole tree, name is unique between siblings because product category is path of names
+func verifyCategoryNode(nodes []domain.CategoryNode, category domain.CategoryNode) error {
+	for _, attribute := range category.Attributes {
+		if attribute.Type == domain.ATTRIBUTE_TYPE_OPTION && len(attribute.Values) == 0 {
+			return usecase_error.ErrBadEntityInput{
+				usecase_error.ErrEntityField{
+					Field:   "Attributes",
+					Message: "Attribute " + attribute.Name + " must have values",
+				},
+			}
+		}
+	}
+	for _, node := range nodes {
+		if node.ID == category.ID {
+			continue
+		}
+		if node.Slug == category.Slug {
+			return usecase_error.ErrBadEntityInput{
+				usecase_error.ErrEntityField{
+					Field:   "Slug",
+					Message: "Slug is not unique",
+				},
+			}
+		}
+		if node.ParentID == category.ParentID && node.Name == category.Name {
+			return usecase_error.ErrBadEntityInput{
+				usecase_error.ErrEntityField{
+					Field:   "Name",
+					Message: "Name is already used by other category in same parent",
+				},
+			}
+		}
+	}
+	return nil
+}
+
+func newCategoryAttributes(inputs []domain.CategoryAttribute) []domain.CategoryAttribute {
+	if inputs == nil {
+		return []domain.CategoryAttribute{}
+	}
+	return inputs
+}
+
+func findCategoryNode(nodes []domain.CategoryNode, categoryID string) (domain.CategoryNode, bool) {
+	for _, node := range nodes {
+		if node.ID == categoryID {
+			return node, true
+		}
+	}
+	return domain.CategoryNode{}, false
+}
+
+func (c *categoryUsecase) Create(ctx context.Context, input adapter.CategoryCreateInput) (domain.CategoryNode, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.contextTimeout)
+	defer cancel()
+
+	nodes, err := c.categoryRepo.Fetch(ctx, domain.CategorySearchOptions{})
+	if err != nil {
+		return domain.CategoryNode{}, err
+	}
+
+	category := domain.CategoryNode{
+		ID:         guuid.New().String(),
+		ParentID:   input.ParentID,
+		Level:      domain.CATEGORY_LEVEL_TOP,
+		Slug:       input.Slug,
+		Name:       input.Name,
+		Icon:       input.Icon,
+		Order:      input.Order,
+		Attributes: newCategoryAttributes(input.Attributes),
+	}
+	if input.ParentID != "" {
+		parent, ok := findCategoryNode(nodes, input.ParentID)
+		if !ok {
+			err := usecase_error.ErrBadEntityInput{
+				usecase_error.ErrEntityField{
+					Field:   "ParentID",
+					Message: "Parent category is not found",
+				},
+			}
+			return category, err
+		}
+		category.Level = parent.Level + 1
+	}
+
+	if err := c.validate(category); err != nil {
+		return category, err
+	}
+	if err := verifyCategoryNode(nodes, category); err != nil {
+		return category, err
+	}
+
+	category, err = c.categoryRepo.Create(ctx, category)
+	if err != nil {
+		return category, err
+	}
+
+	helper.InvalidateCategoryCache()
+	c.auditLogger.record(ctx, domain.AUDIT_ACTION_CATEGORY_CREATE, domain.AUDIT_TARGET_CATEGORY, category.ID, nil, category)
+	return category, nil
+}
+
+func (c *categoryUsecase) FetchTree(ctx context.Context) ([]domain.CategoryNode, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.contextTimeout)
+	defer cancel()
+
+	nodes, err := c.categoryRepo.Fetch(ctx, domain.CategorySearchOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return domain.BuildCategoryTree(nodes), nil
+}
+
+func (c *categoryUsecase) GetByID(ctx context.Context, categoryID string) (domain.CategoryNode, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.contextTimeout)
+	defer cancel()
+
+	nodes, err := c.categoryRepo.Fetch(ctx, domain.CategorySearchOptions{})
+	if err != nil {
+		return domain.CategoryNode{}, err
+	}
+	category, ok := findCategoryNode(nodes, categoryID)
+	if !ok {
+		return category, usecase_error.ErrNotFound
+	}
+	for _, child := range domain.BuildCategoryTree(nodes) {
+		if subtree, ok := findCategorySubtree(child, categoryID); ok {
+			return subtree, nil
+		}
+	}
+	return category, nil
+}
+
+func findCategorySubtree(node domain.CategoryNode, categoryID string) (domain.CategoryNode, bool) {
+	if node.ID == categoryID {
+		return node, true
+	}
+	for _, child := range node.Children {
+		if subtree, ok := findCategorySubtree(child, categoryID); ok {
+			return subtree, true
+		}
+	}
+	return domain.CategoryNode{}, false
+}
+
+// UpdateOne rename or move category, products in renamed or moved category are moved to its new path
+func (c *categoryUsecase) UpdateOne(ctx context.Context, input adapter.CategoryUpdateInput, categoryID string) (domain.CategoryNode, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.contextTimeout)
+	defer cancel()
+
+	nodes, err := c.categoryRepo.Fetch(ctx, domain.CategorySearchOptions{})
+	if err != nil {
+		return domain.CategoryNode{}, err
+	}
+	category, ok := findCategoryNode(nodes, categoryID)
+	if !ok {
+		return category, usecase_error.ErrNotFound
+	}
+	before := helper.AuditSnapshot(category)
+	previous := category
+	previousPath := domain.CategoryPath(nodes, category.ID)
+
+	if input.ParentID != category.ParentID {
+		parent, ok := findCategoryNode(nodes, input.ParentID)
+		if !ok || parent.Level != category.Level-1 {
+			err := usecase_error.ErrBadEntityInput{
+				usecase_error.ErrEntityField{
+					Field:   "ParentID",
+					Message: "Category can only be moved under category in same level as its parent",
+				},
+			}
+			return category, err
+		}
+	}
+	category.ParentID = input.ParentID
+	category.Slug = input.Slug
+	category.Name = input.Name
+	category.Icon = input.Icon
+	category.Order = input.Order
+	category.Attributes = newCategoryAttributes(input.Attributes)
+
+	if err := c.validate(category); err != nil {
+		return category, err
+	}
+	if err := verifyCategoryNode(nodes, category); err != nil {
+		return category, err
+	}
+
+	category, err = c.categoryRepo.UpdateOne(ctx, category)
+	if err != nil {
+		return category, err
+	}
+	for i, node := range nodes {
+		if node.ID == category.ID {
+			nodes[i] = category
+		}
+	}
+	if path := domain.CategoryPath(nodes, category.ID); path != previousPath {
+		if err := c.productRepo.MoveCategory(ctx, previousPath, path); err != nil {
+			c.rollbackMove(previous, previousPath, path)
+			return previous, err
+		}
+	}
+
+	helper.InvalidateCategoryCache()
+	c.auditLogger.record(ctx, domain.AUDIT_ACTION_CATEGORY_UPDATE, domain.AUDIT_TARGET_CATEGORY, category.ID, before, category)
+	return category, nil
+}
+
+// rollbackMove put category back under its previous path when its products could not be moved,
+// products already moved are moved back so category and products stay in same path
+func (c *categoryUsecase) rollbackMove(previous domain.CategoryNode, previousPath, path domain.Category) {
+	ctx, cancel := undoContext()
+	defer cancel()
+
+	if err := c.productRepo.MoveCategory(ctx, path, previousPath); err != nil {
+		fmt.Printf("[CATEGORY USECASE] : ROLLBACK MOVE PRODUCTS %s %#v \n", previous.ID, err)
+	}
+	if _, err := c.categoryRepo.UpdateOne(ctx, previous); err != nil {
+		fmt.Printf("[CATEGORY USECASE] : ROLLBACK UPDATE %s %#v \n", previous.ID, err)
+	}
+}
+
+// DeleteOne only delete category without sub category and without product
+func (c *categoryUsecase) DeleteOne(ctx context.Context, categoryID string) (domain.CategoryNode, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.contextTimeout)
+	defer cancel()
+
+	nodes, err := c.categoryRepo.Fetch(ctx, domain.CategorySearchOptions{})
+	if err != nil {
+		return domain.CategoryNode{}, err
+	}
+	category, ok := findCategoryNode(nodes, categoryID)
+	if !ok {
+		return category, usecase_error.ErrNotFound
+	}
+	for _, node := range nodes {
+		if node.ParentID == category.ID {
+			err := usecase_error.ErrBadEntityInput{
+				usecase_error.ErrEntityField{
+					Field:   "Category",
+					Message: "Category still has sub categories",
+				},
+			}
+			return category, err
+		}
+	}
+	search := domain.ProductSearchOptions{
+		CategoryPath: domain.CategoryPath(nodes, category.ID),
+	}
+	products, err := c.productRepo.Fetch(ctx, "", 1, search)
+	if err != nil {
+		return category, err
+	}
+	if len(products) != 0 {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Category",
+				Message: "Category is still used by products",
+			},
+		}
+		return category, err
+	}
+
+	before := helper.AuditSnapshot(category)
+	category, err = c.categoryRepo.DeleteOne(ctx, category)
+	if err != nil {
+		return category, err
+	}
+
+	helper.InvalidateCategoryCache()
+	c.auditLogger.record(ctx, domain.AUDIT_ACTION_CATEGORY_DELETE, domain.AUDIT_TARGET_CATEGORY, category.ID, before, nil)
+	return category, nil
+}
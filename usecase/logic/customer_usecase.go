@@ -35,6 +35,8 @@ type customerUsecase struct {
 	rproductRepo   repository.RProductRepository
 	cartRepo       repository.CartRepository
 	orderRepo      repository.OrderRepository
+	sessionRepo    repository.SessionRepository
+	auditLogger    auditLogger
 	contextTimeout time.Duration
 }
 
@@ -44,6 +46,8 @@ func NewCustomerUsecase(
 	rproductRepo repository.RProductRepository,
 	cartRepo repository.CartRepository,
 	orderRepo repository.OrderRepository,
+	sessionRepo repository.SessionRepository,
+	auditLogRepo repository.AuditLogRepository,
 	contextTimeout time.Duration,
 ) CustomerUsecase {
 	return &customerUsecase{
@@ -52,6 +56,8 @@ func NewCustomerUsecase(
 		rproductRepo:   rproductRepo,
 		cartRepo:       cartRepo,
 		orderRepo:      orderRepo,
+		sessionRepo:    sessionRepo,
+		auditLogger:    newAuditLogger(auditLogRepo),
 		contextTimeout: contextTimeout,
 	}
 }
@@ -223,16 +229,27 @@ func (c *customerUsecase) UpdateBiodata(ctx context.Context, customerBiodata ada
 		return customer, err
 	}
 
+	before := helper.AuditSnapshot(customer)
 	customer.Name = customerBiodata.Name
 	customer.Born = customerBiodata.Born
 	customer.BirthDay = customerBiodata.BirthDay
 	customer.Gender = customerBiodata.Gender
+	//new phone number has to be verified again
+	if customer.Phone != customerBiodata.Phone {
+		customer.PhoneVerified = false
+	}
 	customer.Phone = customerBiodata.Phone
 	if err := c.validate(customer); err != nil {
 		return customer, err
 	}
 
-	return c.customerRepo.UpdateOne(ctx, customer)
+	customer, err = c.customerRepo.UpdateOne(ctx, customer)
+	if err != nil {
+		return customer, err
+	}
+
+	c.auditLogger.record(ctx, domain.AUDIT_ACTION_CUSTOMER_UPDATE, domain.AUDIT_TARGET_CUSTOMER, customer.ID, before, customer)
+	return customer, nil
 }
 
 func (c *customerUsecase) UpdatePassword(ctx context.Context, input adapter.CustomerUpdatePasswordInput, customerID string) (domain.Customer, error) {
@@ -243,6 +260,7 @@ func (c *customerUsecase) UpdatePassword(ctx context.Context, input adapter.Cust
 		return customer, err
 	}
 
+	before := helper.AuditSnapshot(customer)
 	if input.Password != input.RePassword {
 		err := usecase_error.ErrBadEntityInput{
 			usecase_error.ErrEntityField{
@@ -263,8 +281,19 @@ func (c *customerUsecase) UpdatePassword(ctx context.Context, input adapter.Cust
 		return customer, err
 	}
 	customer.Password = hashedPassword
+	customer.NoPassword = false
 
-	return c.customerRepo.UpdateOne(ctx, customer)
+	customer, err = c.customerRepo.UpdateOne(ctx, customer)
+	if err != nil {
+		return customer, err
+	}
+	//leaked password must not keep other device logged in
+	if err := c.sessionRepo.RevokeAll(ctx, customer.ID, domain.LOGIN_AS_CUSTOMER, time.Now()); err != nil {
+		return customer, err
+	}
+
+	c.auditLogger.record(ctx, domain.AUDIT_ACTION_CUSTOMER_UPDATE_PASSWORD, domain.AUDIT_TARGET_CUSTOMER, customer.ID, before, customer)
+	return customer, nil
 }
 
 func (c *customerUsecase) UploadAvatar(ctx context.Context, avatar, id string) (domain.Customer, error) {
@@ -288,6 +317,7 @@ func (c *customerUsecase) AddBankAccount(ctx context.Context, input adapter.Cust
 		return customer, err
 	}
 
+	before := helper.AuditSnapshot(customer)
 	bankAccount := domain.BankAccount{}
 	bankAccount.ID = guuid.New().String()
 	bankAccount.Number = input.Number
@@ -299,7 +329,13 @@ func (c *customerUsecase) AddBankAccount(ctx context.Context, input adapter.Cust
 	if err := c.validate(customer); err != nil {
 		return customer, err
 	}
-	return c.customerRepo.UpdateOne(ctx, customer)
+	customer, err = c.customerRepo.UpdateOne(ctx, customer)
+	if err != nil {
+		return customer, err
+	}
+
+	c.auditLogger.record(ctx, domain.AUDIT_ACTION_CUSTOMER_ADD_BANK, domain.AUDIT_TARGET_CUSTOMER, customer.ID, before, customer)
+	return customer, nil
 }
 
 func (c *customerUsecase) UpdateBankAccount(ctx context.Context, input adapter.CustomerBankUpdateInput, accountID, customerID string) (domain.Customer, error) {
@@ -310,6 +346,7 @@ func (c *customerUsecase) UpdateBankAccount(ctx context.Context, input adapter.C
 		return customer, err
 	}
 
+	before := helper.AuditSnapshot(customer)
 	found := false
 	index := 0
 	for i, account := range customer.BankAccounts {
@@ -330,7 +367,13 @@ func (c *customerUsecase) UpdateBankAccount(ctx context.Context, input adapter.C
 		return customer, err
 	}
 
-	return c.customerRepo.UpdateOne(ctx, customer)
+	customer, err = c.customerRepo.UpdateOne(ctx, customer)
+	if err != nil {
+		return customer, err
+	}
+
+	c.auditLogger.record(ctx, domain.AUDIT_ACTION_CUSTOMER_UPDATE_BANK, domain.AUDIT_TARGET_CUSTOMER, customer.ID, before, customer)
+	return customer, nil
 }
 
 func (c *customerUsecase) AddAddress(ctx context.Context, input adapter.CustomerAddressCreateInput, id string) (domain.Customer, error) {
@@ -429,5 +472,8 @@ func (c *customerUsecase) DeleteOne(ctx context.Context, customer domain.Custome
 		return customer, err
 	}
 
+	if err := c.sessionRepo.RevokeAll(ctx, customer.ID, domain.LOGIN_AS_CUSTOMER, time.Now()); err != nil {
+		return customer, err
+	}
 	return c.customerRepo.DeleteOne(ctx, customer)
 }
@@ -0,0 +1,284 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	guuid "github.com/google/uuid"
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/helper"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type FlashSaleUsecase interface {
+	Create(ctx context.Context, input adapter.FlashSaleCreateInput, productID string) (domain.FlashSale, error)
+	Fetch(ctx context.Context, options adapter.FlashSaleSearchOptions) ([]domain.FlashSale, error)
+	GetByID(ctx context.Context, flashSaleID string) (domain.FlashSale, error)
+	DeleteOne(ctx context.Context, productID string, flashSaleID string) (domain.FlashSale, error)
+}
+
+type flashSaleUsecase struct {
+	flashSaleRepo  repository.FlashSaleRepository
+	productRepo    repository.ProductRepository
+	auditLogger    auditLogger
+	contextTimeout time.Duration
+}
+
+func NewFlashSaleUsecase(
+	flashSaleRepo repository.FlashSaleRepository,
+	productRepo repository.ProductRepository,
+	auditLogRepo repository.AuditLogRepository,
+	contextTimeout time.Duration,
+) FlashSaleUsecase {
+	return &flashSaleUsecase{
+		flashSaleRepo:  flashSaleRepo,
+		productRepo:    productRepo,
+		auditLogger:    newAuditLogger(auditLogRepo),
+		contextTimeout: contextTimeout,
+	}
+}
+
+func (f *flashSaleUsecase) validate(value interface{}) error {
+	if entityErr := helper.NewValidationEntity().Validate(value); entityErr != nil {
+		return entityErr
+	}
+
+	return nil
+}
+
+func flashSaleError(field string, message string) error {
+	return usecase_error.ErrBadEntityInput{
+		usecase_error.ErrEntityField{
+			Field:   field,
+			Message: message,
+		},
+	}
+}
+
+// verifyFlashSale refuse flash sale which is not cheaper than original price,
+// and flash sale overlapping other slot of the same sku because only one special price applies at a time
+func (f *flashSaleUsecase) verifyFlashSale(ctx context.Context, product domain.Product, flashSale domain.FlashSale) error {
+	snapshot := product.DenormalizationData()
+	if len(product.Variants) != 0 || flashSale.SKU != "" {
+		if _, ok := snapshot.Variant(flashSale.SKU); !ok {
+			return flashSaleError("SKU", "Variant is not found")
+		}
+	}
+	switch {
+	case flashSale.Price >= flashSale.OriginalPrice:
+		return flashSaleError("Price", "Flash sale price must be less than original price")
+	case flashSale.LimitPerCustomer > flashSale.Quota:
+		return flashSaleError("LimitPerCustomer", "LimitPerCustomer must be less than or equal Quota")
+	case !flashSale.EndAt.After(time.Now()):
+		return flashSaleError("EndAt", "Flash sale must end later than now")
+	}
+
+	flashSales, err := f.flashSaleRepo.Fetch(ctx, domain.FlashSaleSearchOptions{
+		ProductID: product.ID,
+		EndAfter:  flashSale.StartAt,
+	})
+	if err != nil {
+		return err
+	}
+	for _, item := range flashSales {
+		if item.SKU == flashSale.SKU && item.StartAt.Before(flashSale.EndAt) {
+			return flashSaleError("StartAt", "Flash sale overlaps other flash sale of the product")
+		}
+	}
+	return nil
+}
+
+// saveProductFlashSales keep slots of flash sales in product, so price of product follows them
+func (f *flashSaleUsecase) saveProductFlashSales(ctx context.Context, product domain.Product) error {
+	product.SyncDiscount(time.Now())
+	return f.productRepo.UpdateFlashSales(ctx, product)
+}
+
+func (f *flashSaleUsecase) Create(ctx context.Context, input adapter.FlashSaleCreateInput, productID string) (domain.FlashSale, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.contextTimeout)
+	defer cancel()
+
+	product, err := f.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return domain.FlashSale{}, err
+	}
+	if !product.IsActive() {
+		return domain.FlashSale{}, flashSaleError("Product", "Product is not available : "+product.Name)
+	}
+	//bundle is ordered as its products, so flash sale quota of bundle could not be reserved
+	snapshot := product.DenormalizationData()
+	if snapshot.IsBundle() {
+		return domain.FlashSale{}, flashSaleError("Product", "Flash sale is not available for bundle")
+	}
+
+	flashSale := domain.FlashSale{
+		ID:               guuid.New().String(),
+		MerchantID:       product.Merchant.ID,
+		Product:          snapshot,
+		SKU:              input.SKU,
+		Price:            input.Price,
+		OriginalPrice:    snapshot.OriginalPriceOf(input.SKU),
+		Quota:            input.Quota,
+		LimitPerCustomer: input.LimitPerCustomer,
+		Purchases:        map[string]int64{},
+		StartAt:          input.StartAt.Truncate(time.Millisecond),
+		EndAt:            input.EndAt.Truncate(time.Millisecond),
+	}
+	if flashSale.LimitPerCustomer == 0 {
+		flashSale.LimitPerCustomer = 1
+	}
+	if entityErr := f.validate(flashSale); entityErr != nil {
+		return flashSale, entityErr
+	}
+	if err := f.verifyFlashSale(ctx, product, flashSale); err != nil {
+		return flashSale, err
+	}
+
+	flashSale, err = f.flashSaleRepo.Create(ctx, flashSale)
+	if err != nil {
+		return flashSale, err
+	}
+
+	//ended flash sales are dropped, they never change price again
+	now := time.Now()
+	flashSales := []domain.ProductFlashSale{}
+	for _, item := range product.FlashSales {
+		if item.EndAt.After(now) {
+			flashSales = append(flashSales, item)
+		}
+	}
+	product.FlashSales = append(flashSales, flashSale.ProductData())
+	if err := f.saveProductFlashSales(ctx, product); err != nil {
+		if _, errDelete := f.flashSaleRepo.DeleteOne(ctx, flashSale); errDelete != nil {
+			fmt.Printf("[FLASH SALE USECASE] : ROLLBACK CREATE %s %#v \n", flashSale.ID, errDelete)
+		}
+		return flashSale, err
+	}
+
+	f.auditLogger.record(ctx, domain.AUDIT_ACTION_FLASH_SALE_CREATE, domain.AUDIT_TARGET_FLASH_SALE, flashSale.ID, nil, flashSale)
+	return flashSale, nil
+}
+
+// Fetch return ongoing and upcoming flash sales ordered by start time
+func (f *flashSaleUsecase) Fetch(ctx context.Context, options adapter.FlashSaleSearchOptions) ([]domain.FlashSale, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.contextTimeout)
+	defer cancel()
+
+	search := domain.FlashSaleSearchOptions{
+		ProductID:  options.ProductID,
+		MerchantID: options.MerchantID,
+		EndAfter:   time.Now(),
+	}
+
+	return f.flashSaleRepo.Fetch(ctx, search)
+}
+
+func (f *flashSaleUsecase) GetByID(ctx context.Context, flashSaleID string) (domain.FlashSale, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.contextTimeout)
+	defer cancel()
+
+	return f.flashSaleRepo.GetByID(ctx, flashSaleID)
+}
+
+// DeleteOne only delete flash sale which is not started yet, buyers may already hold its quota afterward
+func (f *flashSaleUsecase) DeleteOne(ctx context.Context, productID string, flashSaleID string) (domain.FlashSale, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.contextTimeout)
+	defer cancel()
+
+	flashSale, err := f.flashSaleRepo.GetByID(ctx, flashSaleID)
+	if err != nil {
+		return flashSale, err
+	}
+	if flashSale.Product.ID != productID {
+		return domain.FlashSale{}, usecase_error.ErrNotFound
+	}
+	if !time.Now().Before(flashSale.StartAt) {
+		return flashSale, flashSaleError("StartAt", "Flash sale is already started")
+	}
+
+	product, err := f.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return flashSale, err
+	}
+	flashSales := []domain.ProductFlashSale{}
+	for _, item := range product.FlashSales {
+		if item.ID != flashSale.ID {
+			flashSales = append(flashSales, item)
+		}
+	}
+	product.FlashSales = flashSales
+	if err := f.saveProductFlashSales(ctx, product); err != nil {
+		return flashSale, err
+	}
+
+	before := helper.AuditSnapshot(flashSale)
+	flashSale, err = f.flashSaleRepo.DeleteOne(ctx, flashSale)
+	if err != nil {
+		return flashSale, err
+	}
+
+	f.auditLogger.record(ctx, domain.AUDIT_ACTION_FLASH_SALE_DELETE, domain.AUDIT_TARGET_FLASH_SALE, flashSale.ID, before, nil)
+	return flashSale, nil
+}
+
+// flashSaleReserver is embedded by order usecase, it hold flash sale quota of ordered items
+type flashSaleReserver struct {
+	flashSaleRepo repository.FlashSaleRepository
+	productRepo   repository.ProductRepository
+}
+
+func newFlashSaleReserver(flashSaleRepo repository.FlashSaleRepository, productRepo repository.ProductRepository) flashSaleReserver {
+	return flashSaleReserver{
+		flashSaleRepo: flashSaleRepo,
+		productRepo:   productRepo,
+	}
+}
+
+// reserve take quota of every flash sale item of order, already reserved item is released when one fail.
+// Product stops showing flash sale price once its quota is sold out
+func (f flashSaleReserver) reserve(ctx context.Context, order domain.Order) error {
+	now := time.Now()
+	for i, item := range order.OrderItems {
+		if item.FlashSaleID == "" {
+			continue
+		}
+		flashSale, err := f.flashSaleRepo.Reserve(ctx, item.FlashSaleID, order.Customer.ID, item.Quantity, now)
+		if err != nil {
+			undoCtx, undoCancel := undoContext()
+			f.release(undoCtx, order.Customer.ID, order.OrderItems[:i])
+			undoCancel()
+			if err == usecase_error.ErrNotFound {
+				return flashSaleError("Quantity", "Flash sale quota is sold out or purchase limit reached : "+item.Product.Name)
+			}
+			return err
+		}
+		if flashSale.Sold >= flashSale.Quota {
+			if err := f.productRepo.SetFlashSaleSoldOut(ctx, item.Product.ID, flashSale.ID, true); err != nil {
+				fmt.Printf("[FLASH SALE RESERVER] : SET SOLD OUT %s %#v \n", flashSale.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// release give back flash sale quota of canceled order items, failure is only logged
+func (f flashSaleReserver) release(ctx context.Context, customerID string, items []domain.OrderItems) {
+	for _, item := range items {
+		if item.FlashSaleID == "" {
+			continue
+		}
+		flashSale, err := f.flashSaleRepo.Release(ctx, item.FlashSaleID, customerID, item.Quantity)
+		if err != nil {
+			fmt.Printf("[FLASH SALE RESERVER] : RELEASE %s %#v \n", item.FlashSaleID, err)
+			continue
+		}
+		if flashSale.Sold < flashSale.Quota && flashSale.Sold+item.Quantity >= flashSale.Quota {
+			if err := f.productRepo.SetFlashSaleSoldOut(ctx, item.Product.ID, flashSale.ID, false); err != nil {
+				fmt.Printf("[FLASH SALE RESERVER] : UNSET SOLD OUT %s %#v \n", flashSale.ID, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	guuid "github.com/google/uuid"
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/helper"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+// GuestCartUsecase manage cart of anonymous visitor, the cart is identified by signed guest cart token
+// and merged into customer cart when visitor login
+type GuestCartUsecase interface {
+	Create(ctx context.Context) (domain.Cart, string, error)
+	GetByToken(ctx context.Context, token string) (domain.Cart, error)
+	AddProduct(ctx context.Context, input adapter.CartAddItemInput, token string) (domain.Cart, error)
+	UpdateItemInCart(ctx context.Context, input adapter.CartUpdateItemInput, productID string, sku string, token string) (domain.Cart, error)
+	RemoveProduct(ctx context.Context, productID string, sku string, token string) (domain.Cart, error)
+	ClearProduct(ctx context.Context, token string) (domain.Cart, error)
+}
+
+type guestCartUsecase struct {
+	guestCartRepo  repository.GuestCartRepository
+	productRepo    repository.ProductRepository
+	contextTimeout time.Duration
+}
+
+func NewGuestCartUsecase(
+	guestCartRepo repository.GuestCartRepository,
+	productRepo repository.ProductRepository,
+	contextTimeout time.Duration,
+) GuestCartUsecase {
+	return &guestCartUsecase{
+		guestCartRepo:  guestCartRepo,
+		productRepo:    productRepo,
+		contextTimeout: contextTimeout,
+	}
+}
+
+func (g *guestCartUsecase) Create(ctx context.Context) (domain.Cart, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.contextTimeout)
+	defer cancel()
+
+	now := time.Now().Truncate(time.Millisecond)
+	cart := domain.Cart{
+		ID:        guuid.New().String(),
+		Items:     []domain.Item{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	token, err := helper.EncodeGuestCartToken(domain.NewGuestCartToken(cart))
+	if err != nil {
+		return cart, "", err
+	}
+
+	cart, err = g.guestCartRepo.Save(ctx, cart)
+	if err != nil {
+		return cart, "", err
+	}
+	return cart, token, nil
+}
+
+func (g *guestCartUsecase) getByToken(ctx context.Context, token string) (domain.Cart, error) {
+	guestCart, err := helper.DecodeGuestCartToken(token)
+	if err != nil {
+		return domain.Cart{}, err
+	}
+
+	return g.guestCartRepo.GetByID(ctx, guestCart.CartID)
+}
+
+func (g *guestCartUsecase) GetByToken(ctx context.Context, token string) (domain.Cart, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.contextTimeout)
+	defer cancel()
+	cart, err := g.getByToken(ctx, token)
+	if err != nil {
+		return cart, err
+	}
+
+	cart, changed, err := reconcileCart(ctx, g.productRepo, cart)
+	if err != nil {
+		return cart, err
+	}
+	if changed {
+		return g.guestCartRepo.Save(ctx, cart)
+	}
+	return cart, nil
+}
+
+func (g *guestCartUsecase) AddProduct(ctx context.Context, input adapter.CartAddItemInput, token string) (domain.Cart, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.contextTimeout)
+	defer cancel()
+	cart, err := g.getByToken(ctx, token)
+	if err != nil {
+		return cart, err
+	}
+
+	cart, err = addCartItem(ctx, g.productRepo, cart, input, "")
+	if err != nil {
+		return cart, err
+	}
+
+	return g.guestCartRepo.Save(ctx, cart)
+}
+
+func (g *guestCartUsecase) UpdateItemInCart(ctx context.Context, input adapter.CartUpdateItemInput, productID string, sku string, token string) (domain.Cart, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.contextTimeout)
+	defer cancel()
+	cart, err := g.getByToken(ctx, token)
+	if err != nil {
+		return cart, err
+	}
+
+	cart, err = updateCartItem(ctx, g.productRepo, cart, input, productID, sku)
+	if err != nil {
+		return cart, err
+	}
+
+	return g.guestCartRepo.Save(ctx, cart)
+}
+
+func (g *guestCartUsecase) RemoveProduct(ctx context.Context, productID string, sku string, token string) (domain.Cart, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.contextTimeout)
+	defer cancel()
+	cart, err := g.getByToken(ctx, token)
+	if err != nil {
+		return cart, err
+	}
+
+	cart, err = removeCartItem(cart, productID, sku)
+	if err != nil {
+		return cart, err
+	}
+
+	return g.guestCartRepo.Save(ctx, cart)
+}
+
+func (g *guestCartUsecase) ClearProduct(ctx context.Context, token string) (domain.Cart, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.contextTimeout)
+	defer cancel()
+	cart, err := g.getByToken(ctx, token)
+	if err != nil {
+		return cart, err
+	}
+
+	cart.Items = []domain.Item{}
+	return g.guestCartRepo.Save(ctx, cart)
+}
+
+// mergeGuestCart move guest cart items into cart of customer who just login, guest cart is removed afterwards.
+// Invalid or expired token is ignored because guest cart is optional for login
+func mergeGuestCart(
+	ctx context.Context,
+	guestCartRepo repository.GuestCartRepository,
+	cartRepo repository.CartRepository,
+	productRepo repository.ProductRepository,
+	token string,
+	credential domain.Credential,
+) error {
+	if token == "" {
+		return nil
+	}
+	guestCartToken, err := helper.DecodeGuestCartToken(token)
+	if err != nil {
+		return nil
+	}
+	guestCart, err := guestCartRepo.GetByID(ctx, guestCartToken.CartID)
+	if err != nil {
+		if err == usecase_error.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+
+	cart, err := cartRepo.GetByID(ctx, credential.CartID)
+	if err != nil {
+		return err
+	}
+	cart, err = mergeCartItems(ctx, productRepo, cart, guestCart, credential.MerchantID)
+	if err != nil {
+		return err
+	}
+	if _, err := cartRepo.UpdateOne(ctx, cart); err != nil {
+		return err
+	}
+
+	if err := guestCartRepo.Delete(ctx, guestCart.ID); err != nil {
+		fmt.Printf("[GUEST CART] : DELETE MERGED CART %#v \n", err)
+	}
+	return nil
+}
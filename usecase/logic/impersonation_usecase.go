@@ -0,0 +1,86 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+// ImpersonationUsecase let support admin see the application as a customer, the credential is read only
+type ImpersonationUsecase interface {
+	Start(ctx context.Context, customerID string) (domain.Credential, error)
+	// RecordRequest write every request made with impersonation credential to audit log
+	RecordRequest(ctx context.Context, credential domain.Credential, method, path string)
+}
+
+type impersonationUsecase struct {
+	customerRepo   repository.CustomerRepository
+	sessionRepo    repository.SessionRepository
+	auditLogger    auditLogger
+	contextTimeout time.Duration
+}
+
+func NewImpersonationUsecase(
+	customerRepo repository.CustomerRepository,
+	sessionRepo repository.SessionRepository,
+	auditLogRepo repository.AuditLogRepository,
+	contextTimeout time.Duration,
+) ImpersonationUsecase {
+	return &impersonationUsecase{
+		customerRepo:   customerRepo,
+		sessionRepo:    sessionRepo,
+		auditLogger:    newAuditLogger(auditLogRepo),
+		contextTimeout: contextTimeout,
+	}
+}
+
+func (i *impersonationUsecase) Start(ctx context.Context, customerID string) (domain.Credential, error) {
+	admin, ok := ctx.Value("credential").(domain.Credential)
+	if !ok || admin.LoginType != domain.LOGIN_AS_ADMIN {
+		return domain.Credential{}, usecase_error.ErrNotAuthorization
+	}
+	ctx, cancel := context.WithTimeout(ctx, i.contextTimeout)
+	defer cancel()
+
+	customer, err := i.customerRepo.GetByID(ctx, customerID)
+	if err != nil {
+		return domain.Credential{}, err
+	}
+
+	credential := domain.NewCredential(
+		customer.ID,
+		customer.CartID,
+		customer.MerchantID,
+		customer.Email,
+		domain.LOGIN_AS_CUSTOMER,
+		"",
+	)
+	credential.ImpersonatorID = admin.UserID
+	credential.ExpiresAt = time.Now().Add(domain.IMPERSONATION_DURATION).Unix()
+	if err := startLoginSession(ctx, i.sessionRepo, &credential); err != nil {
+		return domain.Credential{}, err
+	}
+
+	after := map[string]interface{}{
+		"session_id": credential.Id,
+		"expires_at": time.Unix(credential.ExpiresAt, 0),
+	}
+	i.auditLogger.record(ctx, domain.AUDIT_ACTION_CUSTOMER_IMPERSONATE, domain.AUDIT_TARGET_CUSTOMER, customer.ID, nil, after)
+	return credential, nil
+}
+
+func (i *impersonationUsecase) RecordRequest(ctx context.Context, credential domain.Credential, method, path string) {
+	ctx = context.WithValue(ctx, "credential", credential)
+	ctx, cancel := context.WithTimeout(ctx, i.contextTimeout)
+	defer cancel()
+
+	after := map[string]interface{}{
+		"method":     method,
+		"path":       path,
+		"session_id": credential.Id,
+	}
+	i.auditLogger.record(ctx, domain.AUDIT_ACTION_IMPERSONATION_REQUEST, domain.AUDIT_TARGET_CUSTOMER, credential.UserID, nil, after)
+}
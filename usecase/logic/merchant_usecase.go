@@ -37,6 +37,7 @@ type merchantUsecase struct {
 	productRepo    repository.ProductRepository
 	orderRepo      repository.OrderRepository
 	cartRepo       repository.CartRepository
+	auditLogger    auditLogger
 	contextTimeout time.Duration
 }
 
@@ -47,6 +48,7 @@ func NewMerchantUsecase(
 	productRepo repository.ProductRepository,
 	orderRepo repository.OrderRepository,
 	cartRepo repository.CartRepository,
+	auditLogRepo repository.AuditLogRepository,
 	contextTimeout time.Duration,
 ) MerchantUsecase {
 	return &merchantUsecase{
@@ -56,6 +58,7 @@ func NewMerchantUsecase(
 		productRepo:    productRepo,
 		orderRepo:      orderRepo,
 		cartRepo:       cartRepo,
+		auditLogger:    newAuditLogger(auditLogRepo),
 		contextTimeout: contextTimeout,
 	}
 
@@ -280,6 +283,7 @@ func (m *merchantUsecase) UpdateData(ctx context.Context, input adapter.Merchant
 		return merchant, err
 	}
 
+	before := helper.AuditSnapshot(merchant)
 	merchant.Phone = input.Phone
 	merchant.Description = input.Description
 	merchant.Address.City = input.Address.City
@@ -307,7 +311,12 @@ func (m *merchantUsecase) UpdateData(ctx context.Context, input adapter.Merchant
 	}()
 	wgSave.Wait()
 
-	return updatedMerchant, err
+	if err != nil {
+		return updatedMerchant, err
+	}
+
+	m.auditLogger.record(ctx, domain.AUDIT_ACTION_MERCHANT_UPDATE, domain.AUDIT_TARGET_MERCHANT, updatedMerchant.ID, before, updatedMerchant)
+	return updatedMerchant, nil
 }
 
 func (m *merchantUsecase) UploadAvatar(ctx context.Context, fileName, merchantID string) (domain.Merchant, error) {
@@ -345,6 +354,7 @@ func (m *merchantUsecase) AddShipping(ctx context.Context, shippingID string, me
 	if err != nil {
 		return merchant, err
 	}
+	before := helper.AuditSnapshot(merchant)
 	shipping, err := m.shippingRepo.GetByID(ctx, shippingID)
 	if err != nil {
 		return merchant, err
@@ -372,7 +382,12 @@ func (m *merchantUsecase) AddShipping(ctx context.Context, shippingID string, me
 	}()
 	wgSave.Wait()
 
-	return updatedMerchant, err
+	if err != nil {
+		return updatedMerchant, err
+	}
+
+	m.auditLogger.record(ctx, domain.AUDIT_ACTION_MERCHANT_ADD_SHIPPING, domain.AUDIT_TARGET_MERCHANT, updatedMerchant.ID, before, updatedMerchant)
+	return updatedMerchant, nil
 }
 
 func (m *merchantUsecase) RemoveShipping(ctx context.Context, shippingID string, merchantID string) (domain.Merchant, error) {
@@ -383,6 +398,7 @@ func (m *merchantUsecase) RemoveShipping(ctx context.Context, shippingID string,
 		return merchant, err
 	}
 
+	before := helper.AuditSnapshot(merchant)
 	found := false
 	index := 0
 	for i, shipping := range merchant.Shippings {
@@ -421,7 +437,12 @@ func (m *merchantUsecase) RemoveShipping(ctx context.Context, shippingID string,
 	}()
 	wgSave.Wait()
 
-	return updatedMerchant, err
+	if err != nil {
+		return updatedMerchant, err
+	}
+
+	m.auditLogger.record(ctx, domain.AUDIT_ACTION_MERCHANT_REMOVE_SHIPPING, domain.AUDIT_TARGET_MERCHANT, updatedMerchant.ID, before, updatedMerchant)
+	return updatedMerchant, nil
 }
 
 func (m *merchantUsecase) AddBankAccount(ctx context.Context, input adapter.MerchantBankCreateInput, merchantID string) (domain.Merchant, error) {
@@ -432,6 +453,7 @@ func (m *merchantUsecase) AddBankAccount(ctx context.Context, input adapter.Merc
 		return merchant, err
 	}
 
+	before := helper.AuditSnapshot(merchant)
 	bankAccount := domain.BankAccount{}
 	bankAccount.ID = guuid.New().String()
 	bankAccount.Number = input.Number
@@ -443,7 +465,13 @@ func (m *merchantUsecase) AddBankAccount(ctx context.Context, input adapter.Merc
 	if err := m.validate(merchant); err != nil {
 		return merchant, err
 	}
-	return m.merchantRepo.UpdateOne(ctx, merchant)
+	merchant, err = m.merchantRepo.UpdateOne(ctx, merchant)
+	if err != nil {
+		return merchant, err
+	}
+
+	m.auditLogger.record(ctx, domain.AUDIT_ACTION_MERCHANT_ADD_BANK, domain.AUDIT_TARGET_MERCHANT, merchant.ID, before, merchant)
+	return merchant, nil
 }
 
 func (m *merchantUsecase) UpdateBankAccount(ctx context.Context, input adapter.MerchantBankUpdateInput, accountID, merchantID string) (domain.Merchant, error) {
@@ -454,6 +482,7 @@ func (m *merchantUsecase) UpdateBankAccount(ctx context.Context, input adapter.M
 		return merchant, err
 	}
 
+	before := helper.AuditSnapshot(merchant)
 	found := false
 	index := 0
 	for i, account := range merchant.BankAccounts {
@@ -474,7 +503,13 @@ func (m *merchantUsecase) UpdateBankAccount(ctx context.Context, input adapter.M
 		return merchant, err
 	}
 
-	return m.merchantRepo.UpdateOne(ctx, merchant)
+	merchant, err = m.merchantRepo.UpdateOne(ctx, merchant)
+	if err != nil {
+		return merchant, err
+	}
+
+	m.auditLogger.record(ctx, domain.AUDIT_ACTION_MERCHANT_UPDATE_BANK, domain.AUDIT_TARGET_MERCHANT, merchant.ID, before, merchant)
+	return merchant, nil
 }
 
 func (m *merchantUsecase) AddEtalase(ctx context.Context, input adapter.MerchantEtalaseCreateInput, merchantID string) (domain.Merchant, error) {
@@ -0,0 +1,56 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type NotificationUsecase interface {
+	Fetch(ctx context.Context, cursor string, num int64, customerID string, unread bool) ([]domain.Notification, error)
+	MarkRead(ctx context.Context, notificationID string, customerID string) (domain.Notification, error)
+}
+
+type notificationUsecase struct {
+	notificationRepo repository.NotificationRepository
+	contextTimeout   time.Duration
+}
+
+func NewNotificationUsecase(
+	notificationRepo repository.NotificationRepository,
+	contextTimeout time.Duration,
+) NotificationUsecase {
+	return &notificationUsecase{
+		notificationRepo: notificationRepo,
+		contextTimeout:   contextTimeout,
+	}
+}
+
+func (n *notificationUsecase) Fetch(ctx context.Context, cursor string, num int64, customerID string, unread bool) ([]domain.Notification, error) {
+	ctx, cancel := context.WithTimeout(ctx, n.contextTimeout)
+	defer cancel()
+
+	search := domain.NotificationSearchOptions{
+		CustomerID: customerID,
+		Unread:     unread,
+	}
+	return n.notificationRepo.Fetch(ctx, cursor, num, search)
+}
+
+func (n *notificationUsecase) MarkRead(ctx context.Context, notificationID string, customerID string) (domain.Notification, error) {
+	ctx, cancel := context.WithTimeout(ctx, n.contextTimeout)
+	defer cancel()
+
+	notification, err := n.notificationRepo.GetByID(ctx, notificationID)
+	if err != nil {
+		return notification, err
+	}
+	//other customer notification is hidden
+	if notification.CustomerID != customerID {
+		return domain.Notification{}, usecase_error.ErrNotFound
+	}
+	return n.notificationRepo.MarkRead(ctx, notification.ID)
+}
@@ -0,0 +1,469 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	guuid "github.com/google/uuid"
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/helper"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type OIDCUsecase interface {
+	Authorize(ctx context.Context, provider string) (domain.OIDCAuthorization, error)
+	// Login return credential or login challenge when two factor is enabled,
+	// customer and cart are created when the identity is not linked to any customer yet
+	Login(ctx context.Context, provider string, input adapter.OIDCCallbackInput) (domain.Credential, domain.LoginChallenge, error)
+	CompleteProfile(ctx context.Context, input adapter.OIDCProfileInput, customerID string) (domain.Customer, error)
+	AuthorizeLink(ctx context.Context, provider, customerID string) (domain.OIDCAuthorization, error)
+	Link(ctx context.Context, provider string, input adapter.OIDCCallbackInput, customerID string) (domain.Customer, error)
+	Unlink(ctx context.Context, provider, customerID string) (domain.Customer, error)
+}
+
+type oidcUsecase struct {
+	oidcProviderRepo repository.OIDCProviderRepository
+	oidcStateRepo    repository.OIDCStateRepository
+	customerRepo     repository.CustomerRepository
+	cartRepo         repository.CartRepository
+	guestCartRepo    repository.GuestCartRepository
+	productRepo      repository.ProductRepository
+	sessionRepo      repository.SessionRepository
+	auditLogger      auditLogger
+	contextTimeout   time.Duration
+}
+
+func NewOIDCUsecase(
+	oidcProviderRepo repository.OIDCProviderRepository,
+	oidcStateRepo repository.OIDCStateRepository,
+	customerRepo repository.CustomerRepository,
+	cartRepo repository.CartRepository,
+	guestCartRepo repository.GuestCartRepository,
+	productRepo repository.ProductRepository,
+	sessionRepo repository.SessionRepository,
+	auditLogRepo repository.AuditLogRepository,
+	contextTimeout time.Duration,
+) OIDCUsecase {
+	return &oidcUsecase{
+		oidcProviderRepo: oidcProviderRepo,
+		oidcStateRepo:    oidcStateRepo,
+		customerRepo:     customerRepo,
+		cartRepo:         cartRepo,
+		guestCartRepo:    guestCartRepo,
+		productRepo:      productRepo,
+		sessionRepo:      sessionRepo,
+		auditLogger:      newAuditLogger(auditLogRepo),
+		contextTimeout:   contextTimeout,
+	}
+}
+
+func (o *oidcUsecase) validate(value interface{}) error {
+	if entityErr := helper.NewValidationEntity().Validate(value); entityErr != nil {
+		return entityErr
+	}
+
+	return nil
+}
+
+func (o *oidcUsecase) Authorize(ctx context.Context, provider string) (domain.OIDCAuthorization, error) {
+	ctx, cancel := context.WithTimeout(ctx, o.contextTimeout)
+	defer cancel()
+
+	return o.authorize(ctx, provider, domain.OIDC_PURPOSE_LOGIN, "")
+}
+
+func (o *oidcUsecase) AuthorizeLink(ctx context.Context, provider, customerID string) (domain.OIDCAuthorization, error) {
+	ctx, cancel := context.WithTimeout(ctx, o.contextTimeout)
+	defer cancel()
+
+	return o.authorize(ctx, provider, domain.OIDC_PURPOSE_LINK, customerID)
+}
+
+// authorize save state, nonce and pkce verifier, then build url where customer is redirected to provider
+func (o *oidcUsecase) authorize(ctx context.Context, provider, purpose, customerID string) (domain.OIDCAuthorization, error) {
+	var authorization domain.OIDCAuthorization
+	if !o.oidcProviderRepo.IsRegistered(provider) {
+		fmt.Printf("[OIDC USECASE] : AUTHORIZE %#v \n", "PROVIDER NOT REGISTERED")
+		return authorization, usecase_error.ErrNotFound
+	}
+
+	verifier, challenge, err := helper.GeneratePKCE()
+	if err != nil {
+		return authorization, err
+	}
+	state, err := helper.GenerateOIDCToken(0)
+	if err != nil {
+		return authorization, err
+	}
+	nonce, err := helper.GenerateOIDCToken(0)
+	if err != nil {
+		return authorization, err
+	}
+
+	request := domain.OIDCAuthRequest{
+		State:        state,
+		Provider:     provider,
+		Purpose:      purpose,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		CustomerID:   customerID,
+	}
+	if err := o.oidcStateRepo.SaveAuthRequest(ctx, request); err != nil {
+		return authorization, err
+	}
+
+	authorizationURL, err := o.oidcProviderRepo.AuthorizationURL(ctx, request, challenge)
+	if err != nil {
+		return authorization, err
+	}
+
+	authorization.AuthorizationURL = authorizationURL
+	authorization.State = state
+	return authorization, nil
+}
+
+// completeAuthorization check callback state belong to the same flow, then trade code for verified identity
+func (o *oidcUsecase) completeAuthorization(ctx context.Context, provider, purpose, customerID string, input adapter.OIDCCallbackInput) (domain.OIDCIdentity, error) {
+	var identity domain.OIDCIdentity
+	if !o.oidcProviderRepo.IsRegistered(provider) {
+		return identity, usecase_error.ErrNotFound
+	}
+	if input.Code == "" || input.State == "" {
+		return identity, usecase_error.ErrBadParamInput
+	}
+
+	request, err := o.oidcStateRepo.TakeAuthRequest(ctx, input.State)
+	if err != nil {
+		fmt.Printf("[OIDC USECASE] : TAKE AUTH REQUEST %#v \n", err)
+		if err == usecase_error.ErrNotFound {
+			return identity, usecase_error.ErrNotAuthentication
+		}
+		return identity, err
+	}
+	if request.Provider != provider || request.Purpose != purpose || request.CustomerID != customerID {
+		fmt.Printf("[OIDC USECASE] : COMPLETE AUTHORIZATION %#v \n", "STATE NOT MATCH")
+		return identity, usecase_error.ErrNotAuthentication
+	}
+
+	identity, err = o.oidcProviderRepo.Exchange(ctx, provider, input.Code, request.CodeVerifier)
+	if err != nil {
+		return identity, err
+	}
+	if identity.Nonce != request.Nonce {
+		fmt.Printf("[OIDC USECASE] : COMPLETE AUTHORIZATION %#v \n", "NONCE NOT MATCH")
+		return identity, usecase_error.ErrNotAuthentication
+	}
+
+	return identity, nil
+}
+
+func (o *oidcUsecase) findLinkedCustomer(ctx context.Context, identity domain.OIDCIdentity) ([]domain.Customer, error) {
+	var noCursor string
+	var numReturned int64 = 1
+	search := domain.CustomerSearchOptions{
+		IdentityProvider: identity.Provider,
+		IdentitySubject:  identity.Subject,
+	}
+	return o.customerRepo.Fetch(ctx, noCursor, numReturned, search)
+}
+
+func (o *oidcUsecase) Login(ctx context.Context, provider string, input adapter.OIDCCallbackInput) (domain.Credential, domain.LoginChallenge, error) {
+	ctx, cancel := context.WithTimeout(ctx, o.contextTimeout)
+	defer cancel()
+
+	identity, err := o.completeAuthorization(ctx, provider, domain.OIDC_PURPOSE_LOGIN, "", input)
+	if err != nil {
+		return domain.Credential{}, domain.LoginChallenge{}, err
+	}
+
+	customers, err := o.findLinkedCustomer(ctx, identity)
+	if err != nil {
+		return domain.Credential{}, domain.LoginChallenge{}, err
+	}
+	var customer domain.Customer
+	if len(customers) == 1 {
+		customer = customers[0]
+		if err := verifyNotSuspended(customer.Suspension); err != nil {
+			return domain.Credential{}, domain.LoginChallenge{}, err
+		}
+		if customer.TwoFactor.Enabled {
+			challenge := domain.NewLoginChallenge(customer.ID, domain.LOGIN_AS_CUSTOMER, true)
+			return domain.Credential{}, challenge, nil
+		}
+	} else {
+		customer, err = o.register(ctx, identity)
+		if err != nil {
+			return domain.Credential{}, domain.LoginChallenge{}, err
+		}
+	}
+
+	credential := domain.NewCredential(
+		customer.ID,
+		customer.CartID,
+		customer.MerchantID,
+		customer.Email,
+		domain.LOGIN_AS_CUSTOMER,
+		"",
+	)
+	if err := startLoginSession(ctx, o.sessionRepo, &credential); err != nil {
+		return domain.Credential{}, domain.LoginChallenge{}, err
+	}
+	if err := mergeGuestCart(ctx, o.guestCartRepo, o.cartRepo, o.productRepo, input.GuestCartToken, credential); err != nil {
+		fmt.Printf("[OIDC USECASE] : MERGE GUEST CART %#v \n", err)
+	}
+	return credential, domain.LoginChallenge{}, nil
+}
+
+func (o *oidcUsecase) isEmailRegistered(ctx context.Context, email string) (bool, error) {
+	var noCursor string
+	var numReturned int64 = 1
+	search := domain.CustomerSearchOptions{
+		Email: email,
+	}
+	customers, err := o.customerRepo.Fetch(ctx, noCursor, numReturned, search)
+	if err != nil {
+		return false, err
+	}
+
+	return len(customers) > 0, nil
+}
+
+// oidcProfileFields are customer fields which provider does not give, they are filled by CompleteProfile
+var oidcProfileFields = []string{"Addresses", "Born", "BirthDay", "Phone", "Gender"}
+
+// register create customer and cart for identity on its first login, customer is saved without profile fields
+// which provider does not give, so customer has to complete profile first
+func (o *oidcUsecase) register(ctx context.Context, identity domain.OIDCIdentity) (domain.Customer, error) {
+	if identity.Email == "" || !identity.EmailVerified {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Email",
+				Message: "Email from provider is not verified",
+			},
+		}
+		return domain.Customer{}, err
+	}
+	//existing account is never linked automatically, owner must login and link it from profile
+	if isRegistered, err := o.isEmailRegistered(ctx, identity.Email); err != nil || isRegistered {
+		if isRegistered {
+			err := usecase_error.ErrBadEntityInput{
+				usecase_error.ErrEntityField{
+					Field:   "Email",
+					Message: "Email is registered, login with password then link the account from profile",
+				},
+			}
+			return domain.Customer{}, err
+		}
+		return domain.Customer{}, err
+	}
+
+	cart := domain.Cart{
+		ID:        guuid.New().String(),
+		Items:     []domain.Item{},
+		CreatedAt: time.Now().Truncate(time.Millisecond),
+		UpdatedAt: time.Now().Truncate(time.Millisecond),
+	}
+
+	//customer can only login with provider until password is set from profile
+	randomPassword, err := helper.GenerateOIDCToken(0)
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	var customer domain.Customer
+	customer.ID = guuid.New().String()
+	customer.CartID = cart.ID
+	customer.Name = identity.Name
+	if customer.Name == "" {
+		customer.Name = identity.Email
+	}
+	customer.Email = identity.Email
+	customer.Password = randomPassword + "aA1!"
+	customer.NoPassword = true
+	customer.Addresses = []domain.Address{}
+	customer.Avatar = identity.Picture
+	if customer.Avatar == "" {
+		customer.Avatar = "https://storage.googleapis.com/ecommerce_s2l_assets/default-user.png"
+	}
+	customer.Identities = []domain.ExternalIdentity{
+		{
+			Provider: identity.Provider,
+			Subject:  identity.Subject,
+			Email:    identity.Email,
+			LinkedAt: time.Now().Truncate(time.Millisecond),
+		},
+	}
+	customer.CreatedAt = time.Now().Truncate(time.Millisecond)
+	customer.UpdatedAt = time.Now().Truncate(time.Millisecond)
+
+	if entityErr := helper.NewValidationEntity().ValidateExcept(customer, oidcProfileFields...); entityErr != nil {
+		fmt.Printf("[OIDC USECASE] : VALIDATE CUSTOMER ENTITY : %#v \n", entityErr)
+		return domain.Customer{}, entityErr
+	}
+
+	hashedPassword, err := helper.NewEncription().Encrypt([]byte(customer.Password))
+	if err != nil {
+		return domain.Customer{}, err
+	}
+	customer.Password = hashedPassword
+
+	if _, err := o.cartRepo.Create(ctx, cart); err != nil {
+		return domain.Customer{}, err
+	}
+	return o.customerRepo.Create(ctx, customer)
+}
+
+// CompleteProfile fill profile fields of customer created by first login with provider,
+// customer without address has not completed profile because address is required otherwise
+func (o *oidcUsecase) CompleteProfile(ctx context.Context, input adapter.OIDCProfileInput, customerID string) (domain.Customer, error) {
+	ctx, cancel := context.WithTimeout(ctx, o.contextTimeout)
+	defer cancel()
+
+	customer, err := o.customerRepo.GetByID(ctx, customerID)
+	if err != nil {
+		return customer, err
+	}
+	if len(customer.Addresses) != 0 {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Profile",
+				Message: "Profile is already completed",
+			},
+		}
+		return customer, err
+	}
+
+	before := helper.AuditSnapshot(customer)
+	var addresses []domain.Address
+	for _, add := range input.Addresses {
+		var address domain.Address
+		address.ID = guuid.New().String()
+		address.Street = add.Street
+		address.City = add.City
+		address.Number = add.Number
+		addresses = append(addresses, address)
+	}
+	customer.Addresses = addresses
+	customer.Born = input.Born
+	customer.BirthDay = input.BirthDay
+	customer.Phone = input.Phone
+	customer.Gender = input.Gender
+
+	if entityErr := o.validate(customer); entityErr != nil {
+		fmt.Printf("[OIDC USECASE] : VALIDATE CUSTOMER ENTITY : %#v \n", entityErr)
+		return customer, entityErr
+	}
+	for _, address := range customer.Addresses {
+		if entityErr := o.validate(address); entityErr != nil {
+			fmt.Printf("[OIDC USECASE] : VALIDATE ADDRESS : %#v \n", entityErr)
+			return customer, entityErr
+		}
+	}
+
+	customer, err = o.customerRepo.UpdateOne(ctx, customer)
+	if err != nil {
+		return customer, err
+	}
+
+	o.auditLogger.record(ctx, domain.AUDIT_ACTION_CUSTOMER_UPDATE, domain.AUDIT_TARGET_CUSTOMER, customer.ID, before, customer)
+	return customer, nil
+}
+
+func (o *oidcUsecase) Link(ctx context.Context, provider string, input adapter.OIDCCallbackInput, customerID string) (domain.Customer, error) {
+	ctx, cancel := context.WithTimeout(ctx, o.contextTimeout)
+	defer cancel()
+
+	identity, err := o.completeAuthorization(ctx, provider, domain.OIDC_PURPOSE_LINK, customerID, input)
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	customer, err := o.customerRepo.GetByID(ctx, customerID)
+	if err != nil {
+		return customer, err
+	}
+	for _, linked := range customer.Identities {
+		if linked.Provider == provider {
+			err := usecase_error.ErrBadEntityInput{
+				usecase_error.ErrEntityField{
+					Field:   "Provider",
+					Message: "Provider is already linked, unlink it first",
+				},
+			}
+			return customer, err
+		}
+	}
+
+	customers, err := o.findLinkedCustomer(ctx, identity)
+	if err != nil {
+		return customer, err
+	}
+	if len(customers) > 0 {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Provider",
+				Message: "Account is linked to other customer",
+			},
+		}
+		return customer, err
+	}
+
+	before := helper.AuditSnapshot(customer)
+	customer.Identities = append(customer.Identities, domain.ExternalIdentity{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+		LinkedAt: time.Now().Truncate(time.Millisecond),
+	})
+
+	customer, err = o.customerRepo.UpdateOne(ctx, customer)
+	if err != nil {
+		return customer, err
+	}
+
+	o.auditLogger.record(ctx, domain.AUDIT_ACTION_CUSTOMER_LINK_IDENTITY, domain.AUDIT_TARGET_CUSTOMER, customer.ID, before, customer)
+	return customer, nil
+}
+
+func (o *oidcUsecase) Unlink(ctx context.Context, provider, customerID string) (domain.Customer, error) {
+	ctx, cancel := context.WithTimeout(ctx, o.contextTimeout)
+	defer cancel()
+
+	customer, err := o.customerRepo.GetByID(ctx, customerID)
+	if err != nil {
+		return customer, err
+	}
+
+	before := helper.AuditSnapshot(customer)
+	identities := []domain.ExternalIdentity{}
+	for _, linked := range customer.Identities {
+		if linked.Provider != provider {
+			identities = append(identities, linked)
+		}
+	}
+	if len(identities) == len(customer.Identities) {
+		return customer, usecase_error.ErrNotFound
+	}
+	//customer without password would not be able to login anymore
+	if customer.NoPassword && len(identities) == 0 {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Provider",
+				Message: "Set password before unlink the last linked account",
+			},
+		}
+		return customer, err
+	}
+
+	customer.Identities = identities
+	customer, err = o.customerRepo.UpdateOne(ctx, customer)
+	if err != nil {
+		return customer, err
+	}
+
+	o.auditLogger.record(ctx, domain.AUDIT_ACTION_CUSTOMER_UNLINK_IDENTITY, domain.AUDIT_TARGET_CUSTOMER, customer.ID, before, customer)
+	return customer, nil
+}
@@ -2,6 +2,7 @@ package logic
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -33,6 +34,10 @@ type orderUsecase struct {
 	merchantRepo   repository.MerchantRepository
 	cartRepo       repository.CartRepository
 	tBuyerRepo     repository.TBuyerRepository
+	auditLogger    auditLogger
+	vouchers       voucherApplier
+	flashSales     flashSaleReserver
+	notifier       wishlistNotifier
 	contextTimeout time.Duration
 }
 
@@ -43,6 +48,11 @@ func NewOrderUsecase(
 	merchantRepo repository.MerchantRepository,
 	cartRepo repository.CartRepository,
 	tBuyerRepo repository.TBuyerRepository,
+	voucherRepo repository.VoucherRepository,
+	flashSaleRepo repository.FlashSaleRepository,
+	auditLogRepo repository.AuditLogRepository,
+	wishlistRepo repository.WishlistRepository,
+	notificationRepo repository.NotificationRepository,
 	contextTimeout time.Duration,
 ) OrderUsecase {
 	return &orderUsecase{
@@ -52,6 +62,10 @@ func NewOrderUsecase(
 		merchantRepo:   merchantRepo,
 		cartRepo:       cartRepo,
 		tBuyerRepo:     tBuyerRepo,
+		auditLogger:    newAuditLogger(auditLogRepo),
+		vouchers:       newVoucherApplier(voucherRepo, orderRepo),
+		flashSales:     newFlashSaleReserver(flashSaleRepo, productRepo),
+		notifier:       newWishlistNotifier(wishlistRepo, notificationRepo),
 		contextTimeout: contextTimeout,
 	}
 }
@@ -96,6 +110,20 @@ func (o *orderUsecase) Create(ctx context.Context, input adapter.OrderCreateInpu
 		return []domain.Order{}, errUserCart
 	}
 
+	//buyer has to see and acknowledge product change before checkout
+	cart, cartChanged, err := reconcileCart(ctx, o.productRepo, cart)
+	if err != nil {
+		return []domain.Order{}, err
+	}
+	if cartChanged {
+		if cart, err = o.cartRepo.UpdateOne(ctx, cart); err != nil {
+			return []domain.Order{}, err
+		}
+	}
+	if err := verifyCartAcknowledged(cart, input); err != nil {
+		return []domain.Order{}, err
+	}
+
 	// Invoice
 	transaction := domain.TBuyer{}
 	transaction.ID = guuid.New().String()
@@ -135,7 +163,11 @@ func (o *orderUsecase) Create(ctx context.Context, input adapter.OrderCreateInpu
 						Number: orderData.ReceiverAddress.Number,
 					}
 					order.ShippingCost = orderData.ShippingCost
-					transaction.TotalTransfer += order.ShippingCost
+					order.PaymentMethod = orderData.PaymentMethod
+					if order.PaymentMethod == "" {
+						order.PaymentMethod = domain.PAYMENT_METHOD_TRANSFER
+					}
+					order.Vouchers = []domain.OrderVoucher{}
 
 					order.CreatedAt = time.Now().Truncate(time.Millisecond)
 					order.UpdatedAt = time.Now().Truncate(time.Millisecond)
@@ -144,12 +176,12 @@ func (o *orderUsecase) Create(ctx context.Context, input adapter.OrderCreateInpu
 					for _, product := range orderData.Products {
 						item := domain.OrderItems{}
 						item.Product.ID = product.ProductID
+						item.SKU = product.SKU
 						item.Quantity = product.Quantity
 						item.BuyerNote = product.BuyerNote
 						item.Colors = product.Colors
 						item.Sizes = product.Sizes
 
-						transaction.TotalTransfer += item.Price * item.Quantity
 						order.OrderItems = append(order.OrderItems, item)
 					}
 					chOrder <- order
@@ -198,6 +230,15 @@ func (o *orderUsecase) Create(ctx context.Context, input adapter.OrderCreateInpu
 								},
 							}
 						}
+						//product of suspended merchant can not be bought
+						if merchant.Suspension.IsActive(time.Now()) {
+							err = usecase_error.ErrBadEntityInput{
+								usecase_error.ErrEntityField{
+									Field:   "MerchantID",
+									Message: "Merchant is suspended",
+								},
+							}
+						}
 
 						select {
 						case <-ctx.Done():
@@ -264,6 +305,27 @@ func (o *orderUsecase) Create(ctx context.Context, input adapter.OrderCreateInpu
 										err = usecase_error.ErrNotFound
 									}
 								}
+								//draft, archived, taken down and deleted product could not be ordered
+								if err == nil && !product.IsActive() {
+									err = usecase_error.ErrBadEntityInput{
+										usecase_error.ErrEntityField{
+											Field:   "Product",
+											Message: "Product is not available : " + product.Name,
+										},
+									}
+								}
+								//product with variants is ordered by sku
+								if err == nil && (len(product.Variants) != 0 || item.SKU != "") {
+									snapshot := product.DenormalizationData()
+									if _, ok := snapshot.Variant(item.SKU); !ok {
+										err = usecase_error.ErrBadEntityInput{
+											usecase_error.ErrEntityField{
+												Field:   "SKU",
+												Message: "Variant is not found",
+											},
+										}
+									}
+								}
 
 								select {
 								case <-ctx.Done():
@@ -294,14 +356,41 @@ func (o *orderUsecase) Create(ctx context.Context, input adapter.OrderCreateInpu
 								}
 							}
 
-							//assingn product to order
+							//assingn product to order, same product can be ordered with different sku
 							for i, item := range order.OrderItems {
 								if item.Product.ID == product.ID {
 									order.OrderItems[i].Product = product.DenormalizationData()
-									break
+									order.OrderItems[i].Price = int64(order.OrderItems[i].Product.PriceFor(item.SKU, item.Quantity))
+									//flash sale quota is only reserved when buyer pays flash sale price, cheaper wholesale tier skips it
+									flashSale, ok := order.OrderItems[i].Product.RunningFlashSale(item.SKU, time.Now())
+									if ok && int64(flashSale.Price) == order.OrderItems[i].Price {
+										order.OrderItems[i].FlashSaleID = flashSale.ID
+									}
 								}
 							}
 						}
+
+						//bundle is ordered as its products, so their stock is reserved and merchant ship them
+						orderItems := []domain.OrderItems{}
+						for _, item := range order.OrderItems {
+							if !item.Product.IsBundle() {
+								orderItems = append(orderItems, item)
+								continue
+							}
+							bundleItems, err := expandBundle(ctx, o.productRepo, order.Merchant.ID, item)
+							if err != nil {
+								select {
+								case <-ctx.Done():
+									return
+								default:
+									resultMerchant.Err = err
+									chProducerProduct <- resultMerchant
+									return
+								}
+							}
+							orderItems = append(orderItems, bundleItems...)
+						}
+						resultMerchant.Order.OrderItems = orderItems
 					}
 
 					select {
@@ -321,34 +410,82 @@ func (o *orderUsecase) Create(ctx context.Context, input adapter.OrderCreateInpu
 		return chProducerProduct
 	}
 
+	//apply vouchers after every order has its captured prices, platform voucher is split between orders
+	merchantVoucherCodes := map[string]string{}
+	for _, orderData := range input.Orders {
+		if orderData.VoucherCode != "" {
+			merchantVoucherCodes[orderData.MerchantID] = orderData.VoucherCode
+		}
+	}
+	claimedVouchers := []string{}
+	stageApplyVoucher := func(ctx context.Context, chProduct chan ResultOrder) chan ResultOrder {
+		chVoucher := make(chan ResultOrder)
+		go func() {
+			defer close(chVoucher)
+
+			results := []ResultOrder{}
+			var err error
+			for result := range chProduct {
+				results = append(results, result)
+				if result.Err != nil {
+					err = result.Err
+				}
+			}
+			if err == nil && (input.VoucherCode != "" || len(merchantVoucherCodes) != 0) {
+				orders := []domain.Order{}
+				for _, result := range results {
+					orders = append(orders, result.Order)
+				}
+				orders, claimedVouchers, err = o.vouchers.apply(ctx, customer.ID, input.VoucherCode, merchantVoucherCodes, orders)
+				for i := range results {
+					results[i].Order, results[i].Err = orders[i], err
+				}
+			}
+
+			for _, result := range results {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+					chVoucher <- result
+				}
+			}
+		}()
+		return chVoucher
+	}
+
 	//save order to database
 	type ResultCreateOrder struct {
 		Order domain.Order
 		Err   error
 	}
+	//every result is sent even after cancel, so created order is never lost and failed checkout can roll it back
 	stageCreateOrder := func(ctx context.Context, chProduct chan ResultOrder) chan ResultOrder {
 		chCreateOrder := make(chan ResultOrder)
 		var wgCreateOrder sync.WaitGroup
-		wgCreateOrder.Add(len(input.Orders))
 		go func() {
 			for result := range chProduct {
+				wgCreateOrder.Add(1)
+				if result.Err == nil {
+					result.Err = o.verifyReceiverPhone(customer, result.Order)
+				}
 				// skip procces if process before error
 				if result.Err != nil {
 					wgCreateOrder.Done()
-					select {
-					case <-ctx.Done():
-						break
-					default:
-						chCreateOrder <- result
-					}
+					chCreateOrder <- result
 				} else {
 					for _, item := range result.Order.OrderItems {
 						productID := item.Product.ID
+						sku := item.SKU
+						//cart keep bundle as one item
+						if item.BundleID != "" {
+							productID, sku = item.BundleID, ""
+						}
 
 						//remove oredered item from cart
 						index := -1
 						for i, item := range cart.Items {
-							if item.Product.ID == productID {
+							if item.Product.ID == productID && item.SKU == sku {
 								index = i
 								break
 							}
@@ -376,22 +513,32 @@ func (o *orderUsecase) Create(ctx context.Context, input adapter.OrderCreateInpu
 
 					go func(result ResultOrder) {
 						defer wgCreateOrder.Done()
-						order, err := o.orderRepo.Create(ctx, result.Order)
-						select {
-						case <-ctx.Done():
-							return
-						default:
-							chCreateOrder <- ResultOrder{
-								Order: order,
-								Err:   err,
+						order, err := result.Order, o.reserveStock(ctx, result.Order)
+						if err == nil {
+							err = o.flashSales.reserve(ctx, result.Order)
+							if err != nil {
+								undoCtx, undoCancel := undoContext()
+								o.releaseStock(undoCtx, result.Order.OrderItems)
+								undoCancel()
 							}
 						}
+						if err == nil {
+							order, err = o.orderRepo.Create(ctx, result.Order)
+							if err != nil {
+								undoCtx, undoCancel := undoContext()
+								o.releaseStock(undoCtx, result.Order.OrderItems)
+								o.flashSales.release(undoCtx, customer.ID, result.Order.OrderItems)
+								undoCancel()
+							}
+						}
+						chCreateOrder <- ResultOrder{
+							Order: order,
+							Err:   err,
+						}
 					}(result)
 				}
 
 			}
-		}()
-		go func() {
 			wgCreateOrder.Wait()
 			close(chCreateOrder)
 		}()
@@ -401,16 +548,39 @@ func (o *orderUsecase) Create(ctx context.Context, input adapter.OrderCreateInpu
 	cOrder := pOrder(ctx, input)
 	cMerchant := stageFetchMerchant(ctx, cOrder)
 	cProduct := stageFetchProduct(ctx, cMerchant)
-	cCreateOrder := stageCreateOrder(ctx, cProduct)
+	cVoucher := stageApplyVoucher(ctx, cProduct)
+	cCreateOrder := stageCreateOrder(ctx, cVoucher)
+	//wait every order of checkout, orders already created are rolled back when one of them fail
 	orders := []domain.Order{}
+	var errCreateOrder error
+	received := 0
 	for result := range cCreateOrder {
+		received++
 		if result.Err != nil {
-			cancel()
-			return orders, result.Err
+			if errCreateOrder == nil {
+				errCreateOrder = result.Err
+			}
+			continue
 		}
 
 		orders = append(orders, result.Order)
 	}
+	if errCreateOrder == nil && received != len(input.Orders) {
+		errCreateOrder = usecase_error.ErrInternalServerError
+	}
+	if errCreateOrder != nil {
+		o.rollbackCheckout(customer.ID, orders, claimedVouchers)
+		return []domain.Order{}, errCreateOrder
+	}
+
+	//transaction total is what buyer transfer for every order after discounts
+	for _, order := range orders {
+		transaction.Subtotal += order.Subtotal()
+		transaction.ShippingCost += order.ShippingCost
+		transaction.Discount += order.Discount
+		transaction.ShippingDiscount += order.ShippingDiscount
+		transaction.TotalTransfer += int64(order.Total())
+	}
 
 	//update cart and save transaction
 	var wgCartAndTransaction sync.WaitGroup
@@ -432,6 +602,132 @@ func (o *orderUsecase) Create(ctx context.Context, input adapter.OrderCreateInpu
 	return orders, nil
 }
 
+// undoTimeout bound giving back of stock, quota and orders of failed checkout
+const undoTimeout = 10 * time.Second
+
+// undoContext is used to undo failed checkout, context of checkout may be already canceled or timed out
+func undoContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), undoTimeout)
+}
+
+// rollbackCheckout undo orders already created by failed checkout, order is deleted or canceled when delete fails,
+// then its stock and flash sale quota are given back. Order which can not be removed keeps what it reserved,
+// so claimed voucher is only released when no remaining order carries its discount
+func (o *orderUsecase) rollbackCheckout(customerID string, orders []domain.Order, claimedVouchers []string) {
+	ctx, cancel := undoContext()
+	defer cancel()
+
+	keptVouchers := map[string]bool{}
+	for _, order := range orders {
+		if _, err := o.orderRepo.DeleteOne(ctx, order); err != nil {
+			fmt.Printf("[ORDER USECASE] : ROLLBACK DELETE ORDER %s %#v \n", order.ID, err)
+			order.StatusOrder = domain.STATUS_ORDER_DI_CANCEL
+			if _, err := o.orderRepo.UpdateOne(ctx, order); err != nil {
+				fmt.Printf("[ORDER USECASE] : ROLLBACK CANCEL ORDER %s %#v \n", order.ID, err)
+				for _, voucher := range order.Vouchers {
+					keptVouchers[voucher.VoucherID] = true
+				}
+				continue
+			}
+		}
+		o.releaseStock(ctx, order.OrderItems)
+		o.flashSales.release(ctx, customerID, order.OrderItems)
+	}
+
+	voucherIDs := []string{}
+	for _, voucherID := range claimedVouchers {
+		if !keptVouchers[voucherID] {
+			voucherIDs = append(voucherIDs, voucherID)
+		}
+	}
+	o.vouchers.release(ctx, customerID, voucherIDs)
+}
+
+// reserveStock decrement stock of every ordered product or sku, already reserved item is released when one fail
+func (o *orderUsecase) reserveStock(ctx context.Context, order domain.Order) error {
+	for i, item := range order.OrderItems {
+		_, err := o.productRepo.IncrementStock(ctx, item.Product.ID, item.SKU, -item.Quantity)
+		if err == nil {
+			continue
+		}
+
+		undoCtx, undoCancel := undoContext()
+		o.releaseStock(undoCtx, order.OrderItems[:i])
+		undoCancel()
+		if err == usecase_error.ErrNotFound {
+			return usecase_error.ErrBadEntityInput{
+				usecase_error.ErrEntityField{
+					Field:   "Quantity",
+					Message: "Stock product tidak mencukupi : " + item.Product.Name,
+				},
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// releaseStock give back stock of canceled order items, failure is only logged.
+// customers who wishlist product which is back in stock are notified
+func (o *orderUsecase) releaseStock(ctx context.Context, items []domain.OrderItems) {
+	for _, item := range items {
+		product, err := o.productRepo.IncrementStock(ctx, item.Product.ID, item.SKU, item.Quantity)
+		if err != nil {
+			fmt.Printf("[ORDER USECASE] : RELEASE STOCK %s %s %#v \n", item.Product.ID, item.SKU, err)
+			continue
+		}
+
+		//stock given back could bring sold out product back in stock
+		before := product
+		before.Stock -= float64(item.Quantity)
+		o.notifier.notify(ctx, before, product)
+	}
+}
+
+// verifyCartAcknowledged refuse checkout of cart item that changed and is not acknowledged yet
+func verifyCartAcknowledged(cart domain.Cart, input adapter.OrderCreateInput) error {
+	for _, orderData := range input.Orders {
+		for _, product := range orderData.Products {
+			for _, item := range cart.Items {
+				if item.Product.ID == product.ProductID && item.SKU == product.SKU && item.Updated {
+					return usecase_error.ErrBadEntityInput{
+						usecase_error.ErrEntityField{
+							Field:   "Items",
+							Message: "Cart item has changed, acknowledge the change before checkout : " + item.Message,
+						},
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// verifyReceiverPhone only accept customer own verified phone as receiver of cash on delivery or high value order
+func (o *orderUsecase) verifyReceiverPhone(customer domain.Customer, order domain.Order) error {
+	if order.PaymentMethod != domain.PAYMENT_METHOD_TRANSFER && order.PaymentMethod != domain.PAYMENT_METHOD_COD {
+		return usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "PaymentMethod",
+				Message: "PaymentMethod is not supported",
+			},
+		}
+	}
+	if !order.NeedVerifiedReceiverPhone() {
+		return nil
+	}
+
+	if !customer.PhoneVerified || order.ReceiverPhone != customer.Phone {
+		return usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "ReceiverPhone",
+				Message: "ReceiverPhone must be verified phone for cash on delivery or high value order",
+			},
+		}
+	}
+	return nil
+}
+
 func (o *orderUsecase) GetByID(ctx context.Context, orderID string) (domain.Order, error) {
 	ctx, cancel := context.WithTimeout(ctx, o.contextTimeout)
 	defer cancel()
@@ -460,12 +756,32 @@ func (o *orderUsecase) RejectOrder(ctx context.Context, orderID string) (domain.
 	if err != nil {
 		return order, err
 	}
-	order.StatusOrder = domain.STATUS_ORDER_DI_CANCEL
-	if err := o.validate(order); err != nil {
+	if order.StatusOrder == domain.STATUS_ORDER_DI_CANCEL {
+		return order, nil
+	}
+	before := helper.AuditSnapshot(order)
+
+	//only the request which cancel the order gives back its stock, quota and voucher
+	canceled, err := o.orderRepo.UpdateStatus(ctx, orderID, domain.STATUS_ORDER_CANCELLABLE, domain.STATUS_ORDER_DI_CANCEL)
+	if err == usecase_error.ErrNotFound {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "StatusOrder",
+				Message: "Order is already canceled, shipped or finished",
+			},
+		}
+		return order, err
+	}
+	if err != nil {
 		return order, err
 	}
+	order = canceled
+	o.releaseStock(ctx, order.OrderItems)
+	o.flashSales.release(ctx, order.Customer.ID, order.OrderItems)
+	o.vouchers.releaseCanceledOrder(ctx, order)
 
-	return o.orderRepo.UpdateOne(ctx, order)
+	o.auditLogger.record(ctx, domain.AUDIT_ACTION_ORDER_REJECT, domain.AUDIT_TARGET_ORDER, order.ID, before, order)
+	return order, nil
 }
 
 func (o *orderUsecase) FinishOrder(ctx context.Context, orderID string) (domain.Order, error) {
@@ -491,13 +807,20 @@ func (o *orderUsecase) InputResiNumber(ctx context.Context, input adapter.OrderR
 		return order, err
 	}
 
+	before := helper.AuditSnapshot(order)
 	order.ResiNumber = input.ResiNumber
 	order.StatusOrder = domain.STATUS_ORDER_SEDANG_DIKIRIM
 	if err := o.validate(order); err != nil {
 		return order, err
 	}
 
-	return o.orderRepo.UpdateOne(ctx, order)
+	order, err = o.orderRepo.UpdateOne(ctx, order)
+	if err != nil {
+		return order, err
+	}
+
+	o.auditLogger.record(ctx, domain.AUDIT_ACTION_ORDER_INPUT_RESI, domain.AUDIT_TARGET_ORDER, order.ID, before, order)
+	return order, nil
 }
 
 func (o *orderUsecase) UploadShippingPhoto(ctx context.Context, fileName string, orderID string) (domain.Order, error) {
@@ -0,0 +1,159 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/helper"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+// PhoneVerificationUsecase verify customer phone by sending one time password with sms
+type PhoneVerificationUsecase interface {
+	Send(ctx context.Context, customerID string) (domain.PhoneOTP, error)
+	Verify(ctx context.Context, input adapter.PhoneOTPInput, customerID string) (domain.Customer, error)
+}
+
+type phoneVerificationUsecase struct {
+	customerRepo   repository.CustomerRepository
+	phoneOTPRepo   repository.PhoneOTPRepository
+	smsSender      repository.SMSSender
+	contextTimeout time.Duration
+}
+
+func NewPhoneVerificationUsecase(
+	customerRepo repository.CustomerRepository,
+	phoneOTPRepo repository.PhoneOTPRepository,
+	smsSender repository.SMSSender,
+	contextTimeout time.Duration,
+) PhoneVerificationUsecase {
+	return &phoneVerificationUsecase{
+		customerRepo:   customerRepo,
+		phoneOTPRepo:   phoneOTPRepo,
+		smsSender:      smsSender,
+		contextTimeout: contextTimeout,
+	}
+}
+
+func (p *phoneVerificationUsecase) Send(ctx context.Context, customerID string) (domain.PhoneOTP, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+
+	customer, err := p.customerRepo.GetByID(ctx, customerID)
+	if err != nil {
+		return domain.PhoneOTP{}, err
+	}
+	if customer.PhoneVerified {
+		return domain.PhoneOTP{}, usecase_error.ErrConflict
+	}
+	if customer.Phone == "" {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Phone",
+				Message: "Phone is required",
+			},
+		}
+		return domain.PhoneOTP{}, err
+	}
+
+	previous, err := p.phoneOTPRepo.GetByCustomerID(ctx, customerID)
+	if err != nil && err != usecase_error.ErrNotFound {
+		return domain.PhoneOTP{}, err
+	}
+	if err == nil && time.Since(previous.SentAt) < domain.PHONE_OTP_RESEND_INTERVAL {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Phone",
+				Message: "Code is already sent, wait before request new code",
+			},
+		}
+		return domain.PhoneOTP{}, err
+	}
+
+	code, err := helper.GeneratePhoneOTP()
+	if err != nil {
+		return domain.PhoneOTP{}, err
+	}
+	otp := domain.PhoneOTP{
+		CustomerID: customer.ID,
+		Phone:      customer.Phone,
+		HashedCode: helper.HashPhoneOTP(customer.ID, customer.Phone, code),
+		Attempts:   0,
+		SentAt:     time.Now().Truncate(time.Second),
+	}
+	if err := p.phoneOTPRepo.Save(ctx, otp); err != nil {
+		return domain.PhoneOTP{}, err
+	}
+
+	message := fmt.Sprintf("%s verification code : %s. Do not share this code.", domain.APPLICATION_NAME, code)
+	if err := p.smsSender.Send(ctx, customer.Phone, message); err != nil {
+		fmt.Printf("[PHONE VERIFICATION USECASE] : SEND SMS %#v \n", err)
+		return domain.PhoneOTP{}, usecase_error.ErrInternalServerError
+	}
+
+	return otp, nil
+}
+
+func (p *phoneVerificationUsecase) Verify(ctx context.Context, input adapter.PhoneOTPInput, customerID string) (domain.Customer, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+
+	customer, err := p.customerRepo.GetByID(ctx, customerID)
+	if err != nil {
+		return customer, err
+	}
+
+	errCode := usecase_error.ErrBadEntityInput{
+		usecase_error.ErrEntityField{
+			Field:   "Code",
+			Message: "Code is not valid or expired",
+		},
+	}
+	otp, err := p.phoneOTPRepo.GetByCustomerID(ctx, customerID)
+	if err != nil {
+		if err == usecase_error.ErrNotFound {
+			return customer, errCode
+		}
+		return customer, err
+	}
+
+	attempts, err := p.phoneOTPRepo.IncrementAttempts(ctx, customerID)
+	if err != nil {
+		if err == usecase_error.ErrNotFound {
+			return customer, errCode
+		}
+		return customer, err
+	}
+	if attempts > domain.PHONE_OTP_MAX_ATTEMPTS {
+		//code can not be guessed anymore, customer has to request new one
+		if err := p.phoneOTPRepo.Delete(ctx, customerID); err != nil {
+			return customer, err
+		}
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Code",
+				Message: "Too many wrong code, request new code",
+			},
+		}
+		return customer, err
+	}
+	//phone changed after code is sent
+	if otp.Phone != customer.Phone || !helper.ComparePhoneOTP(otp.HashedCode, customer.ID, customer.Phone, input.Code) {
+		return customer, errCode
+	}
+
+	customer.PhoneVerified = true
+	customer, err = p.customerRepo.UpdateOne(ctx, customer)
+	if err != nil {
+		return customer, err
+	}
+	if err := p.phoneOTPRepo.Delete(ctx, customerID); err != nil {
+		fmt.Printf("[PHONE VERIFICATION USECASE] : DELETE OTP %#v \n", err)
+	}
+
+	return customer, nil
+}
@@ -0,0 +1,322 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	guuid "github.com/google/uuid"
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/helper"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+// ProductImportUsecase create or update merchant products in bulk by merchant sku,
+// import run in background and its progress is read from the job
+type ProductImportUsecase interface {
+	Import(ctx context.Context, rows []adapter.ProductImportRow) (domain.ProductImportJob, error)
+	GetJob(ctx context.Context, jobID string) (domain.ProductImportJob, error)
+	Export(ctx context.Context) ([]domain.Product, error)
+}
+
+type productImportUsecase struct {
+	productRepo    repository.ProductRepository
+	merchantRepo   repository.MerchantRepository
+	jobRepo        repository.ProductImportJobRepository
+	auditLogger    auditLogger
+	notifier       wishlistNotifier
+	contextTimeout time.Duration
+}
+
+func NewProductImportUsecase(
+	productRepo repository.ProductRepository,
+	merchantRepo repository.MerchantRepository,
+	jobRepo repository.ProductImportJobRepository,
+	auditLogRepo repository.AuditLogRepository,
+	wishlistRepo repository.WishlistRepository,
+	notificationRepo repository.NotificationRepository,
+	contextTimeout time.Duration,
+) ProductImportUsecase {
+	return &productImportUsecase{
+		productRepo:    productRepo,
+		merchantRepo:   merchantRepo,
+		jobRepo:        jobRepo,
+		auditLogger:    newAuditLogger(auditLogRepo),
+		notifier:       newWishlistNotifier(wishlistRepo, notificationRepo),
+		contextTimeout: contextTimeout,
+	}
+}
+
+func (p *productImportUsecase) Import(ctx context.Context, rows []adapter.ProductImportRow) (domain.ProductImportJob, error) {
+	credential, ok := ctx.Value("credential").(domain.Credential)
+	if !ok || credential.MerchantID == "" {
+		return domain.ProductImportJob{}, usecase_error.ErrNotAuthorization
+	}
+	if len(rows) == 0 {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "File",
+				Message: "File has no product",
+			},
+		}
+		return domain.ProductImportJob{}, err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+	job, err := p.jobRepo.Create(timeoutCtx, domain.ProductImportJob{
+		MerchantID: credential.MerchantID,
+		Status:     domain.PRODUCT_IMPORT_STATUS_PENDING,
+		TotalRows:  len(rows),
+		Errors:     []domain.ProductImportRowError{},
+	})
+	if err != nil {
+		return job, err
+	}
+
+	//request context is canceled once response is sent, audit values are carried to background context
+	jobCtx := context.WithValue(context.Background(), "credential", credential)
+	jobCtx = context.WithValue(jobCtx, "ip", ctx.Value("ip"))
+	jobCtx = context.WithValue(jobCtx, "request_id", ctx.Value("request_id"))
+	go p.run(jobCtx, job, rows)
+
+	return job, nil
+}
+
+// run never return error, failure is saved in job
+func (p *productImportUsecase) run(ctx context.Context, job domain.ProductImportJob, rows []adapter.ProductImportRow) {
+	job.Status = domain.PRODUCT_IMPORT_STATUS_RUNNING
+	job = p.saveJob(ctx, job)
+
+	skus := []string{}
+	for _, row := range rows {
+		if row.Input.SKU != "" {
+			skus = append(skus, row.Input.SKU)
+		}
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	merchant, err := p.merchantRepo.GetByID(timeoutCtx, job.MerchantID)
+	var existing []domain.Product
+	if err == nil {
+		//row of deleted product sku creates new product, deleted product is never brought back by import
+		notDeleted := false
+		search := domain.ProductSearchOptions{
+			MerchantID: job.MerchantID,
+			SKUs:       skus,
+			Deleted:    &notDeleted,
+		}
+		existing, err = p.productRepo.Fetch(timeoutCtx, "", 0, search)
+	}
+	cancel()
+	if err != nil {
+		fmt.Printf("[PRODUCT IMPORT] : PREPARE JOB %s %#v \n", job.ID, err)
+		job.Status = domain.PRODUCT_IMPORT_STATUS_FAILED
+		job.FinishedAt = time.Now().Truncate(time.Millisecond)
+		p.saveJob(ctx, job)
+		return
+	}
+	productBySKU := map[string]domain.Product{}
+	for _, product := range existing {
+		productBySKU[product.SKU] = product
+	}
+
+	seen := map[string]bool{}
+	updated := map[string]domain.Product{}
+	for _, row := range rows {
+		errs := row.Errors
+		if row.Input.SKU == "" {
+			errs = append(errs, usecase_error.ErrEntityField{Field: "SKU", Message: "SKU is required"})
+		} else if seen[row.Input.SKU] {
+			errs = append(errs, usecase_error.ErrEntityField{Field: "SKU", Message: "SKU is duplicated in file"})
+		}
+		seen[row.Input.SKU] = true
+		if len(errs) == 0 {
+			product, found := productBySKU[row.Input.SKU]
+			rowCtx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+			if found {
+				product, err = p.updateProduct(rowCtx, product, row.Input)
+			} else {
+				product, err = p.createProduct(rowCtx, merchant, row.Input)
+			}
+			cancel()
+
+			if err == nil {
+				if found {
+					job.Updated++
+					updated[product.ID] = product
+				} else {
+					job.Created++
+				}
+				continue
+			}
+			if entityErrs, ok := err.(usecase_error.ErrBadEntityInput); ok {
+				errs = append(errs, entityErrs...)
+			} else {
+				errs = append(errs, usecase_error.ErrEntityField{Field: "Row", Message: err.Error()})
+			}
+		}
+
+		job.Failed++
+		for _, e := range errs {
+			job.Errors = append(job.Errors, domain.ProductImportRowError{
+				Row:     row.Row,
+				SKU:     row.Input.SKU,
+				Field:   e.Field,
+				Message: e.Message,
+			})
+		}
+	}
+
+	//merchant keep copy of its products
+	if len(updated) != 0 {
+		for i, product := range merchant.Products {
+			if updatedProduct, ok := updated[product.ID]; ok {
+				merchant.Products[i] = updatedProduct
+			}
+		}
+		timeoutCtx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+		if _, err := p.merchantRepo.UpdateOne(timeoutCtx, merchant); err != nil {
+			fmt.Printf("[PRODUCT IMPORT] : UPDATE MERCHANT %s %#v \n", job.ID, err)
+		}
+		cancel()
+	}
+
+	job.Status = domain.PRODUCT_IMPORT_STATUS_DONE
+	job.FinishedAt = time.Now().Truncate(time.Millisecond)
+	p.saveJob(ctx, job)
+}
+
+func (p *productImportUsecase) saveJob(ctx context.Context, job domain.ProductImportJob) domain.ProductImportJob {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+
+	savedJob, err := p.jobRepo.UpdateOne(ctx, job)
+	if err != nil {
+		fmt.Printf("[PRODUCT IMPORT] : SAVE JOB %s %#v \n", job.ID, err)
+		return job
+	}
+	return savedJob
+}
+
+func (p *productImportUsecase) createProduct(ctx context.Context, merchant domain.Merchant, input adapter.ProductCreateInput) (domain.Product, error) {
+	now := time.Now().Truncate(time.Millisecond)
+	product := domain.Product{
+		ID:          guuid.New().String(),
+		SKU:         input.SKU,
+		Status:      domain.PRODUCT_STATUS_ACTIVE,
+		Merchant:    merchant.DenomarlizationData(),
+		Name:        input.Name,
+		Weight:      input.Weight,
+		Width:       input.Width,
+		Height:      input.Height,
+		Long:        input.Long,
+		Description: input.Description,
+		Category:    input.Category,
+		Etalase:     input.Etalase,
+		Tags:        input.Tags,
+		Colors:      input.Colors,
+		Sizes:       input.Sizes,
+		Photos:      []string{DEFAULT_PRODUCT_PHOTO},
+		Price:       input.Price,
+		Stock:       input.Stock,
+		Variants:    []domain.Variant{},
+		Attributes:  newAttributes(input.Attributes),
+		Discounts:   []domain.Discount{},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	product.SyncDiscount(now)
+	if err := helper.NewValidationEntity().Validate(product); err != nil {
+		return product, err
+	}
+	if err := helper.ValidateProductAttributes(product.Category, product.Attributes); err != nil {
+		return product, err
+	}
+
+	return p.productRepo.Create(ctx, product)
+}
+
+// updateProduct keep photos and variants, price and stock of product with variants still follow its variants
+func (p *productImportUsecase) updateProduct(ctx context.Context, product domain.Product, input adapter.ProductCreateInput) (domain.Product, error) {
+	if product.DenormalizationData().IsBundle() {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "BundleItems",
+				Message: "Bundle is changed through bundle endpoint",
+			},
+		}
+		return product, err
+	}
+	previous := product
+	before := helper.AuditSnapshot(product)
+
+	product.Name = input.Name
+	product.Weight = input.Weight
+	product.Width = input.Width
+	product.Height = input.Height
+	product.Long = input.Long
+	product.Description = input.Description
+	product.Category = input.Category
+	product.Etalase = input.Etalase
+	product.Tags = input.Tags
+	product.Colors = input.Colors
+	product.Sizes = input.Sizes
+	product.Price = input.Price
+	product.Stock = input.Stock
+	product.Attributes = newAttributes(input.Attributes)
+	product.SyncVariants()
+	product.SyncDiscount(time.Now())
+	if err := helper.NewValidationEntity().Validate(product); err != nil {
+		return product, err
+	}
+	if err := helper.ValidateProductAttributes(product.Category, product.Attributes); err != nil {
+		return product, err
+	}
+	if err := verifyDiscounts(product); err != nil {
+		return product, err
+	}
+	if err := verifyWholesales(product); err != nil {
+		return product, err
+	}
+
+	product, err := p.productRepo.UpdateOne(ctx, product)
+	if err != nil {
+		return product, err
+	}
+	p.auditLogger.record(ctx, domain.AUDIT_ACTION_PRODUCT_UPDATE, domain.AUDIT_TARGET_PRODUCT, product.ID, before, product)
+	p.notifier.notify(ctx, previous, product)
+	return product, nil
+}
+
+func (p *productImportUsecase) GetJob(ctx context.Context, jobID string) (domain.ProductImportJob, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+
+	credential, ok := ctx.Value("credential").(domain.Credential)
+	if !ok {
+		return domain.ProductImportJob{}, usecase_error.ErrNotAuthorization
+	}
+	job, err := p.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return job, err
+	}
+	//other merchant job is hidden
+	if job.MerchantID != credential.MerchantID {
+		return domain.ProductImportJob{}, usecase_error.ErrNotFound
+	}
+	return job, nil
+}
+
+func (p *productImportUsecase) Export(ctx context.Context) ([]domain.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeout)
+	defer cancel()
+
+	credential, ok := ctx.Value("credential").(domain.Credential)
+	if !ok || credential.MerchantID == "" {
+		return nil, usecase_error.ErrNotAuthorization
+	}
+	notDeleted := false
+	return p.productRepo.Fetch(ctx, "", 0, domain.ProductSearchOptions{MerchantID: credential.MerchantID, Deleted: &notDeleted})
+}
@@ -2,6 +2,8 @@ package logic
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -13,13 +15,24 @@ import (
 	"github.com/market-place/usecase/usecase_error"
 )
 
+// DEFAULT_PRODUCT_PHOTO is shown until merchant upload product photos
+const DEFAULT_PRODUCT_PHOTO = "https://storage.googleapis.com/ecommerce_s2l_assets/default-product.jpg"
+
 type ProductUsecase interface {
 	Create(ctx context.Context, input adapter.ProductCreateInput) (domain.Product, error)
 	GetByID(ctx context.Context, productID string) (domain.Product, error)
 	Fetch(ctx context.Context, cursor string, num int64, input adapter.ProductSearchOptions) ([]domain.Product, error)
 	UpdateData(ctx context.Context, input adapter.ProductUpdateInput, productID string) (domain.Product, error)
 	UploadPhotos(ctx context.Context, fileNames []string, productID string) (domain.Product, error)
+	BulkUpdateStockPrice(ctx context.Context, input adapter.ProductBulkUpdateInput) ([]domain.ProductBulkUpdateResult, error)
+	UpdateStatus(ctx context.Context, input adapter.ProductStatusInput, productID string) (domain.Product, error)
+	TakeDown(ctx context.Context, productID string) (domain.Product, error)
+	LiftTakeDown(ctx context.Context, productID string) (domain.Product, error)
 	DeleteOne(ctx context.Context, productID string) (domain.Product, error)
+	Restore(ctx context.Context, productID string) (domain.Product, error)
+	AddDiscount(ctx context.Context, input adapter.ProductDiscountInput, productID string) (domain.Product, error)
+	RemoveDiscount(ctx context.Context, productID string, discountID string) (domain.Product, error)
+	RefreshDiscountedPrices(ctx context.Context) (int, error)
 	ProductTerlaris(ctx context.Context) ([]map[string]interface{}, error)
 }
 
@@ -28,6 +41,8 @@ type productUsecase struct {
 	merchantRepo   repository.MerchantRepository
 	cartRepo       repository.CartRepository
 	orderRepo      repository.OrderRepository
+	auditLogger    auditLogger
+	notifier       wishlistNotifier
 	contextTimeOut time.Duration
 }
 
@@ -36,6 +51,9 @@ func NewProductUsecase(
 	merchantRepo repository.MerchantRepository,
 	cartRepo repository.CartRepository,
 	orderRepo repository.OrderRepository,
+	auditLogRepo repository.AuditLogRepository,
+	wishlistRepo repository.WishlistRepository,
+	notificationRepo repository.NotificationRepository,
 	contextTimeOut time.Duration,
 ) ProductUsecase {
 	return &productUsecase{
@@ -43,6 +61,8 @@ func NewProductUsecase(
 		merchantRepo:   merchantRepo,
 		cartRepo:       cartRepo,
 		orderRepo:      orderRepo,
+		auditLogger:    newAuditLogger(auditLogRepo),
+		notifier:       newWishlistNotifier(wishlistRepo, notificationRepo),
 		contextTimeOut: contextTimeOut,
 	}
 }
@@ -75,7 +95,28 @@ func (p *productUsecase) Create(ctx context.Context, input adapter.ProductCreate
 		return product, err
 	}
 
+	if err := verifyUniqueSKU(ctx, p.productRepo, merchant.ID, "", input.SKU); err != nil {
+		return product, err
+	}
+
+	//merchant can prepare product as draft before selling it
+	status := input.Status
+	if status == "" {
+		status = domain.PRODUCT_STATUS_ACTIVE
+	}
+	if status != domain.PRODUCT_STATUS_DRAFT && status != domain.PRODUCT_STATUS_ACTIVE {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Status",
+				Message: "Status must be DRAFT or ACTIVE",
+			},
+		}
+		return product, err
+	}
+
 	product.ID = guuid.New().String()
+	product.SKU = input.SKU
+	product.Status = status
 	product.Merchant = merchant.DenomarlizationData()
 	product.Name = input.Name
 	product.Weight = input.Weight
@@ -88,15 +129,27 @@ func (p *productUsecase) Create(ctx context.Context, input adapter.ProductCreate
 	product.Tags = input.Tags
 	product.Colors = input.Colors
 	product.Sizes = input.Sizes
-	product.Photos = []string{"https://storage.googleapis.com/ecommerce_s2l_assets/default-product.jpg"}
+	product.Photos = []string{DEFAULT_PRODUCT_PHOTO}
 	product.Price = input.Price
 	product.Stock = input.Stock
+	product.Variants = newVariants(input.Variants)
+	product.Attributes = newAttributes(input.Attributes)
+	product.Discounts = []domain.Discount{}
+	product.Wholesales = newWholesales(input.Wholesales)
+	product.SyncVariants()
+	product.SyncDiscount(time.Now())
 	product.CreatedAt = time.Now().Truncate(time.Millisecond)
 	product.UpdatedAt = time.Now().Truncate(time.Millisecond)
 
 	if entityErr := p.validate(product); entityErr != nil {
 		return product, entityErr
 	}
+	if entityErr := helper.ValidateProductAttributes(product.Category, product.Attributes); entityErr != nil {
+		return product, entityErr
+	}
+	if entityErr := verifyWholesales(product); entityErr != nil {
+		return product, entityErr
+	}
 
 	product, err = p.productRepo.Create(ctx, product)
 	if err != nil {
@@ -106,10 +159,88 @@ func (p *productUsecase) Create(ctx context.Context, input adapter.ProductCreate
 	return product, err
 }
 
+// verifyUniqueSKU merchant sku is key of csv import, so it can only be used by one product of merchant
+func verifyUniqueSKU(ctx context.Context, productRepo repository.ProductRepository, merchantID, productID, sku string) error {
+	if sku == "" {
+		return nil
+	}
+	products, err := productRepo.Fetch(ctx, "", 0, domain.ProductSearchOptions{MerchantID: merchantID, SKUs: []string{sku}})
+	if err != nil {
+		return err
+	}
+	for _, product := range products {
+		if product.ID != productID {
+			return usecase_error.ErrBadEntityInput{
+				usecase_error.ErrEntityField{
+					Field:   "SKU",
+					Message: "SKU is already used by other product",
+				},
+			}
+		}
+	}
+	return nil
+}
+
+// newAttributes drop attribute without value, so optional attribute can be left empty
+func newAttributes(inputs []domain.ProductAttribute) []domain.ProductAttribute {
+	attributes := []domain.ProductAttribute{}
+	for _, attribute := range inputs {
+		if attribute.Value != "" {
+			attributes = append(attributes, attribute)
+		}
+	}
+	return attributes
+}
+
+// newWholesales order wholesale tiers by min quantity, so tiers are checked and shown from smallest quantity
+func newWholesales(inputs []domain.WholesaleTier) []domain.WholesaleTier {
+	wholesales := append([]domain.WholesaleTier{}, inputs...)
+	sort.SliceStable(wholesales, func(i, j int) bool {
+		return wholesales[i].MinQuantity < wholesales[j].MinQuantity
+	})
+	return wholesales
+}
+
+// newVariants generate sku of variant which merchant leave empty
+func newVariants(inputs []domain.Variant) []domain.Variant {
+	variants := []domain.Variant{}
+	for _, variant := range inputs {
+		if variant.SKU == "" {
+			variant.SKU = guuid.New().String()
+		}
+		variants = append(variants, variant)
+	}
+	return variants
+}
+
+// canSeeHiddenProduct is true for owner merchant and admin, buyer only see active and archived products
+func canSeeHiddenProduct(ctx context.Context, merchantID string) bool {
+	credential, ok := ctx.Value("credential").(domain.Credential)
+	if !ok {
+		return false
+	}
+	if credential.LoginType == domain.LOGIN_AS_ADMIN {
+		return true
+	}
+	return credential.MerchantID != "" && credential.MerchantID == merchantID
+}
+
 func (p *productUsecase) GetByID(ctx context.Context, productID string) (domain.Product, error) {
 	ctx, cancel := context.WithTimeout(ctx, p.contextTimeOut)
 	defer cancel()
-	return p.productRepo.GetByID(ctx, productID)
+
+	product, err := p.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return product, err
+	}
+	if !product.IsVisible() && !canSeeHiddenProduct(ctx, product.Merchant.ID) {
+		return domain.Product{}, usecase_error.ErrNotFound
+	}
+	products := []domain.Product{product}
+	if err := syncBundleStocks(ctx, p.productRepo, products); err != nil {
+		return product, err
+	}
+	return products[0], nil
 }
 
 func (p *productUsecase) Fetch(ctx context.Context, cursor string, num int64, input adapter.ProductSearchOptions) ([]domain.Product, error) {
@@ -124,6 +255,16 @@ func (p *productUsecase) Fetch(ctx context.Context, cursor string, num int64, in
 		Price:       input.Price,
 		City:        input.City,
 	}
+	notDeleted := false
+	search.Deleted = &notDeleted
+	if input.MerchantID != "" && canSeeHiddenProduct(ctx, input.MerchantID) {
+		if input.Status != "" {
+			search.Statuses = []string{input.Status}
+		}
+		search.Deleted = &input.Deleted
+	} else {
+		search.Statuses = []string{domain.PRODUCT_STATUS_ACTIVE}
+	}
 
 	products, err := p.productRepo.Fetch(ctx, cursor, num, search)
 	if err != nil {
@@ -140,11 +281,28 @@ func (p *productUsecase) UpdateData(ctx context.Context, input adapter.ProductUp
 	if err != nil {
 		return product, err
 	}
+	//bundle price, stock and products are changed through bundle usecase
+	if product.DenormalizationData().IsBundle() {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "BundleItems",
+				Message: "Bundle is changed through bundle endpoint",
+			},
+		}
+		return product, err
+	}
+	previous := product
+	before := helper.AuditSnapshot(product)
 	merchant, err := p.merchantRepo.GetByID(ctx, product.Merchant.ID)
 	if err != nil {
 		return product, err
 	}
 
+	if err := verifyUniqueSKU(ctx, p.productRepo, merchant.ID, product.ID, input.SKU); err != nil {
+		return product, err
+	}
+
+	product.SKU = input.SKU
 	product.Name = input.Name
 	product.Weight = input.Weight
 	product.Width = input.Width
@@ -158,6 +316,11 @@ func (p *productUsecase) UpdateData(ctx context.Context, input adapter.ProductUp
 	product.Sizes = input.Sizes
 	product.Price = input.Price
 	product.Stock = input.Stock
+	product.Variants = newVariants(input.Variants)
+	product.Attributes = newAttributes(input.Attributes)
+	product.Wholesales = newWholesales(input.Wholesales)
+	product.SyncVariants()
+	product.SyncDiscount(time.Now())
 	product.UpdatedAt = time.Now().Truncate(time.Millisecond)
 
 	index := -1
@@ -174,10 +337,26 @@ func (p *productUsecase) UpdateData(ctx context.Context, input adapter.ProductUp
 	if entityErr := p.validate(product); entityErr != nil {
 		return product, entityErr
 	}
+	if entityErr := helper.ValidateProductAttributes(product.Category, product.Attributes); entityErr != nil {
+		return product, entityErr
+	}
+	if entityErr := verifyDiscounts(product); entityErr != nil {
+		return product, entityErr
+	}
+	if entityErr := verifyWholesales(product); entityErr != nil {
+		return product, entityErr
+	}
 	if merchant, err = p.merchantRepo.UpdateOne(ctx, merchant); err != nil {
 		return product, err
 	}
-	return p.productRepo.UpdateOne(ctx, product)
+	product, err = p.productRepo.UpdateOne(ctx, product)
+	if err != nil {
+		return product, err
+	}
+
+	p.auditLogger.record(ctx, domain.AUDIT_ACTION_PRODUCT_UPDATE, domain.AUDIT_TARGET_PRODUCT, product.ID, before, product)
+	p.notifier.notify(ctx, previous, product)
+	return product, nil
 }
 
 func (p *productUsecase) UploadPhotos(ctx context.Context, fileNames []string, productID string) (domain.Product, error) {
@@ -193,6 +372,460 @@ func (p *productUsecase) UploadPhotos(ctx context.Context, fileNames []string, p
 	return p.productRepo.UpdateOne(ctx, product)
 }
 
+// UpdateStatus is used by merchant to move product between draft, active and archived,
+// product taken down by admin could not be changed by merchant
+func (p *productUsecase) UpdateStatus(ctx context.Context, input adapter.ProductStatusInput, productID string) (domain.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeOut)
+	defer cancel()
+
+	product, err := p.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return product, err
+	}
+
+	message := ""
+	switch {
+	case input.Status != domain.PRODUCT_STATUS_DRAFT && input.Status != domain.PRODUCT_STATUS_ACTIVE && input.Status != domain.PRODUCT_STATUS_ARCHIVED:
+		message = "Status must be DRAFT, ACTIVE or ARCHIVED"
+	case product.Status == domain.PRODUCT_STATUS_TAKEN_DOWN:
+		message = "Product is taken down by admin"
+	case product.DeletedAt != nil:
+		message = "Product is deleted, restore it first"
+	}
+	if message != "" {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Status",
+				Message: message,
+			},
+		}
+		return product, err
+	}
+
+	return p.saveStatus(ctx, product, input.Status, domain.AUDIT_ACTION_PRODUCT_UPDATE_STATUS)
+}
+
+func (p *productUsecase) TakeDown(ctx context.Context, productID string) (domain.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeOut)
+	defer cancel()
+
+	product, err := p.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return product, err
+	}
+
+	return p.saveStatus(ctx, product, domain.PRODUCT_STATUS_TAKEN_DOWN, domain.AUDIT_ACTION_PRODUCT_TAKE_DOWN)
+}
+
+// LiftTakeDown move product back to draft, merchant decide when it is sold again
+func (p *productUsecase) LiftTakeDown(ctx context.Context, productID string) (domain.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeOut)
+	defer cancel()
+
+	product, err := p.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return product, err
+	}
+	if product.Status != domain.PRODUCT_STATUS_TAKEN_DOWN {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Status",
+				Message: "Product is not taken down",
+			},
+		}
+		return product, err
+	}
+
+	return p.saveStatus(ctx, product, domain.PRODUCT_STATUS_DRAFT, domain.AUDIT_ACTION_PRODUCT_LIFT_TAKE_DOWN)
+}
+
+func (p *productUsecase) saveStatus(ctx context.Context, product domain.Product, status string, action string) (domain.Product, error) {
+	before := helper.AuditSnapshot(product)
+	product.Status = status
+	product, err := p.productRepo.UpdateOne(ctx, product)
+	if err != nil {
+		return product, err
+	}
+
+	p.auditLogger.record(ctx, action, domain.AUDIT_TARGET_PRODUCT, product.ID, before, product)
+	return product, nil
+}
+
+// MAX_BULK_UPDATE_ITEMS limit items of one bulk stock and price update request
+const MAX_BULK_UPDATE_ITEMS = 500
+
+func (p *productUsecase) BulkUpdateStockPrice(ctx context.Context, input adapter.ProductBulkUpdateInput) ([]domain.ProductBulkUpdateResult, error) {
+	credential, ok := ctx.Value("credential").(domain.Credential)
+	if !ok || credential.MerchantID == "" {
+		return nil, usecase_error.ErrNotAuthorization
+	}
+	if len(input.Items) == 0 || len(input.Items) > MAX_BULK_UPDATE_ITEMS {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Items",
+				Message: fmt.Sprintf("Items must contain 1 to %d items", MAX_BULK_UPDATE_ITEMS),
+			},
+		}
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeOut)
+	defer cancel()
+
+	productIDs := []string{}
+	for _, item := range input.Items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	notDeleted := false
+	search := domain.ProductSearchOptions{
+		IDs:        productIDs,
+		MerchantID: credential.MerchantID,
+		Deleted:    &notDeleted,
+	}
+	products, err := p.productRepo.Fetch(ctx, "", 0, search)
+	if err != nil {
+		return nil, err
+	}
+	previous := map[string]domain.Product{}
+	for _, product := range products {
+		previous[product.ID] = product
+	}
+
+	//items of same product are applied to one copy, so product is written once
+	results := make([]domain.ProductBulkUpdateResult, len(input.Items))
+	changed := map[string]domain.Product{}
+	changedOrder := []string{}
+	for i, item := range input.Items {
+		results[i] = domain.ProductBulkUpdateResult{
+			ProductID: item.ProductID,
+			SKU:       item.SKU,
+			Status:    domain.PRODUCT_BULK_UPDATE_STATUS_FAILED,
+		}
+
+		product, found := changed[item.ProductID]
+		if !found {
+			product, found = previous[item.ProductID]
+		}
+		product.Variants = append([]domain.Variant{}, product.Variants...)
+		message := ""
+		switch {
+		case !found:
+			message = "Product is not found"
+		case item.Stock == nil && item.Price == nil:
+			message = "Stock or price is required"
+		case item.Stock != nil && *item.Stock < 0:
+			message = "Stock must be greater than or equal 0"
+		case item.Price != nil && *item.Price < 1:
+			message = "Price must be greater than or equal 1"
+		case item.SKU == "" && len(product.Variants) != 0:
+			message = "SKU is required for product with variants"
+		case len(product.BundleItems) != 0:
+			message = "Stock and price of bundle are changed through bundle endpoint"
+		}
+		variantIndex := -1
+		if message == "" && item.SKU != "" {
+			for j, variant := range product.Variants {
+				if variant.SKU == item.SKU {
+					variantIndex = j
+					break
+				}
+			}
+			if variantIndex == -1 {
+				message = "Variant is not found"
+			}
+		}
+		if message != "" {
+			results[i].Message = message
+			continue
+		}
+
+		if variantIndex != -1 {
+			if item.Stock != nil {
+				product.Variants[variantIndex].Stock = *item.Stock
+			}
+			if item.Price != nil {
+				product.Variants[variantIndex].Price = *item.Price
+			}
+		} else {
+			if item.Stock != nil {
+				product.Stock = *item.Stock
+			}
+			if item.Price != nil {
+				product.Price = *item.Price
+			}
+		}
+		product.SyncVariants()
+		if verifyDiscounts(product) != nil {
+			results[i].Message = "Price must be greater than fixed discount of product"
+			continue
+		}
+		if verifyWholesales(product) != nil {
+			results[i].Message = "Price must be greater than wholesale price of product"
+			continue
+		}
+		product.SyncDiscount(time.Now())
+		product.UpdatedAt = time.Now().Truncate(time.Millisecond)
+
+		if _, ok := changed[product.ID]; !ok {
+			changedOrder = append(changedOrder, product.ID)
+		}
+		changed[product.ID] = product
+		results[i].Status = domain.PRODUCT_BULK_UPDATE_STATUS_UPDATED
+	}
+
+	updates := []domain.ProductStockPriceUpdate{}
+	for _, productID := range changedOrder {
+		updates = append(updates, domain.ProductStockPriceUpdate{
+			Previous: previous[productID],
+			Product:  changed[productID],
+		})
+	}
+	errs, err := p.productRepo.BulkUpdateStockPrice(ctx, credential.MerchantID, updates)
+	if err != nil {
+		return nil, err
+	}
+	saved := map[string]domain.Product{}
+	for i, update := range updates {
+		if errs[i] == nil {
+			saved[update.Product.ID] = update.Product
+		}
+	}
+
+	for i, item := range input.Items {
+		if results[i].Status != domain.PRODUCT_BULK_UPDATE_STATUS_UPDATED {
+			continue
+		}
+		product, ok := saved[item.ProductID]
+		if !ok {
+			results[i].Status = domain.PRODUCT_BULK_UPDATE_STATUS_FAILED
+			results[i].Message = "Product could not be saved"
+			continue
+		}
+		denormalization := product.DenormalizationData()
+		results[i].Price = denormalization.PriceOf(item.SKU)
+		results[i].Stock = denormalization.StockOf(item.SKU)
+	}
+	if len(saved) == 0 {
+		return results, nil
+	}
+
+	//merchant keep copy of its products, carts and wishlists refresh their copy when read
+	merchant, err := p.merchantRepo.GetByID(ctx, credential.MerchantID)
+	if err == nil {
+		for i, mProduct := range merchant.Products {
+			if product, ok := saved[mProduct.ID]; ok {
+				merchant.Products[i] = product
+			}
+		}
+		_, err = p.merchantRepo.UpdateOne(ctx, merchant)
+	}
+	if err != nil {
+		fmt.Printf("[PRODUCT USECASE] : BULK UPDATE SYNC MERCHANT %s %#v \n", credential.MerchantID, err)
+	}
+
+	for _, productID := range changedOrder {
+		product, ok := saved[productID]
+		if !ok {
+			continue
+		}
+		before := helper.AuditSnapshot(previous[productID])
+		p.auditLogger.record(ctx, domain.AUDIT_ACTION_PRODUCT_UPDATE, domain.AUDIT_TARGET_PRODUCT, productID, before, product)
+		p.notifier.notify(ctx, previous[productID], product)
+	}
+
+	return results, nil
+}
+
+// verifyDiscounts check fixed discounts are less than product lowest price, so no variant is sold for free
+func verifyDiscounts(product domain.Product) error {
+	for _, discount := range product.Discounts {
+		if discount.Type == domain.DISCOUNT_TYPE_FIXED && discount.Value >= product.Price {
+			err := usecase_error.ErrBadEntityInput{
+				usecase_error.ErrEntityField{
+					Field:   "Discounts",
+					Message: "Fixed discount must be less than lowest price of product",
+				},
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// MAX_WHOLESALE_TIERS limit wholesale tiers of one product
+const MAX_WHOLESALE_TIERS = 5
+
+// verifyWholesales check wholesale tiers are ordered by min quantity with every next tier cheaper,
+// and first tier is cheaper than product lowest price
+func verifyWholesales(product domain.Product) error {
+	message := ""
+	for i, tier := range product.Wholesales {
+		switch {
+		case i == 0 && tier.Price >= product.Price:
+			message = "Wholesale price must be less than lowest price of product"
+		case i > 0 && tier.MinQuantity <= product.Wholesales[i-1].MinQuantity:
+			message = "Min quantity of wholesale tiers must be unique"
+		case i > 0 && tier.Price >= product.Wholesales[i-1].Price:
+			message = "Wholesale price must decrease as min quantity increases"
+		}
+		if message != "" {
+			break
+		}
+	}
+	if len(product.Wholesales) > MAX_WHOLESALE_TIERS {
+		message = fmt.Sprintf("Wholesales must contain at most %d tiers", MAX_WHOLESALE_TIERS)
+	}
+	if message != "" {
+		return usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Wholesales",
+				Message: message,
+			},
+		}
+	}
+	return nil
+}
+
+func (p *productUsecase) AddDiscount(ctx context.Context, input adapter.ProductDiscountInput, productID string) (domain.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeOut)
+	defer cancel()
+
+	product, err := p.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return product, err
+	}
+	if product.DeletedAt != nil {
+		return domain.Product{}, usecase_error.ErrNotFound
+	}
+
+	now := time.Now()
+	discount := domain.Discount{
+		ID:      guuid.New().String(),
+		Type:    input.Type,
+		Value:   input.Value,
+		StartAt: input.StartAt.Truncate(time.Millisecond),
+		EndAt:   input.EndAt.Truncate(time.Millisecond),
+	}
+	if entityErr := p.validate(discount); entityErr != nil {
+		return product, entityErr
+	}
+	message := ""
+	switch {
+	case discount.Type == domain.DISCOUNT_TYPE_PERCENT && discount.Value >= 100:
+		message = "Percent discount must be less than 100"
+	case !discount.EndAt.After(now):
+		message = "Discount must end later than now"
+	}
+	if message != "" {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Discount",
+				Message: message,
+			},
+		}
+		return product, err
+	}
+
+	//ended discounts are dropped, they never change price again
+	discounts := []domain.Discount{}
+	for _, item := range product.Discounts {
+		if item.EndAt.After(now) {
+			discounts = append(discounts, item)
+		}
+	}
+	previous := product
+	product.Discounts = append(discounts, discount)
+	if entityErr := verifyDiscounts(product); entityErr != nil {
+		return product, entityErr
+	}
+
+	return p.saveDiscounts(ctx, previous, product, domain.AUDIT_ACTION_PRODUCT_ADD_DISCOUNT)
+}
+
+func (p *productUsecase) RemoveDiscount(ctx context.Context, productID string, discountID string) (domain.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeOut)
+	defer cancel()
+
+	product, err := p.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return product, err
+	}
+	if product.DeletedAt != nil {
+		return domain.Product{}, usecase_error.ErrNotFound
+	}
+
+	discounts := []domain.Discount{}
+	for _, discount := range product.Discounts {
+		if discount.ID != discountID {
+			discounts = append(discounts, discount)
+		}
+	}
+	if len(discounts) == len(product.Discounts) {
+		return product, usecase_error.ErrNotFound
+	}
+	previous := product
+	product.Discounts = discounts
+
+	return p.saveDiscounts(ctx, previous, product, domain.AUDIT_ACTION_PRODUCT_REMOVE_DISCOUNT)
+}
+
+func (p *productUsecase) saveDiscounts(ctx context.Context, previous domain.Product, product domain.Product, action string) (domain.Product, error) {
+	product.SyncDiscount(time.Now())
+	product, err := p.productRepo.UpdateDiscounts(ctx, product)
+	if err != nil {
+		return product, err
+	}
+
+	//merchant keep copy of its products, carts and wishlists refresh their copy when read
+	merchant, err := p.merchantRepo.GetByID(ctx, product.Merchant.ID)
+	if err == nil {
+		for i, mProduct := range merchant.Products {
+			if mProduct.ID == product.ID {
+				merchant.Products[i] = product
+			}
+		}
+		_, err = p.merchantRepo.UpdateOne(ctx, merchant)
+	}
+	if err != nil {
+		fmt.Printf("[PRODUCT USECASE] : DISCOUNT SYNC MERCHANT %s %#v \n", product.Merchant.ID, err)
+	}
+
+	before := helper.AuditSnapshot(previous)
+	p.auditLogger.record(ctx, action, domain.AUDIT_TARGET_PRODUCT, product.ID, before, product)
+	p.notifier.notify(ctx, previous, product)
+	return product, nil
+}
+
+// RefreshDiscountedPrices store new discounted price of products whose discount started or ended,
+// it is run periodically because nobody update the product when scheduled discount starts or ends
+func (p *productUsecase) RefreshDiscountedPrices(ctx context.Context) (int, error) {
+	now := time.Now()
+	products, err := p.productRepo.Fetch(ctx, "", 0, domain.ProductSearchOptions{PriceChangeBefore: now})
+	if err != nil {
+		return 0, err
+	}
+
+	updates := []domain.Product{}
+	for _, product := range products {
+		product.SyncDiscount(now)
+		updates = append(updates, product)
+	}
+	errs, err := p.productRepo.BulkUpdateDiscountedPrice(ctx, updates, now)
+	if err != nil {
+		return 0, err
+	}
+
+	refreshed := 0
+	for i, product := range updates {
+		if errs[i] != nil {
+			continue
+		}
+		refreshed++
+		p.notifier.notify(ctx, products[i], product)
+	}
+	return refreshed, nil
+}
+
 func (p *productUsecase) DeleteOne(ctx context.Context, productID string) (domain.Product, error) {
 	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
 	defer cancel()
@@ -201,6 +834,11 @@ func (p *productUsecase) DeleteOne(ctx context.Context, productID string) (domai
 		return product, err
 	}
 
+	if product.DeletedAt != nil {
+		return domain.Product{}, usecase_error.ErrNotFound
+	}
+
+	before := helper.AuditSnapshot(product)
 	merchant, err := p.merchantRepo.GetByID(ctx, product.Merchant.ID)
 	if err != nil {
 		return product, err
@@ -227,7 +865,45 @@ func (p *productUsecase) DeleteOne(ctx context.Context, productID string) (domai
 		return product, err
 	}
 
-	return p.productRepo.DeleteOne(ctx, product)
+	//product is only soft deleted, reviews and orders history still refer to it
+	deletedAt := time.Now().Truncate(time.Millisecond)
+	product.DeletedAt = &deletedAt
+	product, err = p.productRepo.UpdateOne(ctx, product)
+	if err != nil {
+		return product, err
+	}
+
+	p.auditLogger.record(ctx, domain.AUDIT_ACTION_PRODUCT_DELETE, domain.AUDIT_TARGET_PRODUCT, product.ID, before, product)
+	return product, nil
+}
+
+func (p *productUsecase) Restore(ctx context.Context, productID string) (domain.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.contextTimeOut)
+	defer cancel()
+
+	product, err := p.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return product, err
+	}
+	if product.DeletedAt == nil {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Product",
+				Message: "Product is not deleted",
+			},
+		}
+		return product, err
+	}
+
+	before := helper.AuditSnapshot(product)
+	product.DeletedAt = nil
+	product, err = p.productRepo.UpdateOne(ctx, product)
+	if err != nil {
+		return product, err
+	}
+
+	p.auditLogger.record(ctx, domain.AUDIT_ACTION_PRODUCT_RESTORE, domain.AUDIT_TARGET_PRODUCT, product.ID, before, product)
+	return product, nil
 }
 
 func (p *productUsecase) ProductTerlaris(ctx context.Context) ([]map[string]interface{}, error) {
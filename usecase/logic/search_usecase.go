@@ -10,7 +10,7 @@ import (
 
 type SearchUsecase interface {
 	SuggestionSearch(ctx context.Context, keyword string) (domain.Search, error)
-	ProductSearch(ctx context.Context, category, secondCategory, thirdCategory string, city string, min, max int64, keyword string, lastDate string) (domain.SearchProduct, error)
+	ProductSearch(ctx context.Context, category, secondCategory, thirdCategory string, city string, min, max int64, keyword string, lastDate string, attributes []domain.ProductAttribute, sort string) (domain.SearchProduct, error)
 	ProductTerlarisSearch(ctx context.Context, page int64)
 	ProductTerpopulerSearch(ctx context.Context, page int64)
 	MerchantProductSearch(ctx context.Context, merchantId string, etalase string, productName string, lastItemDate string, size int64) ([]domain.Product, error)
@@ -37,10 +37,10 @@ func (s *searchUsecase) SuggestionSearch(ctx context.Context, keyword string) (d
 	return s.searchRepo.SuggestionSearch(ctx, keyword)
 }
 
-func (s *searchUsecase) ProductSearch(ctx context.Context, category, secondCategory, thirdCategory string, city string, min, max int64, keyword string, lastDate string) (domain.SearchProduct, error) {
+func (s *searchUsecase) ProductSearch(ctx context.Context, category, secondCategory, thirdCategory string, city string, min, max int64, keyword string, lastDate string, attributes []domain.ProductAttribute, sort string) (domain.SearchProduct, error) {
 	ctx, cancel := context.WithTimeout(ctx, s.contextTimeOut)
 	defer cancel()
-	return s.searchRepo.ProductSearch(ctx, category, secondCategory, thirdCategory, city, min, max, keyword, lastDate)
+	return s.searchRepo.ProductSearch(ctx, category, secondCategory, thirdCategory, city, min, max, keyword, lastDate, attributes, sort)
 }
 
 func (s *searchUsecase) ProductTerlarisSearch(ctx context.Context, page int64) {
@@ -0,0 +1,105 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	guuid "github.com/google/uuid"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type SessionUsecase interface {
+	Fetch(ctx context.Context, cursor string, num int64, customerID, currentSessionID string) ([]domain.Session, error)
+	Revoke(ctx context.Context, sessionID, customerID string) (domain.Session, error)
+	RevokeAll(ctx context.Context, customerID string) error
+}
+
+type sessionUsecase struct {
+	sessionRepo    repository.SessionRepository
+	contextTimeout time.Duration
+}
+
+func NewSessionUsecase(
+	sessionRepo repository.SessionRepository,
+	contextTimeout time.Duration,
+) SessionUsecase {
+	return &sessionUsecase{
+		sessionRepo:    sessionRepo,
+		contextTimeout: contextTimeout,
+	}
+}
+
+func (s *sessionUsecase) Fetch(ctx context.Context, cursor string, num int64, customerID, currentSessionID string) ([]domain.Session, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	search := domain.SessionSearchOptions{
+		UserID:     customerID,
+		LoginType:  domain.LOGIN_AS_CUSTOMER,
+		ActiveOnly: true,
+	}
+	sessions, err := s.sessionRepo.Fetch(ctx, cursor, num, search)
+	if err != nil {
+		return sessions, err
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentSessionID
+	}
+
+	return sessions, nil
+}
+
+func (s *sessionUsecase) Revoke(ctx context.Context, sessionID, customerID string) (domain.Session, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return session, err
+	}
+	if session.UserID != customerID || session.LoginType != domain.LOGIN_AS_CUSTOMER {
+		return session, usecase_error.ErrNotFound
+	}
+	if session.Revoked {
+		return session, nil
+	}
+
+	session.Revoked = true
+	session.RevokedAt = time.Now().Truncate(time.Millisecond)
+	return s.sessionRepo.UpdateOne(ctx, session)
+}
+
+// RevokeAll logout customer from every device, including the session used by the request
+func (s *sessionUsecase) RevokeAll(ctx context.Context, customerID string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	return s.sessionRepo.RevokeAll(ctx, customerID, domain.LOGIN_AS_CUSTOMER, time.Now())
+}
+
+// startLoginSession is shared by every login flow that issue customer or admin token
+func startLoginSession(ctx context.Context, sessionRepo repository.SessionRepository, credential *domain.Credential) error {
+	session := domain.Session{}
+	session.ID = guuid.New().String()
+	session.UserID = credential.UserID
+	session.LoginType = credential.LoginType
+	session.ImpersonatorID = credential.ImpersonatorID
+	if userAgent, ok := ctx.Value("user_agent").(string); ok {
+		session.UserAgent = userAgent
+	}
+	if ip, ok := ctx.Value("ip").(string); ok {
+		session.IP = ip
+	}
+	session.LastActivityAt = time.Now().Truncate(time.Millisecond)
+
+	session, err := sessionRepo.Create(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	credential.Id = session.ID
+	return nil
+}
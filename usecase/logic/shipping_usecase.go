@@ -21,15 +21,18 @@ type ShippingUsecase interface {
 
 type shippingUsecase struct {
 	shippingRepo   repository.ShippingRepository
+	auditLogger    auditLogger
 	contextTimeOut time.Duration
 }
 
 func NewShippingUsecase(
 	shippingRepo repository.ShippingRepository,
+	auditLogRepo repository.AuditLogRepository,
 	contextTimeOut time.Duration,
 ) ShippingUsecase {
 	return &shippingUsecase{
 		shippingRepo:   shippingRepo,
+		auditLogger:    newAuditLogger(auditLogRepo),
 		contextTimeOut: contextTimeOut,
 	}
 }
@@ -81,7 +84,13 @@ func (s *shippingUsecase) Create(ctx context.Context, input adapter.ShippingCrea
 
 	ctx, cancel := context.WithTimeout(ctx, s.contextTimeOut)
 	defer cancel()
-	return s.shippingRepo.Create(ctx, shipping)
+	shipping, err := s.shippingRepo.Create(ctx, shipping)
+	if err != nil {
+		return shipping, err
+	}
+
+	s.auditLogger.record(ctx, domain.AUDIT_ACTION_SHIPPING_CREATE, domain.AUDIT_TARGET_SHIPPING, shipping.ID, nil, shipping)
+	return shipping, nil
 }
 
 func (s *shippingUsecase) GetByID(ctx context.Context, shippingID string) (domain.ShippingProvider, error) {
@@ -112,6 +121,7 @@ func (s *shippingUsecase) UpdateOne(ctx context.Context, input adapter.ShippingU
 	if err != nil {
 		return shipping, err
 	}
+	before := helper.AuditSnapshot(shipping)
 	shipping.Name = input.Name
 
 	if err := s.validate(shipping); err != nil {
@@ -130,7 +140,13 @@ func (s *shippingUsecase) UpdateOne(ctx context.Context, input adapter.ShippingU
 		return shipping, err
 	}
 
-	return s.shippingRepo.UpdateOne(ctx, shipping)
+	shipping, err = s.shippingRepo.UpdateOne(ctx, shipping)
+	if err != nil {
+		return shipping, err
+	}
+
+	s.auditLogger.record(ctx, domain.AUDIT_ACTION_SHIPPING_UPDATE, domain.AUDIT_TARGET_SHIPPING, shipping.ID, before, shipping)
+	return shipping, nil
 }
 
 func (s *shippingUsecase) DeleteOne(ctx context.Context, shippingID string) (domain.ShippingProvider, error) {
@@ -140,5 +156,11 @@ func (s *shippingUsecase) DeleteOne(ctx context.Context, shippingID string) (dom
 	if err != nil {
 		return shipping, err
 	}
-	return s.shippingRepo.DeleteOne(ctx, shipping)
+	shipping, err = s.shippingRepo.DeleteOne(ctx, shipping)
+	if err != nil {
+		return shipping, err
+	}
+
+	s.auditLogger.record(ctx, domain.AUDIT_ACTION_SHIPPING_DELETE, domain.AUDIT_TARGET_SHIPPING, shipping.ID, shipping, nil)
+	return shipping, nil
 }
@@ -0,0 +1,243 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/helper"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+// SuspensionUsecase let admin suspend or ban fraudulent customer and merchant,
+// account owner is notified by sms
+type SuspensionUsecase interface {
+	SuspendCustomer(ctx context.Context, input adapter.SuspensionInput, customerID string) (domain.Customer, error)
+	LiftCustomer(ctx context.Context, customerID string) (domain.Customer, error)
+	SuspendMerchant(ctx context.Context, input adapter.SuspensionInput, merchantID string) (domain.Merchant, error)
+	LiftMerchant(ctx context.Context, merchantID string) (domain.Merchant, error)
+}
+
+type suspensionUsecase struct {
+	customerRepo   repository.CustomerRepository
+	merchantRepo   repository.MerchantRepository
+	productRepo    repository.ProductRepository
+	sessionRepo    repository.SessionRepository
+	smsSender      repository.SMSSender
+	auditLogger    auditLogger
+	contextTimeout time.Duration
+}
+
+func NewSuspensionUsecase(
+	customerRepo repository.CustomerRepository,
+	merchantRepo repository.MerchantRepository,
+	productRepo repository.ProductRepository,
+	sessionRepo repository.SessionRepository,
+	smsSender repository.SMSSender,
+	auditLogRepo repository.AuditLogRepository,
+	contextTimeout time.Duration,
+) SuspensionUsecase {
+	return &suspensionUsecase{
+		customerRepo:   customerRepo,
+		merchantRepo:   merchantRepo,
+		productRepo:    productRepo,
+		sessionRepo:    sessionRepo,
+		smsSender:      smsSender,
+		auditLogger:    newAuditLogger(auditLogRepo),
+		contextTimeout: contextTimeout,
+	}
+}
+
+// verifyNotSuspended is checked when customer login, so suspended customer get clear reason instead of wrong password
+func verifyNotSuspended(suspension domain.Suspension) error {
+	if !suspension.IsActive(time.Now()) {
+		return nil
+	}
+
+	message := "Account is suspended"
+	if suspension.Status == domain.SUSPENSION_STATUS_BANNED {
+		message = "Account is banned"
+	}
+	return usecase_error.ErrLoginField{
+		Field:   "Email",
+		Message: message,
+	}
+}
+
+func (s *suspensionUsecase) newSuspension(ctx context.Context, input adapter.SuspensionInput) (domain.Suspension, error) {
+	admin, ok := ctx.Value("credential").(domain.Credential)
+	if !ok || admin.LoginType != domain.LOGIN_AS_ADMIN {
+		return domain.Suspension{}, usecase_error.ErrNotAuthorization
+	}
+
+	now := time.Now().Truncate(time.Millisecond)
+	errs := usecase_error.ErrBadEntityInput{}
+	if input.Status != domain.SUSPENSION_STATUS_SUSPENDED && input.Status != domain.SUSPENSION_STATUS_BANNED {
+		errs = append(errs, usecase_error.ErrEntityField{
+			Field:   "Status",
+			Message: "Status must be SUSPENDED or BANNED",
+		})
+	}
+	if input.Reason == "" {
+		errs = append(errs, usecase_error.ErrEntityField{
+			Field:   "Reason",
+			Message: "Reason is required",
+		})
+	}
+	if input.Status == domain.SUSPENSION_STATUS_BANNED && !input.Until.IsZero() {
+		errs = append(errs, usecase_error.ErrEntityField{
+			Field:   "Until",
+			Message: "Ban has no end date",
+		})
+	}
+	if !input.Until.IsZero() && !input.Until.After(now) {
+		errs = append(errs, usecase_error.ErrEntityField{
+			Field:   "Until",
+			Message: "Until must be in the future",
+		})
+	}
+	if len(errs) != 0 {
+		return domain.Suspension{}, errs
+	}
+
+	until := input.Until.Truncate(time.Millisecond)
+	if until.IsZero() {
+		until = domain.SUSPENSION_INDEFINITE_UNTIL
+	}
+	return domain.Suspension{
+		Status:    input.Status,
+		Reason:    input.Reason,
+		Until:     until,
+		AdminID:   admin.UserID,
+		CreatedAt: now,
+	}, nil
+}
+
+func (s *suspensionUsecase) SuspendCustomer(ctx context.Context, input adapter.SuspensionInput, customerID string) (domain.Customer, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	suspension, err := s.newSuspension(ctx, input)
+	if err != nil {
+		return domain.Customer{}, err
+	}
+	customer, err := s.customerRepo.GetByID(ctx, customerID)
+	if err != nil {
+		return customer, err
+	}
+
+	before := helper.AuditSnapshot(customer)
+	customer, err = s.customerRepo.UpdateSuspension(ctx, customer.ID, suspension)
+	if err != nil {
+		return customer, err
+	}
+	//token already issued is refused by session check
+	if err := s.sessionRepo.RevokeAll(ctx, customer.ID, domain.LOGIN_AS_CUSTOMER, time.Now()); err != nil {
+		return customer, err
+	}
+	s.auditLogger.record(ctx, domain.AUDIT_ACTION_CUSTOMER_SUSPEND, domain.AUDIT_TARGET_CUSTOMER, customer.ID, before, customer)
+
+	s.notify(ctx, customer.Phone, suspensionMessage("Your account", suspension))
+	return customer, nil
+}
+
+func (s *suspensionUsecase) LiftCustomer(ctx context.Context, customerID string) (domain.Customer, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	customer, err := s.customerRepo.GetByID(ctx, customerID)
+	if err != nil {
+		return customer, err
+	}
+	if customer.Suspension.Status == "" {
+		return customer, usecase_error.ErrNotFound
+	}
+
+	before := helper.AuditSnapshot(customer)
+	customer, err = s.customerRepo.UpdateSuspension(ctx, customer.ID, domain.Suspension{})
+	if err != nil {
+		return customer, err
+	}
+	s.auditLogger.record(ctx, domain.AUDIT_ACTION_CUSTOMER_UNSUSPEND, domain.AUDIT_TARGET_CUSTOMER, customer.ID, before, customer)
+
+	s.notify(ctx, customer.Phone, fmt.Sprintf("%s : Your account is active again.", domain.APPLICATION_NAME))
+	return customer, nil
+}
+
+func (s *suspensionUsecase) SuspendMerchant(ctx context.Context, input adapter.SuspensionInput, merchantID string) (domain.Merchant, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	suspension, err := s.newSuspension(ctx, input)
+	if err != nil {
+		return domain.Merchant{}, err
+	}
+	merchant, err := s.merchantRepo.GetByID(ctx, merchantID)
+	if err != nil {
+		return merchant, err
+	}
+
+	before := helper.AuditSnapshot(merchant)
+	merchant, err = s.merchantRepo.UpdateSuspension(ctx, merchant.ID, suspension)
+	if err != nil {
+		return merchant, err
+	}
+	//products are hidden from search once index is synced
+	if err := s.productRepo.UpdateMerchantSuspension(ctx, merchant.ID, suspension.Until); err != nil {
+		return merchant, err
+	}
+	s.auditLogger.record(ctx, domain.AUDIT_ACTION_MERCHANT_SUSPEND, domain.AUDIT_TARGET_MERCHANT, merchant.ID, before, merchant)
+
+	s.notify(ctx, merchant.Phone, suspensionMessage("Your merchant "+merchant.Name, suspension))
+	return merchant, nil
+}
+
+func (s *suspensionUsecase) LiftMerchant(ctx context.Context, merchantID string) (domain.Merchant, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	merchant, err := s.merchantRepo.GetByID(ctx, merchantID)
+	if err != nil {
+		return merchant, err
+	}
+	if merchant.Suspension.Status == "" {
+		return merchant, usecase_error.ErrNotFound
+	}
+
+	before := helper.AuditSnapshot(merchant)
+	merchant, err = s.merchantRepo.UpdateSuspension(ctx, merchant.ID, domain.Suspension{})
+	if err != nil {
+		return merchant, err
+	}
+	if err := s.productRepo.UpdateMerchantSuspension(ctx, merchant.ID, time.Time{}); err != nil {
+		return merchant, err
+	}
+	s.auditLogger.record(ctx, domain.AUDIT_ACTION_MERCHANT_UNSUSPEND, domain.AUDIT_TARGET_MERCHANT, merchant.ID, before, merchant)
+
+	s.notify(ctx, merchant.Phone, fmt.Sprintf("%s : Your merchant %s is active again.", domain.APPLICATION_NAME, merchant.Name))
+	return merchant, nil
+}
+
+// notify failure is only logged, suspension is already saved
+func (s *suspensionUsecase) notify(ctx context.Context, phone, message string) {
+	if phone == "" {
+		return
+	}
+	if err := s.smsSender.Send(ctx, phone, message); err != nil {
+		fmt.Printf("[SUSPENSION USECASE] : SEND SMS %#v \n", err)
+	}
+}
+
+func suspensionMessage(subject string, suspension domain.Suspension) string {
+	state := "is suspended"
+	switch {
+	case suspension.Status == domain.SUSPENSION_STATUS_BANNED:
+		state = "is banned"
+	case !suspension.Until.Equal(domain.SUSPENSION_INDEFINITE_UNTIL):
+		state = "is suspended until " + suspension.Until.Format("2006-01-02 15:04")
+	}
+	return fmt.Sprintf("%s : %s %s. Reason : %s", domain.APPLICATION_NAME, subject, state, suspension.Reason)
+}
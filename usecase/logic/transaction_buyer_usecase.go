@@ -6,6 +6,7 @@ import (
 
 	"github.com/market-place/domain"
 	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/helper"
 	"github.com/market-place/usecase/repository"
 	"github.com/market-place/usecase/usecase_error"
 	"golang.org/x/sync/errgroup"
@@ -23,17 +24,20 @@ type TBuyerUsecase interface {
 type tbuyerUsecase struct {
 	tbuyerRepo     repository.TBuyerRepository
 	orderRepo      repository.OrderRepository
+	auditLogger    auditLogger
 	contextTimeout time.Duration
 }
 
 func NewTBuyerUsecase(
 	tbuyerRepo repository.TBuyerRepository,
 	orderRepo repository.OrderRepository,
+	auditLogRepo repository.AuditLogRepository,
 	contextTimeOut time.Duration,
 ) TBuyerUsecase {
 	return &tbuyerUsecase{
 		tbuyerRepo:     tbuyerRepo,
 		orderRepo:      orderRepo,
+		auditLogger:    newAuditLogger(auditLogRepo),
 		contextTimeout: contextTimeOut,
 	}
 }
@@ -84,6 +88,7 @@ func (t *tbuyerUsecase) AcceptTransaction(ctx context.Context, transactionID str
 		return tbuyer, err
 	}
 
+	before := helper.AuditSnapshot(tbuyer)
 	tbuyer.PaymentStatus = domain.PEMBAYARAN_SUCCESS
 	tbuyer.AdminID = userInfo.UserID
 
@@ -111,7 +116,13 @@ func (t *tbuyerUsecase) AcceptTransaction(ctx context.Context, transactionID str
 		}
 	}
 
-	return t.tbuyerRepo.UpdateOne(ctx, tbuyer)
+	tbuyer, err = t.tbuyerRepo.UpdateOne(ctx, tbuyer)
+	if err != nil {
+		return tbuyer, err
+	}
+
+	t.auditLogger.record(ctx, domain.AUDIT_ACTION_TRANSACTION_ACCEPT, domain.AUDIT_TARGET_TRANSACTION, tbuyer.ID, before, tbuyer)
+	return tbuyer, nil
 }
 
 func (t *tbuyerUsecase) RejectTransaction(ctx context.Context, input adapter.TbuyerRejectInput, transactionID string) (domain.TBuyer, error) {
@@ -122,8 +133,15 @@ func (t *tbuyerUsecase) RejectTransaction(ctx context.Context, input adapter.Tbu
 	if err != nil {
 		return tbuyer, err
 	}
+	before := helper.AuditSnapshot(tbuyer)
 	tbuyer.Message = input.Message
 	tbuyer.PaymentStatus = domain.PEMBAYARAN_GAGAL
 
-	return t.tbuyerRepo.UpdateOne(ctx, tbuyer)
+	tbuyer, err = t.tbuyerRepo.UpdateOne(ctx, tbuyer)
+	if err != nil {
+		return tbuyer, err
+	}
+
+	t.auditLogger.record(ctx, domain.AUDIT_ACTION_TRANSACTION_REJECT, domain.AUDIT_TARGET_TRANSACTION, tbuyer.ID, before, tbuyer)
+	return tbuyer, nil
 }
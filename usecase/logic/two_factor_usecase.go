@@ -0,0 +1,224 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/helper"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+// TwoFactorUsecase handle optional two factor enrollment from customer profile (merchant owner).
+// Admin enrollment is mandatory and done in the login challenge, see AuthenticationUsecase.
+type TwoFactorUsecase interface {
+	Enroll(ctx context.Context, customerID string) (domain.TwoFactorEnrollment, error)
+	Confirm(ctx context.Context, input adapter.TwoFactorCodeInput, customerID string) ([]string, error)
+	Disable(ctx context.Context, input adapter.TwoFactorCodeInput, customerID string) (domain.Customer, error)
+}
+
+type twoFactorUsecase struct {
+	customerRepo   repository.CustomerRepository
+	twoFactorLimit twoFactorLimiter
+	contextTimeout time.Duration
+}
+
+func NewTwoFactorUsecase(
+	customerRepo repository.CustomerRepository,
+	twoFactorAttemptRepo repository.TwoFactorAttemptRepository,
+	contextTimeout time.Duration,
+) TwoFactorUsecase {
+	return &twoFactorUsecase{
+		customerRepo:   customerRepo,
+		twoFactorLimit: newTwoFactorLimiter(twoFactorAttemptRepo),
+		contextTimeout: contextTimeout,
+	}
+}
+
+func (t *twoFactorUsecase) Enroll(ctx context.Context, customerID string) (domain.TwoFactorEnrollment, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.contextTimeout)
+	defer cancel()
+	customer, err := t.customerRepo.GetByID(ctx, customerID)
+	if err != nil {
+		return domain.TwoFactorEnrollment{}, err
+	}
+	if customer.TwoFactor.Enabled {
+		return domain.TwoFactorEnrollment{}, usecase_error.ErrConflict
+	}
+
+	enrollment, err := startTwoFactorEnrollment(&customer.TwoFactor, customer.Email)
+	if err != nil {
+		return enrollment, err
+	}
+	if _, err := t.customerRepo.UpdateOne(ctx, customer); err != nil {
+		return enrollment, err
+	}
+
+	return enrollment, nil
+}
+
+func (t *twoFactorUsecase) Confirm(ctx context.Context, input adapter.TwoFactorCodeInput, customerID string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.contextTimeout)
+	defer cancel()
+	customer, err := t.customerRepo.GetByID(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	if customer.TwoFactor.Enabled {
+		return nil, usecase_error.ErrConflict
+	}
+
+	recoveryCodes, err := confirmTwoFactor(&customer.TwoFactor, input.Code)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := t.customerRepo.UpdateOne(ctx, customer); err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+func (t *twoFactorUsecase) Disable(ctx context.Context, input adapter.TwoFactorCodeInput, customerID string) (domain.Customer, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.contextTimeout)
+	defer cancel()
+	customer, err := t.customerRepo.GetByID(ctx, customerID)
+	if err != nil {
+		return customer, err
+	}
+	if !customer.TwoFactor.Enabled {
+		return customer, usecase_error.ErrNotFound
+	}
+
+	err = t.twoFactorLimit.check(ctx, domain.LOGIN_AS_CUSTOMER, customer.ID, func() error {
+		return verifyTwoFactor(&customer.TwoFactor, input.Code, input.RecoveryCode)
+	})
+	if err != nil {
+		return customer, err
+	}
+	customer.TwoFactor = domain.TwoFactor{}
+
+	return t.customerRepo.UpdateOne(ctx, customer)
+}
+
+// twoFactorLimiter is embedded by usecases verifying second factor code,
+// wrong codes are counted per user and user is locked out after too many of them
+type twoFactorLimiter struct {
+	attemptRepo repository.TwoFactorAttemptRepository
+}
+
+func newTwoFactorLimiter(attemptRepo repository.TwoFactorAttemptRepository) twoFactorLimiter {
+	return twoFactorLimiter{
+		attemptRepo: attemptRepo,
+	}
+}
+
+// check count the attempt before running verify, so parallel guesses are limited too.
+// Attempts are reset once a code is accepted
+func (t twoFactorLimiter) check(ctx context.Context, loginType, userID string, verify func() error) error {
+	attempts, err := t.attemptRepo.Increment(ctx, loginType, userID)
+	if err != nil {
+		return err
+	}
+	if attempts > domain.TWO_FACTOR_MAX_ATTEMPTS {
+		fmt.Printf("[TWO FACTOR] : CHECK %#v \n", "TOO MANY ATTEMPTS")
+		return usecase_error.ErrLoginField{
+			Field:   "Code",
+			Message: "Too many wrong code, try again later",
+		}
+	}
+
+	if err := verify(); err != nil {
+		return err
+	}
+	if err := t.attemptRepo.Reset(ctx, loginType, userID); err != nil {
+		fmt.Printf("[TWO FACTOR] : RESET ATTEMPTS %#v \n", err)
+	}
+	return nil
+}
+
+// startTwoFactorEnrollment generate new pending secret, it is activated after the first code is confirmed
+func startTwoFactorEnrollment(twoFactor *domain.TwoFactor, accountName string) (domain.TwoFactorEnrollment, error) {
+	totp := helper.NewTOTP()
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return domain.TwoFactorEnrollment{}, err
+	}
+	twoFactor.PendingSecret = secret
+
+	return domain.TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(secret, accountName),
+	}, nil
+}
+
+// confirmTwoFactor activate pending secret and return plain recovery codes, only hashed codes are saved
+func confirmTwoFactor(twoFactor *domain.TwoFactor, code string) ([]string, error) {
+	if twoFactor.PendingSecret == "" {
+		fmt.Printf("[TWO FACTOR] : CONFIRM %#v \n", "NO PENDING ENROLLMENT")
+		return nil, usecase_error.ErrNotFound
+	}
+
+	totp := helper.NewTOTP()
+	step, isValid := totp.Validate(twoFactor.PendingSecret, code, time.Now())
+	if !isValid {
+		fmt.Printf("[TWO FACTOR] : CONFIRM %#v \n", "CODE NOT MATCH")
+		return nil, usecase_error.ErrLoginField{
+			Field:   "Code",
+			Message: "Code is wrong",
+		}
+	}
+
+	recoveryCodes, err := totp.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	hashedCodes := []string{}
+	for _, recoveryCode := range recoveryCodes {
+		hashedCode, err := helper.NewEncription().Encrypt([]byte(recoveryCode))
+		if err != nil {
+			return nil, err
+		}
+		hashedCodes = append(hashedCodes, hashedCode)
+	}
+
+	twoFactor.Enabled = true
+	twoFactor.Secret = twoFactor.PendingSecret
+	twoFactor.PendingSecret = ""
+	twoFactor.RecoveryCodes = hashedCodes
+	twoFactor.LastUsedStep = step
+
+	return recoveryCodes, nil
+}
+
+// verifyTwoFactor accept totp code or one of recovery codes, used recovery code is removed
+func verifyTwoFactor(twoFactor *domain.TwoFactor, code, recoveryCode string) error {
+	if recoveryCode != "" {
+		for i, hashedCode := range twoFactor.RecoveryCodes {
+			if helper.NewEncription().Compare([]byte(hashedCode), []byte(recoveryCode)) {
+				twoFactor.RecoveryCodes = append(twoFactor.RecoveryCodes[:i], twoFactor.RecoveryCodes[i+1:]...)
+				return nil
+			}
+		}
+		fmt.Printf("[TWO FACTOR] : VERIFY %#v \n", "RECOVERY CODE NOT MATCH")
+		return usecase_error.ErrLoginField{
+			Field:   "RecoveryCode",
+			Message: "Recovery code is wrong",
+		}
+	}
+
+	step, isValid := helper.NewTOTP().Validate(twoFactor.Secret, code, time.Now())
+	if !isValid || step <= twoFactor.LastUsedStep {
+		fmt.Printf("[TWO FACTOR] : VERIFY %#v \n", "CODE NOT MATCH OR REUSED")
+		return usecase_error.ErrLoginField{
+			Field:   "Code",
+			Message: "Code is wrong",
+		}
+	}
+	twoFactor.LastUsedStep = step
+
+	return nil
+}
@@ -0,0 +1,490 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	guuid "github.com/google/uuid"
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/helper"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type VoucherUsecase interface {
+	Create(ctx context.Context, input adapter.VoucherCreateInput) (domain.Voucher, error)
+	Fetch(ctx context.Context, options adapter.VoucherSearchOptions) ([]domain.Voucher, error)
+	GetByID(ctx context.Context, voucherID string) (domain.Voucher, error)
+	UpdateOne(ctx context.Context, input adapter.VoucherUpdateInput, voucherID string) (domain.Voucher, error)
+	DeleteOne(ctx context.Context, voucherID string) (domain.Voucher, error)
+}
+
+type voucherUsecase struct {
+	voucherRepo    repository.VoucherRepository
+	auditLogger    auditLogger
+	contextTimeout time.Duration
+}
+
+func NewVoucherUsecase(
+	voucherRepo repository.VoucherRepository,
+	auditLogRepo repository.AuditLogRepository,
+	contextTimeout time.Duration,
+) VoucherUsecase {
+	return &voucherUsecase{
+		voucherRepo:    voucherRepo,
+		auditLogger:    newAuditLogger(auditLogRepo),
+		contextTimeout: contextTimeout,
+	}
+}
+
+func (v *voucherUsecase) validate(value interface{}) error {
+	if entityErr := helper.NewValidationEntity().Validate(value); entityErr != nil {
+		return entityErr
+	}
+
+	return nil
+}
+
+// voucherScope is merchant id of vouchers managed by credential, admin manage platform vouchers which have empty merchant id
+func voucherScope(ctx context.Context) (string, bool) {
+	credential, ok := ctx.Value("credential").(domain.Credential)
+	if !ok {
+		return "", false
+	}
+	if credential.LoginType == domain.LOGIN_AS_ADMIN {
+		return "", true
+	}
+	return credential.MerchantID, credential.MerchantID != ""
+}
+
+func normalizeVoucherCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// verifyVoucher code is unique between every voucher, because buyer only input the code at checkout
+func (v *voucherUsecase) verifyVoucher(ctx context.Context, voucher domain.Voucher) error {
+	message, field := "", ""
+	switch {
+	case voucher.Type == domain.VOUCHER_TYPE_FIXED && voucher.Value < 1:
+		field, message = "Value", "Value of fixed voucher must be greater than or equal 1"
+	case voucher.QuotaPerCustomer > voucher.Quota:
+		field, message = "QuotaPerCustomer", "QuotaPerCustomer must be less than or equal Quota"
+	case voucher.Quota < voucher.Used:
+		field, message = "Quota", fmt.Sprintf("Quota must be greater than or equal used quota %d", voucher.Used)
+	case voucher.MerchantID != "" && len(voucher.MerchantIDs) != 0:
+		field, message = "MerchantIDs", "Merchant voucher could not be scoped to other merchants"
+	}
+	for _, category := range voucher.Categories {
+		if message == "" && !helper.IsRegisteredCategory(category) {
+			field, message = "Categories", "Category is not registered : "+category.Top
+		}
+	}
+	if message != "" {
+		return usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   field,
+				Message: message,
+			},
+		}
+	}
+
+	vouchers, err := v.voucherRepo.Fetch(ctx, domain.VoucherSearchOptions{Code: voucher.Code})
+	if err != nil {
+		return err
+	}
+	for _, item := range vouchers {
+		if item.ID != voucher.ID {
+			return voucherCodeNotUnique()
+		}
+	}
+	return nil
+}
+
+func (v *voucherUsecase) Create(ctx context.Context, input adapter.VoucherCreateInput) (domain.Voucher, error) {
+	merchantID, ok := voucherScope(ctx)
+	if !ok {
+		return domain.Voucher{}, usecase_error.ErrNotAuthorization
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, v.contextTimeout)
+	defer cancel()
+
+	voucher := domain.Voucher{
+		ID:               guuid.New().String(),
+		Code:             normalizeVoucherCode(input.Code),
+		MerchantID:       merchantID,
+		Type:             input.Type,
+		Value:            input.Value,
+		MaxDiscount:      input.MaxDiscount,
+		MinSpend:         input.MinSpend,
+		Categories:       input.Categories,
+		MerchantIDs:      input.MerchantIDs,
+		NewCustomerOnly:  input.NewCustomerOnly,
+		Quota:            input.Quota,
+		QuotaPerCustomer: input.QuotaPerCustomer,
+		Usages:           map[string]int64{},
+		StartAt:          input.StartAt.Truncate(time.Millisecond),
+		EndAt:            input.EndAt.Truncate(time.Millisecond),
+	}
+	if voucher.QuotaPerCustomer == 0 {
+		voucher.QuotaPerCustomer = 1
+	}
+	if entityErr := v.validate(voucher); entityErr != nil {
+		return voucher, entityErr
+	}
+	if err := v.verifyVoucher(ctx, voucher); err != nil {
+		return voucher, err
+	}
+
+	//code taken by concurrent create is rejected by unique index of code
+	voucher, err := v.voucherRepo.Create(ctx, voucher)
+	if err == usecase_error.ErrConflict {
+		return voucher, voucherCodeNotUnique()
+	}
+	if err != nil {
+		return voucher, err
+	}
+
+	v.auditLogger.record(ctx, domain.AUDIT_ACTION_VOUCHER_CREATE, domain.AUDIT_TARGET_VOUCHER, voucher.ID, nil, voucher)
+	return voucher, nil
+}
+
+// Fetch return every voucher to its manager, buyer only see running vouchers
+func (v *voucherUsecase) Fetch(ctx context.Context, options adapter.VoucherSearchOptions) ([]domain.Voucher, error) {
+	ctx, cancel := context.WithTimeout(ctx, v.contextTimeout)
+	defer cancel()
+
+	search := domain.VoucherSearchOptions{
+		MerchantID: &options.MerchantID,
+		Code:       normalizeVoucherCode(options.Code),
+	}
+	if merchantID, ok := voucherScope(ctx); !ok || merchantID != options.MerchantID {
+		search.RunningAt = time.Now()
+	}
+
+	return v.voucherRepo.Fetch(ctx, search)
+}
+
+func (v *voucherUsecase) GetByID(ctx context.Context, voucherID string) (domain.Voucher, error) {
+	ctx, cancel := context.WithTimeout(ctx, v.contextTimeout)
+	defer cancel()
+
+	return v.voucherRepo.GetByID(ctx, voucherID)
+}
+
+// getManagedVoucher refuse voucher of other merchant, and platform voucher for merchant
+func (v *voucherUsecase) getManagedVoucher(ctx context.Context, voucherID string) (domain.Voucher, error) {
+	merchantID, ok := voucherScope(ctx)
+	if !ok {
+		return domain.Voucher{}, usecase_error.ErrNotAuthorization
+	}
+	voucher, err := v.voucherRepo.GetByID(ctx, voucherID)
+	if err != nil {
+		return voucher, err
+	}
+	if voucher.MerchantID != merchantID {
+		return domain.Voucher{}, usecase_error.ErrNotAuthorization
+	}
+	return voucher, nil
+}
+
+func (v *voucherUsecase) UpdateOne(ctx context.Context, input adapter.VoucherUpdateInput, voucherID string) (domain.Voucher, error) {
+	ctx, cancel := context.WithTimeout(ctx, v.contextTimeout)
+	defer cancel()
+
+	voucher, err := v.getManagedVoucher(ctx, voucherID)
+	if err != nil {
+		return voucher, err
+	}
+	before := helper.AuditSnapshot(voucher)
+
+	voucher.Code = normalizeVoucherCode(input.Code)
+	voucher.Type = input.Type
+	voucher.Value = input.Value
+	voucher.MaxDiscount = input.MaxDiscount
+	voucher.MinSpend = input.MinSpend
+	voucher.Categories = input.Categories
+	voucher.MerchantIDs = input.MerchantIDs
+	voucher.NewCustomerOnly = input.NewCustomerOnly
+	voucher.Quota = input.Quota
+	voucher.QuotaPerCustomer = input.QuotaPerCustomer
+	voucher.StartAt = input.StartAt.Truncate(time.Millisecond)
+	voucher.EndAt = input.EndAt.Truncate(time.Millisecond)
+	if voucher.QuotaPerCustomer == 0 {
+		voucher.QuotaPerCustomer = 1
+	}
+	if entityErr := v.validate(voucher); entityErr != nil {
+		return voucher, entityErr
+	}
+	if err := v.verifyVoucher(ctx, voucher); err != nil {
+		return voucher, err
+	}
+
+	voucher, err = v.voucherRepo.UpdateOne(ctx, voucher)
+	if err == usecase_error.ErrConflict {
+		return voucher, voucherCodeNotUnique()
+	}
+	if err != nil {
+		return voucher, err
+	}
+
+	v.auditLogger.record(ctx, domain.AUDIT_ACTION_VOUCHER_UPDATE, domain.AUDIT_TARGET_VOUCHER, voucher.ID, before, voucher)
+	return voucher, nil
+}
+
+// DeleteOne only delete unused voucher, used voucher is stopped by changing its end at
+func (v *voucherUsecase) DeleteOne(ctx context.Context, voucherID string) (domain.Voucher, error) {
+	ctx, cancel := context.WithTimeout(ctx, v.contextTimeout)
+	defer cancel()
+
+	voucher, err := v.getManagedVoucher(ctx, voucherID)
+	if err != nil {
+		return voucher, err
+	}
+	if voucher.Used > 0 {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "Used",
+				Message: "Voucher is already used, change its end at to stop it",
+			},
+		}
+		return voucher, err
+	}
+
+	before := helper.AuditSnapshot(voucher)
+	voucher, err = v.voucherRepo.DeleteOne(ctx, voucher)
+	if err != nil {
+		return voucher, err
+	}
+
+	v.auditLogger.record(ctx, domain.AUDIT_ACTION_VOUCHER_DELETE, domain.AUDIT_TARGET_VOUCHER, voucher.ID, before, nil)
+	return voucher, nil
+}
+
+// voucherApplier is embedded by order usecase, it compute voucher discounts of checkout and claim their quota
+type voucherApplier struct {
+	voucherRepo repository.VoucherRepository
+	orderRepo   repository.OrderRepository
+}
+
+func newVoucherApplier(voucherRepo repository.VoucherRepository, orderRepo repository.OrderRepository) voucherApplier {
+	return voucherApplier{
+		voucherRepo: voucherRepo,
+		orderRepo:   orderRepo,
+	}
+}
+
+func voucherCodeNotUnique() error {
+	return usecase_error.ErrBadEntityInput{
+		usecase_error.ErrEntityField{
+			Field:   "Code",
+			Message: "Code is not unique",
+		},
+	}
+}
+
+func voucherError(message string) error {
+	return usecase_error.ErrBadEntityInput{
+		usecase_error.ErrEntityField{
+			Field:   "VoucherCode",
+			Message: message,
+		},
+	}
+}
+
+// getVoucher find running voucher by code, quota is only checked here for message, it is claimed atomically later
+func (v voucherApplier) getVoucher(ctx context.Context, customerID string, code string) (domain.Voucher, error) {
+	vouchers, err := v.voucherRepo.Fetch(ctx, domain.VoucherSearchOptions{Code: normalizeVoucherCode(code)})
+	if err != nil {
+		return domain.Voucher{}, err
+	}
+	if len(vouchers) == 0 {
+		return domain.Voucher{}, voucherError("Voucher is not found : " + code)
+	}
+
+	voucher := vouchers[0]
+	switch {
+	case !voucher.IsRunning(time.Now()):
+		return voucher, voucherError("Voucher is not running : " + code)
+	case voucher.Used >= voucher.Quota:
+		return voucher, voucherError("Voucher quota is used up : " + code)
+	case voucher.Usages[customerID] >= voucher.QuotaPerCustomer:
+		return voucher, voucherError("Voucher is already used : " + code)
+	}
+	if voucher.NewCustomerOnly {
+		orders, err := v.orderRepo.Fetch(ctx, "", 1, domain.OrderSearchOptions{CustomerID: customerID})
+		if err != nil {
+			return voucher, err
+		}
+		if len(orders) != 0 {
+			return voucher, voucherError("Voucher is only for new customer : " + code)
+		}
+	}
+	return voucher, nil
+}
+
+// splitByWeight split amount proportional to weights, rounding remainder goes to last weighted item
+func splitByWeight(amount int64, weights []int64) []int64 {
+	parts := make([]int64, len(weights))
+	total, last := int64(0), -1
+	for i, weight := range weights {
+		total += weight
+		if weight > 0 {
+			last = i
+		}
+	}
+	if total == 0 {
+		return parts
+	}
+
+	given := int64(0)
+	for i, weight := range weights {
+		if i == last {
+			parts[i] = amount - given
+			break
+		}
+		parts[i] = amount * weight / total
+		given += parts[i]
+	}
+	return parts
+}
+
+// applyVoucher add discount of voucher to orders it applies to
+func applyVoucher(voucher domain.Voucher, orders []domain.Order) error {
+	amounts, shippingCosts := make([]int64, len(orders)), make([]int64, len(orders))
+	eligibleAmount, shippingCost := int64(0), int64(0)
+	for i, order := range orders {
+		amounts[i] = voucher.EligibleAmount(order)
+		if amounts[i] > 0 {
+			shippingCosts[i] = order.ShippingCost - order.ShippingDiscount
+		}
+		eligibleAmount += amounts[i]
+		shippingCost += shippingCosts[i]
+	}
+	if eligibleAmount == 0 {
+		return voucherError("Voucher is not applicable to ordered products : " + voucher.Code)
+	}
+	if eligibleAmount < voucher.MinSpend {
+		return voucherError(fmt.Sprintf("Voucher needs minimum spend %d : %s", voucher.MinSpend, voucher.Code))
+	}
+
+	discount, shippingDiscount := voucher.Discount(eligibleAmount, shippingCost)
+	discounts := splitByWeight(discount, amounts)
+	shippingDiscounts := splitByWeight(shippingDiscount, shippingCosts)
+	for i := range orders {
+		if amounts[i] == 0 {
+			continue
+		}
+		//discount of stacked vouchers never exceed order subtotal
+		if remaining := orders[i].Subtotal() - orders[i].Discount; discounts[i] > remaining {
+			discounts[i] = remaining
+		}
+		orders[i].Discount += discounts[i]
+		orders[i].ShippingDiscount += shippingDiscounts[i]
+		orders[i].Vouchers = append(orders[i].Vouchers, domain.OrderVoucher{
+			VoucherID:        voucher.ID,
+			Code:             voucher.Code,
+			MerchantID:       voucher.MerchantID,
+			Type:             voucher.Type,
+			Discount:         discounts[i],
+			ShippingDiscount: shippingDiscounts[i],
+		})
+	}
+	return nil
+}
+
+// apply compute discounts of merchant vouchers then platform voucher, and claim their quota.
+// Claimed vouchers are returned so they can be released when checkout fail afterward
+func (v voucherApplier) apply(ctx context.Context, customerID string, platformCode string, merchantCodes map[string]string, orders []domain.Order) ([]domain.Order, []string, error) {
+	vouchers := []domain.Voucher{}
+	for i, order := range orders {
+		code := merchantCodes[order.Merchant.ID]
+		if code == "" {
+			continue
+		}
+		voucher, err := v.getVoucher(ctx, customerID, code)
+		if err != nil {
+			return orders, nil, err
+		}
+		if voucher.MerchantID != order.Merchant.ID {
+			return orders, nil, voucherError("Voucher is not voucher of merchant : " + code)
+		}
+		if err := applyVoucher(voucher, orders[i:i+1]); err != nil {
+			return orders, nil, err
+		}
+		vouchers = append(vouchers, voucher)
+	}
+	if platformCode != "" {
+		voucher, err := v.getVoucher(ctx, customerID, platformCode)
+		if err != nil {
+			return orders, nil, err
+		}
+		if voucher.MerchantID != "" {
+			return orders, nil, voucherError("Voucher is merchant voucher, use it on order of the merchant : " + platformCode)
+		}
+		if err := applyVoucher(voucher, orders); err != nil {
+			return orders, nil, err
+		}
+		vouchers = append(vouchers, voucher)
+	}
+
+	claimed := []string{}
+	for _, voucher := range vouchers {
+		err := v.voucherRepo.Claim(ctx, voucher.ID, customerID)
+		if err == usecase_error.ErrNotFound {
+			err = voucherError("Voucher quota is used up : " + voucher.Code)
+		}
+		if err != nil {
+			undoCtx, undoCancel := undoContext()
+			v.release(undoCtx, customerID, claimed)
+			undoCancel()
+			return orders, nil, err
+		}
+		claimed = append(claimed, voucher.ID)
+	}
+	return orders, claimed, nil
+}
+
+// release give back claimed quota, failure is only logged
+func (v voucherApplier) release(ctx context.Context, customerID string, voucherIDs []string) {
+	for _, voucherID := range voucherIDs {
+		if err := v.voucherRepo.Release(ctx, voucherID, customerID); err != nil {
+			fmt.Printf("[VOUCHER APPLIER] : RELEASE VOUCHER %s %s %#v \n", voucherID, customerID, err)
+		}
+	}
+}
+
+// releaseCanceledOrder give back quota of vouchers of canceled order,
+// platform voucher shared by orders of one checkout is released when its last order is canceled
+func (v voucherApplier) releaseCanceledOrder(ctx context.Context, order domain.Order) {
+	if len(order.Vouchers) == 0 {
+		return
+	}
+
+	orders, err := v.orderRepo.Fetch(ctx, "", 0, domain.OrderSearchOptions{TransactionID: order.TransactionsID})
+	if err != nil {
+		fmt.Printf("[VOUCHER APPLIER] : FETCH TRANSACTION ORDERS %s %#v \n", order.TransactionsID, err)
+		return
+	}
+	voucherIDs := []string{}
+	for _, voucher := range order.Vouchers {
+		shared := false
+		for _, other := range orders {
+			if other.ID == order.ID || other.StatusOrder == domain.STATUS_ORDER_DI_CANCEL {
+				continue
+			}
+			for _, otherVoucher := range other.Vouchers {
+				if otherVoucher.VoucherID == voucher.VoucherID {
+					shared = true
+				}
+			}
+		}
+		if !shared {
+			voucherIDs = append(voucherIDs, voucher.VoucherID)
+		}
+	}
+	v.release(ctx, order.Customer.ID, voucherIDs)
+}
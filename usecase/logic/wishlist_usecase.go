@@ -0,0 +1,208 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/adapter"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+// WishlistUsecase keep products customer want to remember without holding them in cart
+type WishlistUsecase interface {
+	Add(ctx context.Context, input adapter.WishlistAddInput, customerID string) (domain.WishlistItem, error)
+	Remove(ctx context.Context, productID string, customerID string) (domain.WishlistItem, error)
+	Fetch(ctx context.Context, cursor string, num int64, customerID string) ([]domain.WishlistItem, error)
+}
+
+type wishlistUsecase struct {
+	wishlistRepo   repository.WishlistRepository
+	productRepo    repository.ProductRepository
+	contextTimeout time.Duration
+}
+
+func NewWishlistUsecase(
+	wishlistRepo repository.WishlistRepository,
+	productRepo repository.ProductRepository,
+	contextTimeout time.Duration,
+) WishlistUsecase {
+	return &wishlistUsecase{
+		wishlistRepo:   wishlistRepo,
+		productRepo:    productRepo,
+		contextTimeout: contextTimeout,
+	}
+}
+
+func (w *wishlistUsecase) Add(ctx context.Context, input adapter.WishlistAddInput, customerID string) (domain.WishlistItem, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.contextTimeout)
+	defer cancel()
+
+	product, err := w.productRepo.GetByID(ctx, input.ProductID)
+	if err != nil {
+		return domain.WishlistItem{}, err
+	}
+	if !product.IsVisible() {
+		return domain.WishlistItem{}, usecase_error.ErrNotFound
+	}
+	snapshot := product.DenormalizationData()
+	if _, ok := snapshot.Variant(input.SKU); input.SKU != "" && !ok {
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "SKU",
+				Message: "Variant is not found",
+			},
+		}
+		return domain.WishlistItem{}, err
+	}
+	item := domain.WishlistItem{
+		CustomerID: customerID,
+		Product:    snapshot,
+		SKU:        input.SKU,
+		AddedPrice: snapshot.PriceOf(input.SKU),
+		Quantity:   1,
+		Sizes:      []string{},
+		Colors:     []string{},
+		Note:       input.Note,
+	}
+
+	return saveWishlistItem(ctx, w.wishlistRepo, item)
+}
+
+func (w *wishlistUsecase) Remove(ctx context.Context, productID string, customerID string) (domain.WishlistItem, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.contextTimeout)
+	defer cancel()
+
+	item, err := getWishlistItem(ctx, w.wishlistRepo, customerID, productID)
+	if err != nil {
+		return item, err
+	}
+	return w.wishlistRepo.DeleteOne(ctx, item)
+}
+
+// Fetch show current price and stock of wishlisted product, deleted product or suspended merchant is not available
+func (w *wishlistUsecase) Fetch(ctx context.Context, cursor string, num int64, customerID string) ([]domain.WishlistItem, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.contextTimeout)
+	defer cancel()
+
+	items, err := w.wishlistRepo.Fetch(ctx, cursor, num, domain.WishlistSearchOptions{CustomerID: customerID})
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	ids := []string{}
+	for _, item := range items {
+		ids = append(ids, item.Product.ID)
+	}
+	products, err := w.productRepo.Fetch(ctx, "", 0, domain.ProductSearchOptions{IDs: ids})
+	if err != nil {
+		return nil, err
+	}
+	productByID := map[string]domain.Product{}
+	for _, product := range products {
+		productByID[product.ID] = product
+	}
+
+	now := time.Now()
+	for i, item := range items {
+		product, ok := productByID[item.Product.ID]
+		if !ok {
+			continue
+		}
+		items[i].Product = product.DenormalizationData()
+		items[i].Available = product.IsActive() && items[i].Product.StockOf(item.SKU) > 0 && !product.Merchant.SuspendedUntil.After(now)
+	}
+	return items, nil
+}
+
+func getWishlistItem(ctx context.Context, wishlistRepo repository.WishlistRepository, customerID, productID string) (domain.WishlistItem, error) {
+	search := domain.WishlistSearchOptions{
+		CustomerID: customerID,
+		ProductID:  productID,
+	}
+	items, err := wishlistRepo.Fetch(ctx, "", 1, search)
+	if err != nil {
+		return domain.WishlistItem{}, err
+	}
+	if len(items) == 0 {
+		return domain.WishlistItem{}, usecase_error.ErrNotFound
+	}
+	return items[0], nil
+}
+
+// saveWishlistItem reject product which is already in customer wishlist
+func saveWishlistItem(ctx context.Context, wishlistRepo repository.WishlistRepository, item domain.WishlistItem) (domain.WishlistItem, error) {
+	_, err := getWishlistItem(ctx, wishlistRepo, item.CustomerID, item.Product.ID)
+	if err == nil {
+		fmt.Printf("[USECASE-VALIDATION] : WISHLIST  %#v \n", "PRODUCT ALREADY IN WISHLIST")
+		err := usecase_error.ErrBadEntityInput{
+			usecase_error.ErrEntityField{
+				Field:   "ProductID",
+				Message: "Product is already in wishlist",
+			},
+		}
+		return item, err
+	}
+	if err != usecase_error.ErrNotFound {
+		return item, err
+	}
+
+	return wishlistRepo.Create(ctx, item)
+}
+
+// wishlistNotifier is embedded by usecases which change product price or stock,
+// customers who wishlist the product are notified when price drop or product is back in stock
+type wishlistNotifier struct {
+	wishlistRepo     repository.WishlistRepository
+	notificationRepo repository.NotificationRepository
+}
+
+func newWishlistNotifier(wishlistRepo repository.WishlistRepository, notificationRepo repository.NotificationRepository) wishlistNotifier {
+	return wishlistNotifier{
+		wishlistRepo:     wishlistRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+// notify never fail the product update, it is called after the change is saved
+func (w wishlistNotifier) notify(ctx context.Context, before, after domain.Product) {
+	if w.wishlistRepo == nil || w.notificationRepo == nil || !after.IsActive() {
+		return
+	}
+
+	notificationType, message := "", ""
+	switch {
+	case before.Stock <= 0 && after.Stock > 0:
+		notificationType = domain.NOTIFICATION_TYPE_BACK_IN_STOCK
+		message = fmt.Sprintf("%s is back in stock", after.Name)
+	case after.EffectivePrice() < before.EffectivePrice():
+		notificationType = domain.NOTIFICATION_TYPE_PRICE_DROP
+		message = fmt.Sprintf("Price of %s dropped from %.0f to %.0f", after.Name, before.EffectivePrice(), after.EffectivePrice())
+	default:
+		return
+	}
+
+	items, err := w.wishlistRepo.Fetch(ctx, "", 0, domain.WishlistSearchOptions{ProductID: after.ID})
+	if err != nil {
+		fmt.Printf("[WISHLIST NOTIFIER] : FETCH WISHLIST %s %#v \n", after.ID, err)
+		return
+	}
+
+	notifications := []domain.Notification{}
+	for _, item := range items {
+		notifications = append(notifications, domain.Notification{
+			CustomerID: item.CustomerID,
+			Type:       notificationType,
+			Message:    message,
+			ProductID:  after.ID,
+		})
+	}
+	if err := w.notificationRepo.CreateMany(ctx, notifications); err != nil {
+		fmt.Printf("[WISHLIST NOTIFIER] : CREATE NOTIFICATION %s %#v \n", after.ID, err)
+	}
+}
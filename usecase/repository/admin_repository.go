@@ -13,4 +13,8 @@ type AdminRepository interface {
 	UpdateOne(ctx context.Context, admin domain.Admin) (domain.Admin, error)
 	DeleteOne(ctx context.Context, admin domain.Admin) (domain.Admin, error)
 	DeleteAll(ctx context.Context) error
+	// CountLegacyPassword count password hash not made with current algorithm and parameter
+	CountLegacyPassword(ctx context.Context, hashPrefix string) (int64, error)
+	// AssignMissingRole give role to every admin which has no role, it return number of updated admin
+	AssignMissingRole(ctx context.Context, role string) (int64, error)
 }
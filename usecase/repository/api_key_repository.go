@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/market-place/domain"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, apiKey domain.APIKey) (domain.APIKey, error)
+	Fetch(ctx context.Context, cursor string, num int64, options domain.APIKeySearchOptions) ([]domain.APIKey, error)
+	GetByID(ctx context.Context, id string) (domain.APIKey, error)
+	UpdateOne(ctx context.Context, apiKey domain.APIKey) (domain.APIKey, error)
+	UpdateLastUsed(ctx context.Context, id string, lastUsedAt time.Time) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/market-place/domain"
+)
+
+// AuditLogRepository is append only, audit log is never updated or deleted
+type AuditLogRepository interface {
+	Create(ctx context.Context, auditLog domain.AuditLog) (domain.AuditLog, error)
+	Fetch(ctx context.Context, cursor string, num int64, options domain.AuditLogSearchOptions) ([]domain.AuditLog, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/market-place/domain"
+)
+
+type CategoryRepository interface {
+	Create(ctx context.Context, category domain.CategoryNode) (domain.CategoryNode, error)
+	Fetch(ctx context.Context, options domain.CategorySearchOptions) ([]domain.CategoryNode, error)
+	GetByID(ctx context.Context, id string) (domain.CategoryNode, error)
+	UpdateOne(ctx context.Context, category domain.CategoryNode) (domain.CategoryNode, error)
+	DeleteOne(ctx context.Context, category domain.CategoryNode) (domain.CategoryNode, error)
+}
@@ -11,6 +11,9 @@ type CustomerRepository interface {
 	Fetch(ctx context.Context, cursor string, num int64, options domain.CustomerSearchOptions) ([]domain.Customer, error)
 	GetByID(ctx context.Context, id string) (domain.Customer, error)
 	UpdateOne(ctx context.Context, customer domain.Customer) (domain.Customer, error)
+	UpdateSuspension(ctx context.Context, id string, suspension domain.Suspension) (domain.Customer, error)
 	DeleteOne(ctx context.Context, customer domain.Customer) (domain.Customer, error)
 	DeleteAll(ctx context.Context) error
+	// CountLegacyPassword count password hash not made with current algorithm and parameter
+	CountLegacyPassword(ctx context.Context, hashPrefix string) (int64, error)
 }
@@ -38,6 +38,51 @@ func NewElasticSearchRepository(db *elasticsearch.Client) repository.SearchRepos
 	}
 }
 
+// suspendedQuery match document whose suspension end is not reached yet,
+// it is used as must_not so product and merchant of suspended merchant are hidden from search
+func suspendedQuery(field string) map[string]interface{} {
+	return map[string]interface{}{
+		"range": map[string]interface{}{
+			field: map[string]interface{}{
+				"gt": "now",
+			},
+		},
+	}
+}
+
+// discountedPriceSort order product by price after running discount, falling back to price
+// for product whose discounted_price is not refreshed yet
+func discountedPriceSort(order string) map[string]interface{} {
+	return map[string]interface{}{
+		"_script": map[string]interface{}{
+			"type": "number",
+			"script": map[string]interface{}{
+				"lang":   "painless",
+				"source": "doc['discounted_price'].size() != 0 ? doc['discounted_price'].value : doc['price'].value",
+			},
+			"order": order,
+		},
+	}
+}
+
+// hiddenProductQueries match product of suspended merchant, soft deleted product and product which is not active,
+// it is used as must_not so only active product is shown in search
+func hiddenProductQueries() []interface{} {
+	return []interface{}{
+		suspendedQuery("merchant.suspended_until"),
+		map[string]interface{}{
+			"terms": map[string]interface{}{
+				"status": domain.PRODUCT_INACTIVE_STATUSES,
+			},
+		},
+		map[string]interface{}{
+			"exists": map[string]interface{}{
+				"field": "deleted_at",
+			},
+		},
+	}
+}
+
 func (e *elasticSearchRepository) getTags(c chan Response, ctx context.Context, keyword string) {
 	var suggestBody bytes.Buffer
 	suggestQuery := map[string]interface{}{
@@ -137,10 +182,15 @@ func (e *elasticSearchRepository) getProducts(c chan Response, ctx context.Conte
 	var productBody bytes.Buffer
 	query := map[string]interface{}{
 		"query": map[string]interface{}{
-			"multi_match": map[string]interface{}{
-				"type":   "bool_prefix",
-				"fields": []string{"name", "name._2gram", "name._3gram"},
-				"query":  keyword,
+			"bool": map[string]interface{}{
+				"must": map[string]interface{}{
+					"multi_match": map[string]interface{}{
+						"type":   "bool_prefix",
+						"fields": []string{"name", "name._2gram", "name._3gram"},
+						"query":  keyword,
+					},
+				},
+				"must_not": hiddenProductQueries(),
 			},
 		},
 	}
@@ -228,10 +278,15 @@ func (e *elasticSearchRepository) getMerchants(c chan Response, ctx context.Cont
 	var merchantBody bytes.Buffer
 	merchantQuery := map[string]interface{}{
 		"query": map[string]interface{}{
-			"multi_match": map[string]interface{}{
-				"type":   "bool_prefix",
-				"fields": []string{"name", "name._2gram", "name._3gram"},
-				"query":  keyword,
+			"bool": map[string]interface{}{
+				"must": map[string]interface{}{
+					"multi_match": map[string]interface{}{
+						"type":   "bool_prefix",
+						"fields": []string{"name", "name._2gram", "name._3gram"},
+						"query":  keyword,
+					},
+				},
+				"must_not": suspendedQuery("suspension.until"),
 			},
 		},
 	}
@@ -344,7 +399,7 @@ func (e *elasticSearchRepository) SuggestionSearch(ctx context.Context, keyword
 	return search, nil
 }
 
-func (e *elasticSearchRepository) ProductSearch(ctx context.Context, category, secondCategory, thirdCategory string, city string, min, max int64, keyword string, lastDate string) (domain.SearchProduct, error) {
+func (e *elasticSearchRepository) ProductSearch(ctx context.Context, category, secondCategory, thirdCategory string, city string, min, max int64, keyword string, lastDate string, attributes []domain.ProductAttribute, sort string) (domain.SearchProduct, error) {
 	var searchedProduct domain.SearchProduct
 
 	keywordQuery := map[string]interface{}{
@@ -380,28 +435,50 @@ func (e *elasticSearchRepository) ProductSearch(ctx context.Context, category, s
 			"merchant.address.city.city_name": city,
 		},
 	}
-	priceQuery := map[string]interface{}{}
+	//price filter and sort use price after running discount,
+	//product which is not refreshed yet has no discounted_price so its price is used
+	priceRange := map[string]interface{}{}
 	if min == 0 && max != 0 {
-		priceQuery["range"] = map[string]interface{}{
-			"price": map[string]interface{}{
-				"gte": 0,
-				"lte": max,
-			},
+		priceRange = map[string]interface{}{
+			"gte": 0,
+			"lte": max,
 		}
 	} else if min != 0 && max == 0 {
-		priceQuery["range"] = map[string]interface{}{
-			"price": map[string]interface{}{
-				"gte": min,
-			},
+		priceRange = map[string]interface{}{
+			"gte": min,
 		}
 	} else {
-		priceQuery["range"] = map[string]interface{}{
-			"price": map[string]interface{}{
-				"gte": min,
-				"lte": max,
-			},
+		priceRange = map[string]interface{}{
+			"gte": min,
+			"lte": max,
 		}
 	}
+	priceQuery := map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should": []interface{}{
+				map[string]interface{}{
+					"range": map[string]interface{}{
+						"discounted_price": priceRange,
+					},
+				},
+				map[string]interface{}{
+					"bool": map[string]interface{}{
+						"filter": map[string]interface{}{
+							"range": map[string]interface{}{
+								"price": priceRange,
+							},
+						},
+						"must_not": map[string]interface{}{
+							"exists": map[string]interface{}{
+								"field": "discounted_price",
+							},
+						},
+					},
+				},
+			},
+			"minimum_should_match": 1,
+		},
+	}
 
 	lastDateQuery := map[string]interface{}{
 		"range": map[string]interface{}{
@@ -429,6 +506,31 @@ func (e *elasticSearchRepository) ProductSearch(ctx context.Context, category, s
 	if lastDate != "" {
 		filterQuery = append(filterQuery, lastDateQuery)
 	}
+	//every searched attribute must be matched by one attribute of product
+	for _, attribute := range attributes {
+		attributeQuery := map[string]interface{}{
+			"nested": map[string]interface{}{
+				"path": "attributes",
+				"query": map[string]interface{}{
+					"bool": map[string]interface{}{
+						"filter": []interface{}{
+							map[string]interface{}{
+								"term": map[string]interface{}{
+									"attributes.name": attribute.Name,
+								},
+							},
+							map[string]interface{}{
+								"term": map[string]interface{}{
+									"attributes.value": attribute.Value,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		filterQuery = append(filterQuery, attributeQuery)
+	}
 
 	boostByTags := map[string]interface{}{
 		"term": map[string]interface{}{
@@ -492,22 +594,69 @@ func (e *elasticSearchRepository) ProductSearch(ctx context.Context, category, s
 			"field": "merchant.address.city.city_name",
 		},
 	}
+	colorAggs := map[string]interface{}{
+		"terms": map[string]interface{}{
+			"field": "colors",
+		},
+	}
+	sizeAggs := map[string]interface{}{
+		"terms": map[string]interface{}{
+			"field": "sizes",
+		},
+	}
+	attributeAggs := map[string]interface{}{
+		"nested": map[string]interface{}{
+			"path": "attributes",
+		},
+		"aggs": map[string]interface{}{
+			"names": map[string]interface{}{
+				"terms": map[string]interface{}{
+					"field": "attributes.name",
+				},
+				"aggs": map[string]interface{}{
+					"values": map[string]interface{}{
+						"terms": map[string]interface{}{
+							"field": "attributes.value",
+						},
+					},
+				},
+			},
+		},
+	}
 	aggsQuery := map[string]interface{}{
 		"categories": categoryAggs,
 		"cities":     cityAggs,
+		"colors":     colorAggs,
+		"sizes":      sizeAggs,
+		"attributes": attributeAggs,
 	}
 
 	var body bytes.Buffer
 	query := map[string]interface{}{
 		"query": map[string]interface{}{
 			"bool": map[string]interface{}{
-				"must":   mustQuery,
-				"filter": filterQuery,
-				"should": shouldQuery,
+				"must":     mustQuery,
+				"filter":   filterQuery,
+				"should":   shouldQuery,
+				"must_not": hiddenProductQueries(),
 			},
 		},
 		"aggs": aggsQuery,
 	}
+	switch sort {
+	case domain.SEARCH_SORT_PRICE_ASC:
+		query["sort"] = []interface{}{
+			discountedPriceSort("asc"),
+		}
+	case domain.SEARCH_SORT_PRICE_DESC:
+		query["sort"] = []interface{}{
+			discountedPriceSort("desc"),
+		}
+	case domain.SEARCH_SORT_DISCOUNT:
+		query["sort"] = []interface{}{
+			map[string]interface{}{"discount_percent": "desc"},
+		}
+	}
 	if err := json.NewEncoder(&body).Encode(query); err != nil {
 		fmt.Printf("[DEBUG] REPOSITORY SEARCH PRODUCT:  %#v \n", err)
 		return searchedProduct, usecase_error.ErrInternalServerError
@@ -550,8 +699,11 @@ func (e *elasticSearchRepository) ProductSearch(ctx context.Context, category, s
 			} `json:"hits"`
 		} `json:"hits"`
 		Aggs struct {
-			Categories domain.CategorySearch `json:"categories"`
-			Cities     domain.CitySearch     `json:"cities"`
+			Categories domain.CategorySearch  `json:"categories"`
+			Cities     domain.CitySearch      `json:"cities"`
+			Colors     domain.OptionSearch    `json:"colors"`
+			Sizes      domain.OptionSearch    `json:"sizes"`
+			Attributes domain.AttributeSearch `json:"attributes"`
 		} `json:"aggregations"`
 	}
 	var payload Payload
@@ -566,6 +718,9 @@ func (e *elasticSearchRepository) ProductSearch(ctx context.Context, category, s
 	}
 	searchedProduct.Categories = payload.Aggs.Categories
 	searchedProduct.Cities = payload.Aggs.Cities
+	searchedProduct.Colors = payload.Aggs.Colors
+	searchedProduct.Sizes = payload.Aggs.Sizes
+	searchedProduct.Attributes = payload.Aggs.Attributes
 
 	return searchedProduct, nil
 }
@@ -627,8 +782,9 @@ func (e *elasticSearchRepository) MerchantProductSearch(ctx context.Context, mer
 	query := map[string]interface{}{
 		"query": map[string]interface{}{
 			"bool": map[string]interface{}{
-				"must":   mustQuery,
-				"filter": filterQuery,
+				"must":     mustQuery,
+				"filter":   filterQuery,
+				"must_not": hiddenProductQueries(),
 			},
 		},
 		"size": number,
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/market-place/domain"
+)
+
+type FlashSaleRepository interface {
+	Create(ctx context.Context, flashSale domain.FlashSale) (domain.FlashSale, error)
+	Fetch(ctx context.Context, options domain.FlashSaleSearchOptions) ([]domain.FlashSale, error)
+	GetByID(ctx context.Context, id string) (domain.FlashSale, error)
+	DeleteOne(ctx context.Context, flashSale domain.FlashSale) (domain.FlashSale, error)
+	// Reserve add quantity to sold of running flash sale in one atomic update, it returns updated flash sale,
+	// ErrNotFound when flash sale is not running, quota is sold out or customer limit is reached
+	Reserve(ctx context.Context, flashSaleID string, customerID string, quantity int64, at time.Time) (domain.FlashSale, error)
+	// Release give back quantity reserved by customer, it returns updated flash sale
+	Release(ctx context.Context, flashSaleID string, customerID string, quantity int64) (domain.FlashSale, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/market-place/domain"
+)
+
+// GuestCartRepository save cart of anonymous visitor, cart is removed after domain.GUEST_CART_DURATION
+type GuestCartRepository interface {
+	Save(ctx context.Context, cart domain.Cart) (domain.Cart, error)
+	GetByID(ctx context.Context, id string) (domain.Cart, error)
+	Delete(ctx context.Context, id string) error
+}
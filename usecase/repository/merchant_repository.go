@@ -13,6 +13,7 @@ type MerchantRepository interface {
 	//name is unique
 	GetByName(ctx context.Context, name string) (domain.Merchant, error)
 	UpdateOne(ctx context.Context, merchant domain.Merchant) (domain.Merchant, error)
+	UpdateSuspension(ctx context.Context, id string, suspension domain.Suspension) (domain.Merchant, error)
 	DeleteOne(ctx context.Context, merchant domain.Merchant) (domain.Merchant, error)
 	DeleteAll(ctx context.Context) error
 }
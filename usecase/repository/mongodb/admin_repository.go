@@ -3,6 +3,7 @@ package mongodb
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/market-place/domain"
@@ -56,6 +57,9 @@ func (a *mongoDBAdminRepository) Fetch(ctx context.Context, cursor string, num i
 	if options.Email != "" {
 		query["email"] = options.Email
 	}
+	if options.Role != "" {
+		query["role"] = options.Role
+	}
 
 	var admins []domain.Admin
 	cur, err := a.db.Collection(a.collectionName).Find(ctx, query)
@@ -118,14 +122,16 @@ func (a *mongoDBAdminRepository) UpdateOne(ctx context.Context, admin domain.Adm
 	query := bson.M{"_id": admin.ID}
 	data := bson.M{
 		"$set": bson.M{
-			"name":      admin.Name,
-			"password":  admin.Password,
-			"addresses": admin.Addresses,
-			"born":      admin.Born,
-			"birth_day": admin.BirthDay,
-			"phone":     admin.Phone,
-			"avatar":    admin.Avatar,
-			"gender":    admin.Gender,
+			"name":       admin.Name,
+			"password":   admin.Password,
+			"addresses":  admin.Addresses,
+			"born":       admin.Born,
+			"birth_day":  admin.BirthDay,
+			"phone":      admin.Phone,
+			"avatar":     admin.Avatar,
+			"gender":     admin.Gender,
+			"role":       admin.Role,
+			"two_factor": admin.TwoFactor,
 		},
 	}
 	opt := options.FindOneAndUpdate().SetReturnDocument(options.ReturnDocument(1))
@@ -166,3 +172,41 @@ func (a *mongoDBAdminRepository) DeleteAll(ctx context.Context) error {
 	}
 	return nil
 }
+
+func (a *mongoDBAdminRepository) CountLegacyPassword(ctx context.Context, hashPrefix string) (int64, error) {
+	query := bson.M{
+		"password": bson.M{
+			"$not": primitive.Regex{Pattern: "^" + regexp.QuoteMeta(hashPrefix)},
+		},
+	}
+
+	count, err := a.db.Collection(a.collectionName).CountDocuments(ctx, query)
+	if err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY ADMIN COUNT LEGACY PASSWORD:  %#v \n", err)
+		return 0, usecase_error.ErrInternalServerError
+	}
+	return count, nil
+}
+
+func (a *mongoDBAdminRepository) AssignMissingRole(ctx context.Context, role string) (int64, error) {
+	query := bson.M{
+		"$or": bson.A{
+			bson.M{"role": bson.M{"$exists": false}},
+			bson.M{"role": ""},
+			bson.M{"role": nil},
+		},
+	}
+	data := bson.M{
+		"$set": bson.M{
+			"role":       role,
+			"updated_at": time.Now().Truncate(time.Millisecond),
+		},
+	}
+
+	result, err := a.db.Collection(a.collectionName).UpdateMany(ctx, query, data)
+	if err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY ADMIN ASSIGN MISSING ROLE:  %#v \n", err)
+		return 0, usecase_error.ErrInternalServerError
+	}
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,152 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoDBAPIKeyRepository struct {
+	db             *mongo.Database
+	collectionName string
+}
+
+func NewAPIKeyRepository(db *mongo.Database) repository.APIKeyRepository {
+	return &mongoDBAPIKeyRepository{
+		db:             db,
+		collectionName: "api_keys",
+	}
+}
+
+func (a *mongoDBAPIKeyRepository) convertToLocalTime(apiKey *domain.APIKey) {
+	apiKey.LastUsedAt = apiKey.LastUsedAt.Local().Truncate(time.Millisecond)
+	apiKey.RevokedAt = apiKey.RevokedAt.Local().Truncate(time.Millisecond)
+	apiKey.CreatedAt = apiKey.CreatedAt.Local().Truncate(time.Millisecond)
+	apiKey.UpdatedAt = apiKey.UpdatedAt.Local().Truncate(time.Millisecond)
+}
+
+func (a *mongoDBAPIKeyRepository) Create(ctx context.Context, apiKey domain.APIKey) (domain.APIKey, error) {
+	apiKey.CreatedAt = time.Now().Truncate(time.Millisecond)
+	apiKey.UpdatedAt = time.Now().Truncate(time.Millisecond)
+
+	_, err := a.db.Collection(a.collectionName).InsertOne(ctx, apiKey)
+	if err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY API KEY CREATE:  %#v \n", err)
+		return apiKey, usecase_error.ErrInternalServerError
+	}
+	a.convertToLocalTime(&apiKey)
+	return apiKey, nil
+}
+
+func (a *mongoDBAPIKeyRepository) Fetch(ctx context.Context, cursor string, num int64, optionsSearch domain.APIKeySearchOptions) ([]domain.APIKey, error) {
+	var apiKeys []domain.APIKey
+
+	query := bson.M{}
+	if optionsSearch.MerchantID != "" {
+		query["merchant_id"] = optionsSearch.MerchantID
+	}
+	if optionsSearch.HashedKey != "" {
+		query["hashed_key"] = optionsSearch.HashedKey
+	}
+	if cursor != "" {
+		last, err := time.Parse(time.RFC3339, cursor)
+		if err != nil {
+			fmt.Printf("[DEBUG] PARSE CURSOR:  %#v \n", err)
+			return apiKeys, usecase_error.ErrBadParamInput
+		}
+		query["created_at"] = bson.M{
+			"$lt": last.Truncate(time.Millisecond),
+		}
+	}
+
+	cur, err := a.db.Collection(a.collectionName).Find(ctx, query,
+		options.Find().SetLimit(num),
+		options.Find().SetSort(bson.M{"created_at": -1}),
+	)
+	if err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY API KEY FETCH:  %#v \n", err)
+		return apiKeys, usecase_error.ErrInternalServerError
+	}
+
+	for cur.Next(ctx) {
+		var apiKey domain.APIKey
+		if err := cur.Decode(&apiKey); err != nil {
+			fmt.Printf("[DEBUG] REPOSITORY API KEY FETCH LOOP:  %#v \n", err)
+			if err == mongo.ErrNilCursor {
+				return apiKeys, nil
+			}
+
+			return apiKeys, usecase_error.ErrInternalServerError
+		}
+		a.convertToLocalTime(&apiKey)
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	return apiKeys, nil
+}
+
+func (a *mongoDBAPIKeyRepository) GetByID(ctx context.Context, id string) (domain.APIKey, error) {
+	query := bson.M{"_id": id}
+
+	var apiKey domain.APIKey
+	if err := a.db.Collection(a.collectionName).FindOne(ctx, query).Decode(&apiKey); err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY API KEY GET BY ID:  %#v \n", err)
+		if err == mongo.ErrNoDocuments {
+			return apiKey, usecase_error.ErrNotFound
+		}
+
+		return apiKey, usecase_error.ErrInternalServerError
+	}
+	a.convertToLocalTime(&apiKey)
+	return apiKey, nil
+}
+
+func (a *mongoDBAPIKeyRepository) UpdateOne(ctx context.Context, apiKey domain.APIKey) (domain.APIKey, error) {
+	apiKey.UpdatedAt = time.Now().Truncate(time.Millisecond)
+
+	query := bson.M{"_id": apiKey.ID}
+	data := bson.M{
+		"$set": bson.M{
+			"name":       apiKey.Name,
+			"scopes":     apiKey.Scopes,
+			"revoked":    apiKey.Revoked,
+			"revoked_at": apiKey.RevokedAt,
+			"updated_at": apiKey.UpdatedAt,
+		},
+	}
+	opt := options.FindOneAndUpdate().SetReturnDocument(options.ReturnDocument(1))
+
+	var updatedAPIKey domain.APIKey
+	if err := a.db.Collection(a.collectionName).FindOneAndUpdate(ctx, query, data, opt).Decode(&updatedAPIKey); err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY API KEY UPDATE:  %#v \n", err)
+		if err == mongo.ErrNoDocuments {
+			return apiKey, usecase_error.ErrNotFound
+		}
+
+		return apiKey, usecase_error.ErrInternalServerError
+	}
+	a.convertToLocalTime(&updatedAPIKey)
+	return updatedAPIKey, nil
+}
+
+func (a *mongoDBAPIKeyRepository) UpdateLastUsed(ctx context.Context, id string, lastUsedAt time.Time) error {
+	query := bson.M{"_id": id}
+	data := bson.M{
+		"$set": bson.M{
+			"last_used_at": lastUsedAt.Truncate(time.Millisecond),
+		},
+	}
+
+	if _, err := a.db.Collection(a.collectionName).UpdateOne(ctx, query, data); err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY API KEY UPDATE LAST USED:  %#v \n", err)
+		return usecase_error.ErrInternalServerError
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoDBAuditLogRepository struct {
+	db             *mongo.Database
+	collectionName string
+}
+
+func NewAuditLogRepository(db *mongo.Database) repository.AuditLogRepository {
+	return &mongoDBAuditLogRepository{
+		db:             db,
+		collectionName: "audit_logs",
+	}
+}
+
+func (a *mongoDBAuditLogRepository) convertToLocalTime(auditLog *domain.AuditLog) {
+	auditLog.CreatedAt = auditLog.CreatedAt.Local().Truncate(time.Millisecond)
+}
+
+func (a *mongoDBAuditLogRepository) Create(ctx context.Context, auditLog domain.AuditLog) (domain.AuditLog, error) {
+	auditLog.ID = primitive.NewObjectID().Hex()
+	auditLog.CreatedAt = time.Now().Truncate(time.Millisecond)
+
+	_, err := a.db.Collection(a.collectionName).InsertOne(ctx, auditLog)
+	if err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY AUDIT LOG CREATE:  %#v \n", err)
+		return auditLog, usecase_error.ErrInternalServerError
+	}
+	return auditLog, nil
+}
+
+// Fetch use last returned id as cursor, object id is ordered by creation time
+func (a *mongoDBAuditLogRepository) Fetch(ctx context.Context, cursor string, num int64, optionsSearch domain.AuditLogSearchOptions) ([]domain.AuditLog, error) {
+	var auditLogs []domain.AuditLog
+
+	query := bson.M{}
+	if optionsSearch.ActorID != "" {
+		query["actor_id"] = optionsSearch.ActorID
+	}
+	if optionsSearch.Action != "" {
+		query["action"] = optionsSearch.Action
+	}
+	if optionsSearch.TargetType != "" {
+		query["target_type"] = optionsSearch.TargetType
+	}
+	if optionsSearch.TargetID != "" {
+		query["target_id"] = optionsSearch.TargetID
+	}
+	if optionsSearch.RequestID != "" {
+		query["request_id"] = optionsSearch.RequestID
+	}
+	createdAt := bson.M{}
+	if !optionsSearch.From.IsZero() {
+		createdAt["$gte"] = optionsSearch.From
+	}
+	if !optionsSearch.To.IsZero() {
+		createdAt["$lte"] = optionsSearch.To
+	}
+	if len(createdAt) != 0 {
+		query["created_at"] = createdAt
+	}
+	if cursor != "" {
+		query["_id"] = bson.M{
+			"$lt": cursor,
+		}
+	}
+
+	cur, err := a.db.Collection(a.collectionName).Find(ctx, query,
+		options.Find().SetLimit(num),
+		options.Find().SetSort(bson.M{"_id": -1}),
+	)
+	if err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY AUDIT LOG FETCH:  %#v \n", err)
+		return auditLogs, usecase_error.ErrInternalServerError
+	}
+
+	for cur.Next(ctx) {
+		var auditLog domain.AuditLog
+		if err := cur.Decode(&auditLog); err != nil {
+			fmt.Printf("[DEBUG] REPOSITORY AUDIT LOG FETCH LOOP:  %#v \n", err)
+			if err == mongo.ErrNilCursor {
+				return auditLogs, nil
+			}
+
+			return auditLogs, usecase_error.ErrInternalServerError
+		}
+		a.convertToLocalTime(&auditLog)
+		auditLogs = append(auditLogs, auditLog)
+	}
+
+	return auditLogs, nil
+}
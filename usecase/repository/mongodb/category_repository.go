@@ -0,0 +1,140 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoDBCategoryRepository struct {
+	db             *mongo.Database
+	collectionName string
+}
+
+func NewCategoryRepository(db *mongo.Database) repository.CategoryRepository {
+	return &mongoDBCategoryRepository{
+		db:             db,
+		collectionName: "categories",
+	}
+}
+
+func (c *mongoDBCategoryRepository) convertToLocalTime(category *domain.CategoryNode) {
+	category.CreatedAt = category.CreatedAt.Local().Truncate(time.Millisecond)
+	category.UpdatedAt = category.UpdatedAt.Local().Truncate(time.Millisecond)
+}
+
+func (c *mongoDBCategoryRepository) Create(ctx context.Context, category domain.CategoryNode) (domain.CategoryNode, error) {
+	category.CreatedAt = time.Now().Truncate(time.Millisecond)
+	category.UpdatedAt = time.Now().Truncate(time.Millisecond)
+
+	_, err := c.db.Collection(c.collectionName).InsertOne(ctx, category)
+	if err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY CATEGORY CREATE:  %#v \n", err)
+		return category, usecase_error.ErrInternalServerError
+	}
+	c.convertToLocalTime(&category)
+	return category, nil
+}
+
+func (c *mongoDBCategoryRepository) Fetch(ctx context.Context, searchOptions domain.CategorySearchOptions) ([]domain.CategoryNode, error) {
+	query := bson.M{}
+	if searchOptions.ParentID != nil {
+		query["parent_id"] = *searchOptions.ParentID
+	}
+	if searchOptions.Slug != "" {
+		query["slug"] = searchOptions.Slug
+	}
+	opt := options.Find().SetSort(bson.M{"level": 1})
+
+	categories := []domain.CategoryNode{}
+	cur, err := c.db.Collection(c.collectionName).Find(ctx, query, opt)
+	if err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY CATEGORY FETCH:  %#v \n", err)
+		return categories, usecase_error.ErrInternalServerError
+	}
+
+	for cur.Next(ctx) {
+		var category domain.CategoryNode
+		if err := cur.Decode(&category); err != nil {
+			fmt.Printf("[REPOSITORY] REPOSITORY CATEGORY LOOP:  %#v \n", err)
+			if err == mongo.ErrNilCursor {
+				return categories, nil
+			}
+
+			return categories, usecase_error.ErrInternalServerError
+		}
+		c.convertToLocalTime(&category)
+		categories = append(categories, category)
+	}
+
+	return categories, nil
+}
+
+func (c *mongoDBCategoryRepository) GetByID(ctx context.Context, id string) (domain.CategoryNode, error) {
+	query := bson.M{"_id": id}
+
+	var category domain.CategoryNode
+	if err := c.db.Collection(c.collectionName).FindOne(ctx, query).Decode(&category); err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY CATEGORY GET BY ID:  %#v \n", err)
+		if err == mongo.ErrNoDocuments {
+			return category, usecase_error.ErrNotFound
+		}
+		return category, usecase_error.ErrInternalServerError
+	}
+	c.convertToLocalTime(&category)
+	return category, nil
+}
+
+func (c *mongoDBCategoryRepository) UpdateOne(ctx context.Context, category domain.CategoryNode) (domain.CategoryNode, error) {
+	category.UpdatedAt = time.Now().Truncate(time.Millisecond)
+
+	query := bson.M{"_id": category.ID}
+	data := bson.M{
+		"$set": bson.M{
+			"parent_id":  category.ParentID,
+			"level":      category.Level,
+			"slug":       category.Slug,
+			"name":       category.Name,
+			"icon":       category.Icon,
+			"order":      category.Order,
+			"attributes": category.Attributes,
+			"updated_at": category.UpdatedAt,
+		},
+	}
+	opt := options.FindOneAndUpdate().SetReturnDocument(options.ReturnDocument(1))
+
+	var updatedCategory domain.CategoryNode
+	if err := c.db.Collection(c.collectionName).FindOneAndUpdate(ctx, query, data, opt).Decode(&updatedCategory); err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY CATEGORY UPDATE ONE:  %#v \n", err)
+		if err == mongo.ErrNoDocuments {
+			return category, usecase_error.ErrNotFound
+		}
+
+		return category, usecase_error.ErrInternalServerError
+	}
+	c.convertToLocalTime(&updatedCategory)
+	return updatedCategory, nil
+}
+
+func (c *mongoDBCategoryRepository) DeleteOne(ctx context.Context, category domain.CategoryNode) (domain.CategoryNode, error) {
+	query := bson.M{"_id": category.ID}
+
+	var deletedCategory domain.CategoryNode
+	if err := c.db.Collection(c.collectionName).FindOneAndDelete(ctx, query).Decode(&deletedCategory); err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY CATEGORY DELETE ONE:  %#v \n", err)
+		if err == mongo.ErrNoDocuments {
+			return deletedCategory, usecase_error.ErrNotFound
+		}
+
+		return deletedCategory, usecase_error.ErrInternalServerError
+	}
+	c.convertToLocalTime(&deletedCategory)
+	return deletedCategory, nil
+}
@@ -3,6 +3,7 @@ package mongodb
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/market-place/domain"
@@ -55,6 +56,14 @@ func (c *mongoDBCustomerRepository) Fetch(ctx context.Context, cursor string, nu
 	if options.Email != "" {
 		query["email"] = options.Email
 	}
+	if options.IdentityProvider != "" && options.IdentitySubject != "" {
+		query["identities"] = bson.M{
+			"$elemMatch": bson.M{
+				"provider": options.IdentityProvider,
+				"subject":  options.IdentitySubject,
+			},
+		}
+	}
 
 	var customers []domain.Customer
 	cur, err := c.db.Collection(c.collectionName).Find(ctx, query)
@@ -118,18 +127,22 @@ func (c *mongoDBCustomerRepository) UpdateOne(ctx context.Context, customer doma
 	query := bson.M{"_id": customer.ID}
 	data := bson.M{
 		"$set": bson.M{
-			"password":      customer.Password,
-			"name":          customer.Name,
-			"addresses":     customer.Addresses,
-			"merchant_id":   customer.MerchantID,
-			"born":          customer.Born,
-			"birth_day":     customer.BirthDay,
-			"phone":         customer.Phone,
-			"avatar":        customer.Avatar,
-			"gender":        customer.Gender,
-			"bank_accounts": customer.BankAccounts,
-			"created_at":    customer.CreatedAt,
-			"updated_at":    customer.UpdatedAt,
+			"password":       customer.Password,
+			"name":           customer.Name,
+			"addresses":      customer.Addresses,
+			"merchant_id":    customer.MerchantID,
+			"born":           customer.Born,
+			"birth_day":      customer.BirthDay,
+			"phone":          customer.Phone,
+			"phone_verified": customer.PhoneVerified,
+			"avatar":         customer.Avatar,
+			"gender":         customer.Gender,
+			"bank_accounts":  customer.BankAccounts,
+			"two_factor":     customer.TwoFactor,
+			"identities":     customer.Identities,
+			"no_password":    customer.NoPassword,
+			"created_at":     customer.CreatedAt,
+			"updated_at":     customer.UpdatedAt,
 		},
 	}
 	opt := options.FindOneAndUpdate().SetReturnDocument(options.ReturnDocument(1))
@@ -147,6 +160,29 @@ func (c *mongoDBCustomerRepository) UpdateOne(ctx context.Context, customer doma
 	return updatedUser, nil
 }
 
+func (c *mongoDBCustomerRepository) UpdateSuspension(ctx context.Context, id string, suspension domain.Suspension) (domain.Customer, error) {
+	query := bson.M{"_id": id}
+	data := bson.M{
+		"$set": bson.M{
+			"suspension": suspension,
+			"updated_at": time.Now().Truncate(time.Millisecond),
+		},
+	}
+	opt := options.FindOneAndUpdate().SetReturnDocument(options.ReturnDocument(1))
+
+	var updatedUser domain.Customer
+	if err := c.db.Collection(c.collectionName).FindOneAndUpdate(ctx, query, data, opt).Decode(&updatedUser); err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY CUSTOMER UPDATE SUSPENSION:  %#v \n", err)
+		if err == mongo.ErrNoDocuments {
+			return updatedUser, usecase_error.ErrNotFound
+		}
+
+		return updatedUser, usecase_error.ErrInternalServerError
+	}
+	c.convertToLocalTime(&updatedUser)
+	return updatedUser, nil
+}
+
 func (c *mongoDBCustomerRepository) DeleteOne(ctx context.Context, customer domain.Customer) (domain.Customer, error) {
 	query := bson.M{"_id": customer.ID}
 
@@ -170,3 +206,18 @@ func (c *mongoDBCustomerRepository) DeleteAll(ctx context.Context) error {
 	}
 	return nil
 }
+
+func (c *mongoDBCustomerRepository) CountLegacyPassword(ctx context.Context, hashPrefix string) (int64, error) {
+	query := bson.M{
+		"password": bson.M{
+			"$not": primitive.Regex{Pattern: "^" + regexp.QuoteMeta(hashPrefix)},
+		},
+	}
+
+	count, err := c.db.Collection(c.collectionName).CountDocuments(ctx, query)
+	if err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY CUSTOMER COUNT LEGACY PASSWORD:  %#v \n", err)
+		return 0, usecase_error.ErrInternalServerError
+	}
+	return count, nil
+}
@@ -0,0 +1,174 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoDBFlashSaleRepository struct {
+	db             *mongo.Database
+	collectionName string
+}
+
+func NewFlashSaleRepository(db *mongo.Database) repository.FlashSaleRepository {
+	return &mongoDBFlashSaleRepository{
+		db:             db,
+		collectionName: "flash_sales",
+	}
+}
+
+func (f *mongoDBFlashSaleRepository) convertToLocalTime(flashSale *domain.FlashSale) {
+	flashSale.StartAt = flashSale.StartAt.Local().Truncate(time.Millisecond)
+	flashSale.EndAt = flashSale.EndAt.Local().Truncate(time.Millisecond)
+	flashSale.CreatedAt = flashSale.CreatedAt.Local().Truncate(time.Millisecond)
+	flashSale.UpdatedAt = flashSale.UpdatedAt.Local().Truncate(time.Millisecond)
+}
+
+func (f *mongoDBFlashSaleRepository) Create(ctx context.Context, flashSale domain.FlashSale) (domain.FlashSale, error) {
+	flashSale.CreatedAt = time.Now().Truncate(time.Millisecond)
+	flashSale.UpdatedAt = time.Now().Truncate(time.Millisecond)
+
+	_, err := f.db.Collection(f.collectionName).InsertOne(ctx, flashSale)
+	if err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY FLASH SALE CREATE:  %#v \n", err)
+		return flashSale, usecase_error.ErrInternalServerError
+	}
+	f.convertToLocalTime(&flashSale)
+	return flashSale, nil
+}
+
+func (f *mongoDBFlashSaleRepository) Fetch(ctx context.Context, searchOptions domain.FlashSaleSearchOptions) ([]domain.FlashSale, error) {
+	query := bson.M{}
+	if searchOptions.ProductID != "" {
+		query["product._id"] = searchOptions.ProductID
+	}
+	if searchOptions.MerchantID != "" {
+		query["merchant_id"] = searchOptions.MerchantID
+	}
+	if !searchOptions.EndAfter.IsZero() {
+		query["end_at"] = bson.M{"$gt": searchOptions.EndAfter}
+	}
+	opt := options.Find().SetSort(bson.M{"start_at": 1})
+
+	flashSales := []domain.FlashSale{}
+	cur, err := f.db.Collection(f.collectionName).Find(ctx, query, opt)
+	if err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY FLASH SALE FETCH:  %#v \n", err)
+		return flashSales, usecase_error.ErrInternalServerError
+	}
+
+	for cur.Next(ctx) {
+		var flashSale domain.FlashSale
+		if err := cur.Decode(&flashSale); err != nil {
+			fmt.Printf("[REPOSITORY] REPOSITORY FLASH SALE LOOP:  %#v \n", err)
+			if err == mongo.ErrNilCursor {
+				return flashSales, nil
+			}
+
+			return flashSales, usecase_error.ErrInternalServerError
+		}
+		f.convertToLocalTime(&flashSale)
+		flashSales = append(flashSales, flashSale)
+	}
+
+	return flashSales, nil
+}
+
+func (f *mongoDBFlashSaleRepository) GetByID(ctx context.Context, id string) (domain.FlashSale, error) {
+	query := bson.M{"_id": id}
+
+	var flashSale domain.FlashSale
+	if err := f.db.Collection(f.collectionName).FindOne(ctx, query).Decode(&flashSale); err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY FLASH SALE GET BY ID:  %#v \n", err)
+		if err == mongo.ErrNoDocuments {
+			return flashSale, usecase_error.ErrNotFound
+		}
+		return flashSale, usecase_error.ErrInternalServerError
+	}
+	f.convertToLocalTime(&flashSale)
+	return flashSale, nil
+}
+
+func (f *mongoDBFlashSaleRepository) DeleteOne(ctx context.Context, flashSale domain.FlashSale) (domain.FlashSale, error) {
+	query := bson.M{"_id": flashSale.ID}
+
+	var deletedFlashSale domain.FlashSale
+	if err := f.db.Collection(f.collectionName).FindOneAndDelete(ctx, query).Decode(&deletedFlashSale); err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY FLASH SALE DELETE ONE:  %#v \n", err)
+		if err == mongo.ErrNoDocuments {
+			return deletedFlashSale, usecase_error.ErrNotFound
+		}
+
+		return deletedFlashSale, usecase_error.ErrInternalServerError
+	}
+	f.convertToLocalTime(&deletedFlashSale)
+	return deletedFlashSale, nil
+}
+
+func (f *mongoDBFlashSaleRepository) Reserve(ctx context.Context, flashSaleID string, customerID string, quantity int64, at time.Time) (domain.FlashSale, error) {
+	purchase := "purchases." + customerID
+	//quota and limit are compared with stored values in the same update, so concurrent checkouts could not oversell
+	query := bson.M{
+		"_id":      flashSaleID,
+		"start_at": bson.M{"$lte": at},
+		"end_at":   bson.M{"$gt": at},
+		"$expr": bson.M{
+			"$and": bson.A{
+				bson.M{"$lte": bson.A{bson.M{"$add": bson.A{"$sold", quantity}}, "$quota"}},
+				bson.M{"$lte": bson.A{bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$" + purchase, 0}}, quantity}}, "$limit_per_customer"}},
+			},
+		},
+	}
+	data := bson.M{
+		"$inc": bson.M{
+			"sold":   quantity,
+			purchase: quantity,
+		},
+	}
+	opt := options.FindOneAndUpdate().SetReturnDocument(options.ReturnDocument(1))
+
+	var flashSale domain.FlashSale
+	if err := f.db.Collection(f.collectionName).FindOneAndUpdate(ctx, query, data, opt).Decode(&flashSale); err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY FLASH SALE RESERVE:  %#v \n", err)
+		if err == mongo.ErrNoDocuments {
+			return flashSale, usecase_error.ErrNotFound
+		}
+		return flashSale, usecase_error.ErrInternalServerError
+	}
+	f.convertToLocalTime(&flashSale)
+	return flashSale, nil
+}
+
+func (f *mongoDBFlashSaleRepository) Release(ctx context.Context, flashSaleID string, customerID string, quantity int64) (domain.FlashSale, error) {
+	purchase := "purchases." + customerID
+	query := bson.M{
+		"_id":    flashSaleID,
+		purchase: bson.M{"$gte": quantity},
+	}
+	data := bson.M{
+		"$inc": bson.M{
+			"sold":   -quantity,
+			purchase: -quantity,
+		},
+	}
+	opt := options.FindOneAndUpdate().SetReturnDocument(options.ReturnDocument(1))
+
+	var flashSale domain.FlashSale
+	if err := f.db.Collection(f.collectionName).FindOneAndUpdate(ctx, query, data, opt).Decode(&flashSale); err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY FLASH SALE RELEASE:  %#v \n", err)
+		if err == mongo.ErrNoDocuments {
+			return flashSale, usecase_error.ErrNotFound
+		}
+		return flashSale, usecase_error.ErrInternalServerError
+	}
+	f.convertToLocalTime(&flashSale)
+	return flashSale, nil
+}
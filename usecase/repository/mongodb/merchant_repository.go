@@ -161,6 +161,30 @@ func (m *mongoDBMerchantRepository) UpdateOne(ctx context.Context, merchant doma
 	return updatedMerchant, nil
 }
 
+func (m *mongoDBMerchantRepository) UpdateSuspension(ctx context.Context, id string, suspension domain.Suspension) (domain.Merchant, error) {
+	query := bson.M{"_id": id}
+	data := bson.M{
+		"$set": bson.M{
+			"suspension": suspension,
+			"updated_at": time.Now().Truncate(time.Millisecond),
+		},
+	}
+	opt := options.FindOneAndUpdate().SetReturnDocument(options.ReturnDocument(1))
+
+	var updatedMerchant domain.Merchant
+	if err := m.db.Collection(m.collectionName).FindOneAndUpdate(ctx, query, data, opt).Decode(&updatedMerchant); err != nil {
+		fmt.Printf("[REPOSITORY] MERCHANT UPDATE SUSPENSION:  %#v \n", err)
+		if err == mongo.ErrNoDocuments {
+			return updatedMerchant, usecase_error.ErrNotFound
+		}
+
+		return updatedMerchant, usecase_error.ErrInternalServerError
+	}
+
+	m.convertToLocalTime(&updatedMerchant)
+	return updatedMerchant, nil
+}
+
 func (m *mongoDBMerchantRepository) DeleteOne(ctx context.Context, merchant domain.Merchant) (domain.Merchant, error) {
 	query := bson.M{"_id": merchant.ID}
 
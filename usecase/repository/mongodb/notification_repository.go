@@ -0,0 +1,130 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoDBNotificationRepository struct {
+	db             *mongo.Database
+	collectionName string
+}
+
+func NewNotificationRepository(db *mongo.Database) repository.NotificationRepository {
+	return &mongoDBNotificationRepository{
+		db:             db,
+		collectionName: "notifications",
+	}
+}
+
+func (n *mongoDBNotificationRepository) convertToLocalTime(notification *domain.Notification) {
+	notification.CreatedAt = notification.CreatedAt.Local().Truncate(time.Millisecond)
+}
+
+func (n *mongoDBNotificationRepository) CreateMany(ctx context.Context, notifications []domain.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	documents := []interface{}{}
+	for _, notification := range notifications {
+		notification.ID = primitive.NewObjectID().Hex()
+		notification.CreatedAt = time.Now().Truncate(time.Millisecond)
+		documents = append(documents, notification)
+	}
+
+	if _, err := n.db.Collection(n.collectionName).InsertMany(ctx, documents); err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY NOTIFICATION CREATE MANY:  %#v \n", err)
+		return usecase_error.ErrInternalServerError
+	}
+	return nil
+}
+
+// Fetch use last returned id as cursor, object id is ordered by creation time
+func (n *mongoDBNotificationRepository) Fetch(ctx context.Context, cursor string, num int64, optionsSearch domain.NotificationSearchOptions) ([]domain.Notification, error) {
+	var notifications []domain.Notification
+
+	query := bson.M{}
+	if optionsSearch.CustomerID != "" {
+		query["customer_id"] = optionsSearch.CustomerID
+	}
+	if optionsSearch.Unread {
+		query["read"] = false
+	}
+	if cursor != "" {
+		query["_id"] = bson.M{
+			"$lt": cursor,
+		}
+	}
+
+	cur, err := n.db.Collection(n.collectionName).Find(ctx, query,
+		options.Find().SetLimit(num),
+		options.Find().SetSort(bson.M{"_id": -1}),
+	)
+	if err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY NOTIFICATION FETCH:  %#v \n", err)
+		return notifications, usecase_error.ErrInternalServerError
+	}
+
+	for cur.Next(ctx) {
+		var notification domain.Notification
+		if err := cur.Decode(&notification); err != nil {
+			fmt.Printf("[DEBUG] REPOSITORY NOTIFICATION FETCH LOOP:  %#v \n", err)
+			if err == mongo.ErrNilCursor {
+				return notifications, nil
+			}
+
+			return notifications, usecase_error.ErrInternalServerError
+		}
+		n.convertToLocalTime(&notification)
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+func (n *mongoDBNotificationRepository) GetByID(ctx context.Context, id string) (domain.Notification, error) {
+	query := bson.M{"_id": id}
+
+	var notification domain.Notification
+	if err := n.db.Collection(n.collectionName).FindOne(ctx, query).Decode(&notification); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return notification, usecase_error.ErrNotFound
+		}
+		fmt.Printf("[DEBUG] REPOSITORY NOTIFICATION GET BY ID:  %#v \n", err)
+		return notification, usecase_error.ErrInternalServerError
+	}
+	n.convertToLocalTime(&notification)
+	return notification, nil
+}
+
+func (n *mongoDBNotificationRepository) MarkRead(ctx context.Context, id string) (domain.Notification, error) {
+	query := bson.M{"_id": id}
+	data := bson.M{
+		"$set": bson.M{
+			"read": true,
+		},
+	}
+
+	opt := options.FindOneAndUpdate().SetReturnDocument(options.ReturnDocument(1))
+
+	var notification domain.Notification
+	if err := n.db.Collection(n.collectionName).FindOneAndUpdate(ctx, query, data, opt).Decode(&notification); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return notification, usecase_error.ErrNotFound
+		}
+		fmt.Printf("[DEBUG] REPOSITORY NOTIFICATION MARK READ:  %#v \n", err)
+		return notification, usecase_error.ErrInternalServerError
+	}
+	n.convertToLocalTime(&notification)
+	return notification, nil
+}
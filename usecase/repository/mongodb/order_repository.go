@@ -159,6 +159,33 @@ func (o *mongoDBOrderRepository) UpdateOne(ctx context.Context, order domain.Ord
 	return updatedOrder, nil
 }
 
+func (o *mongoDBOrderRepository) UpdateStatus(ctx context.Context, orderID string, fromStatus []string, toStatus string) (domain.Order, error) {
+	query := bson.M{
+		"_id":          orderID,
+		"status_order": bson.M{"$in": fromStatus},
+	}
+	data := bson.M{
+		"$set": bson.M{
+			"status_order": toStatus,
+			"updated_at":   time.Now().Truncate(time.Millisecond),
+		},
+	}
+	opt := options.FindOneAndUpdate().SetReturnDocument(options.ReturnDocument(1))
+
+	var updatedOrder domain.Order
+	if err := o.db.Collection(o.collectionName).FindOneAndUpdate(ctx, query, data, opt).Decode(&updatedOrder); err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY ORDER UPDATE STATUS:  %#v \n", err)
+		if err == mongo.ErrNoDocuments {
+			return domain.Order{}, usecase_error.ErrNotFound
+		}
+
+		return domain.Order{}, usecase_error.ErrInternalServerError
+	}
+
+	o.convertToLocalTime(&updatedOrder)
+	return updatedOrder, nil
+}
+
 func (o *mongoDBOrderRepository) DeleteOne(ctx context.Context, order domain.Order) (domain.Order, error) {
 	query := bson.M{"_id": order.ID}
 
@@ -0,0 +1,93 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoDBProductImportJobRepository struct {
+	db             *mongo.Database
+	collectionName string
+}
+
+func NewProductImportJobRepository(db *mongo.Database) repository.ProductImportJobRepository {
+	return &mongoDBProductImportJobRepository{
+		db:             db,
+		collectionName: "product_import_jobs",
+	}
+}
+
+func (p *mongoDBProductImportJobRepository) convertToLocalTime(job *domain.ProductImportJob) {
+	job.CreatedAt = job.CreatedAt.Local().Truncate(time.Millisecond)
+	job.UpdatedAt = job.UpdatedAt.Local().Truncate(time.Millisecond)
+	job.FinishedAt = job.FinishedAt.Local().Truncate(time.Millisecond)
+}
+
+func (p *mongoDBProductImportJobRepository) Create(ctx context.Context, job domain.ProductImportJob) (domain.ProductImportJob, error) {
+	job.ID = primitive.NewObjectID().Hex()
+	job.CreatedAt = time.Now().Truncate(time.Millisecond)
+	job.UpdatedAt = time.Now().Truncate(time.Millisecond)
+
+	_, err := p.db.Collection(p.collectionName).InsertOne(ctx, job)
+	if err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY PRODUCT IMPORT JOB CREATE:  %#v \n", err)
+		return job, usecase_error.ErrInternalServerError
+	}
+	p.convertToLocalTime(&job)
+	return job, nil
+}
+
+func (p *mongoDBProductImportJobRepository) GetByID(ctx context.Context, id string) (domain.ProductImportJob, error) {
+	query := bson.M{"_id": id}
+
+	var job domain.ProductImportJob
+	if err := p.db.Collection(p.collectionName).FindOne(ctx, query).Decode(&job); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return job, usecase_error.ErrNotFound
+		}
+		fmt.Printf("[DEBUG] REPOSITORY PRODUCT IMPORT JOB GET BY ID:  %#v \n", err)
+		return job, usecase_error.ErrInternalServerError
+	}
+	p.convertToLocalTime(&job)
+	return job, nil
+}
+
+func (p *mongoDBProductImportJobRepository) UpdateOne(ctx context.Context, job domain.ProductImportJob) (domain.ProductImportJob, error) {
+	job.UpdatedAt = time.Now().Truncate(time.Millisecond)
+
+	query := bson.M{"_id": job.ID}
+	data := bson.M{
+		"$set": bson.M{
+			"status":      job.Status,
+			"total_rows":  job.TotalRows,
+			"created":     job.Created,
+			"updated":     job.Updated,
+			"failed":      job.Failed,
+			"errors":      job.Errors,
+			"updated_at":  job.UpdatedAt,
+			"finished_at": job.FinishedAt,
+		},
+	}
+
+	opt := options.FindOneAndUpdate().SetReturnDocument(options.ReturnDocument(1))
+
+	var updatedJob domain.ProductImportJob
+	if err := p.db.Collection(p.collectionName).FindOneAndUpdate(ctx, query, data, opt).Decode(&updatedJob); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return job, usecase_error.ErrNotFound
+		}
+		fmt.Printf("[DEBUG] REPOSITORY PRODUCT IMPORT JOB UPDATE ONE:  %#v \n", err)
+		return job, usecase_error.ErrInternalServerError
+	}
+	p.convertToLocalTime(&updatedJob)
+	return updatedJob, nil
+}
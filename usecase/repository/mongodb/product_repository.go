@@ -28,6 +28,22 @@ func NewProductRepository(db *mongo.Database) repository.ProductRepository {
 func (p *mongoDBProductRepository) convertToLocalTime(product *domain.Product) {
 	product.CreatedAt = product.CreatedAt.Local().Truncate(time.Millisecond)
 	product.UpdatedAt = product.UpdatedAt.Local().Truncate(time.Millisecond)
+	if product.DeletedAt != nil {
+		deletedAt := product.DeletedAt.Local().Truncate(time.Millisecond)
+		product.DeletedAt = &deletedAt
+	}
+	if product.PriceChangeAt != nil {
+		priceChangeAt := product.PriceChangeAt.Local().Truncate(time.Millisecond)
+		product.PriceChangeAt = &priceChangeAt
+	}
+	for i, discount := range product.Discounts {
+		product.Discounts[i].StartAt = discount.StartAt.Local().Truncate(time.Millisecond)
+		product.Discounts[i].EndAt = discount.EndAt.Local().Truncate(time.Millisecond)
+	}
+	for i, flashSale := range product.FlashSales {
+		product.FlashSales[i].StartAt = flashSale.StartAt.Local().Truncate(time.Millisecond)
+		product.FlashSales[i].EndAt = flashSale.EndAt.Local().Truncate(time.Millisecond)
+	}
 }
 
 func (p *mongoDBProductRepository) Create(ctx context.Context, product domain.Product) (domain.Product, error) {
@@ -45,6 +61,38 @@ func (p *mongoDBProductRepository) Create(ctx context.Context, product domain.Pr
 
 func (p *mongoDBProductRepository) Fetch(ctx context.Context, cursor string, num int64, options domain.ProductSearchOptions) ([]domain.Product, error) {
 	query := bson.M{}
+	if len(options.IDs) != 0 {
+		query["_id"] = bson.M{
+			"$in": options.IDs,
+		}
+	}
+	if len(options.SKUs) != 0 {
+		query["sku"] = bson.M{
+			"$in": options.SKUs,
+		}
+	}
+	if len(options.Statuses) != 0 {
+		statuses := bson.A{}
+		for _, status := range options.Statuses {
+			statuses = append(statuses, status)
+			if status == domain.PRODUCT_STATUS_ACTIVE {
+				statuses = append(statuses, "", nil)
+			}
+		}
+		query["status"] = bson.M{
+			"$in": statuses,
+		}
+	}
+	for field, value := range categoryPathQuery(options.CategoryPath) {
+		query[field] = value
+	}
+	if options.Deleted != nil {
+		if *options.Deleted {
+			query["deleted_at"] = bson.M{"$type": "date"}
+		} else {
+			query["deleted_at"] = nil
+		}
+	}
 	if options.Name != "" {
 		query["name"] = bson.M{
 			"$regex": options.Name,
@@ -65,6 +113,12 @@ func (p *mongoDBProductRepository) Fetch(ctx context.Context, cursor string, num
 			"$gte": options.Price,
 		}
 	}
+	if !options.PriceChangeBefore.IsZero() {
+		query["$or"] = bson.A{
+			bson.M{"price_change_at": bson.M{"$lte": options.PriceChangeBefore}},
+			bson.M{"discounted_price": bson.M{"$exists": false}},
+		}
+	}
 	if options.City != "" {
 		query["merchant.address.city"] = options.City
 	}
@@ -124,23 +178,35 @@ func (p *mongoDBProductRepository) UpdateOne(ctx context.Context, product domain
 	query := bson.M{"_id": product.ID}
 	data := bson.M{
 		"$set": bson.M{
-			"name":        product.Name,
-			"weight":      product.Weight,
-			"width":       product.Width,
-			"height":      product.Height,
-			"long":        product.Long,
-			"description": product.Description,
-			"category":    product.Category,
-			"tags":        product.Tags,
-			"etalase":     product.Etalase,
-			"colors":      product.Colors,
-			"sizes":       product.Sizes,
-			"photos":      product.Photos,
-			"price":       product.Price,
-			"stock":       product.Stock,
-			"merchant":    product.Merchant,
-			"rating":      product.Rating,
-			"num_review":  product.NumReview,
+			"sku":              product.SKU,
+			"name":             product.Name,
+			"weight":           product.Weight,
+			"width":            product.Width,
+			"height":           product.Height,
+			"long":             product.Long,
+			"description":      product.Description,
+			"category":         product.Category,
+			"tags":             product.Tags,
+			"etalase":          product.Etalase,
+			"colors":           product.Colors,
+			"sizes":            product.Sizes,
+			"photos":           product.Photos,
+			"price":            product.Price,
+			"stock":            product.Stock,
+			"variants":         product.Variants,
+			"attributes":       product.Attributes,
+			"discounts":        product.Discounts,
+			"flash_sales":      product.FlashSales,
+			"wholesales":       product.Wholesales,
+			"bundle_items":     product.BundleItems,
+			"discounted_price": product.DiscountedPrice,
+			"discount_percent": product.DiscountPercent,
+			"price_change_at":  product.PriceChangeAt,
+			"merchant":         product.Merchant,
+			"rating":           product.Rating,
+			"num_review":       product.NumReview,
+			"status":           product.Status,
+			"deleted_at":       product.DeletedAt,
 		},
 	}
 	opt := options.FindOneAndUpdate().SetReturnDocument(options.ReturnDocument(1))
@@ -158,6 +224,307 @@ func (p *mongoDBProductRepository) UpdateOne(ctx context.Context, product domain
 	return updatedProduct, nil
 }
 
+func (p *mongoDBProductRepository) IncrementStock(ctx context.Context, productID string, sku string, quantity int64) (domain.Product, error) {
+	query := bson.M{"_id": productID}
+	inc := bson.M{"stock": quantity}
+	if sku != "" {
+		variant := bson.M{"sku": sku}
+		if quantity < 0 {
+			variant["stock"] = bson.M{"$gte": -quantity}
+		}
+		query["variants"] = bson.M{"$elemMatch": variant}
+		inc["variants.$.stock"] = quantity
+	} else if quantity < 0 {
+		query["stock"] = bson.M{"$gte": -quantity}
+	}
+	data := bson.M{
+		"$inc": inc,
+		"$set": bson.M{
+			"updated_at": time.Now().Truncate(time.Millisecond),
+		},
+	}
+
+	opt := options.FindOneAndUpdate().SetReturnDocument(options.ReturnDocument(1))
+
+	var updatedProduct domain.Product
+	if err := p.db.Collection(p.collectionName).FindOneAndUpdate(ctx, query, data, opt).Decode(&updatedProduct); err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY PRODUCT INCREMENT STOCK:  %#v \n", err)
+		if err == mongo.ErrNoDocuments {
+			return updatedProduct, usecase_error.ErrNotFound
+		}
+
+		return updatedProduct, usecase_error.ErrInternalServerError
+	}
+	p.convertToLocalTime(&updatedProduct)
+	return updatedProduct, nil
+}
+
+func (p *mongoDBProductRepository) BulkUpdateStockPrice(ctx context.Context, merchantID string, updates []domain.ProductStockPriceUpdate) ([]error, error) {
+	errs := make([]error, len(updates))
+	if len(updates) == 0 {
+		return errs, nil
+	}
+
+	updatedAt := time.Now().Truncate(time.Millisecond)
+	models := []mongo.WriteModel{}
+	productIDs := []string{}
+	for _, update := range updates {
+		product := update.Product
+		productIDs = append(productIDs, product.ID)
+		query := bson.M{
+			"_id":          product.ID,
+			"merchant._id": merchantID,
+		}
+		set := bson.M{
+			"price":            product.Price,
+			"discounted_price": product.DiscountedPrice,
+			"discount_percent": product.DiscountPercent,
+			"price_change_at":  product.PriceChangeAt,
+			"updated_at":       updatedAt,
+		}
+		data := bson.M{"$set": set}
+		model := mongo.NewUpdateOneModel()
+
+		if len(product.Variants) == 0 {
+			set["stock"] = product.Stock
+		} else {
+			previousVariants := map[string]domain.Variant{}
+			for _, variant := range update.Previous.Variants {
+				previousVariants[variant.SKU] = variant
+			}
+
+			//only changed variants are set, stock of variant must be unchanged since read so total stock is kept right
+			conditions := bson.A{}
+			arrayFilters := []interface{}{}
+			for i, variant := range product.Variants {
+				previous := previousVariants[variant.SKU]
+				if variant.Stock == previous.Stock && variant.Price == previous.Price {
+					continue
+				}
+				identifier := fmt.Sprintf("v%d", i)
+				arrayFilters = append(arrayFilters, bson.M{identifier + ".sku": variant.SKU})
+				if variant.Price != previous.Price {
+					set["variants.$["+identifier+"].price"] = variant.Price
+				}
+				if variant.Stock != previous.Stock {
+					set["variants.$["+identifier+"].stock"] = variant.Stock
+					conditions = append(conditions, bson.M{
+						"variants": bson.M{"$elemMatch": bson.M{"sku": variant.SKU, "stock": previous.Stock}},
+					})
+				}
+			}
+			if len(conditions) != 0 {
+				query["$and"] = conditions
+			}
+			if product.Stock != update.Previous.Stock {
+				data["$inc"] = bson.M{"stock": product.Stock - update.Previous.Stock}
+			}
+			if len(arrayFilters) != 0 {
+				model.SetArrayFilters(options.ArrayFilters{Filters: arrayFilters})
+			}
+		}
+		models = append(models, model.SetFilter(query).SetUpdate(data))
+	}
+	opt := options.BulkWrite().SetOrdered(false)
+
+	result, err := p.db.Collection(p.collectionName).BulkWrite(ctx, models, opt)
+	if err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY PRODUCT BULK UPDATE STOCK PRICE:  %#v \n", err)
+		bulkErr, ok := err.(mongo.BulkWriteException)
+		if !ok {
+			return errs, usecase_error.ErrInternalServerError
+		}
+		for _, writeErr := range bulkErr.WriteErrors {
+			errs[writeErr.Index] = usecase_error.ErrInternalServerError
+		}
+	}
+
+	//bulk write only count matched documents, products which are not stamped with this update did not match
+	written := 0
+	for _, err := range errs {
+		if err == nil {
+			written++
+		}
+	}
+	if result != nil && result.MatchedCount == int64(written) {
+		return errs, nil
+	}
+	stored, err := p.Fetch(ctx, "", 0, domain.ProductSearchOptions{IDs: productIDs})
+	if err != nil {
+		return errs, err
+	}
+	stamped := map[string]bool{}
+	for _, product := range stored {
+		stamped[product.ID] = product.UpdatedAt.Equal(updatedAt)
+	}
+	for i, update := range updates {
+		if errs[i] == nil && !stamped[update.Product.ID] {
+			errs[i] = usecase_error.ErrNotFound
+		}
+	}
+	return errs, nil
+}
+
+func (p *mongoDBProductRepository) BulkUpdateDiscountedPrice(ctx context.Context, products []domain.Product, dueAt time.Time) ([]error, error) {
+	errs := make([]error, len(products))
+	if len(products) == 0 {
+		return errs, nil
+	}
+
+	models := []mongo.WriteModel{}
+	for _, product := range products {
+		query := bson.M{
+			"_id":   product.ID,
+			"price": product.Price,
+			"$or": bson.A{
+				bson.M{"price_change_at": bson.M{"$lte": dueAt}},
+				bson.M{"discounted_price": bson.M{"$exists": false}},
+			},
+		}
+		data := bson.M{
+			"$set": bson.M{
+				"discounted_price": product.DiscountedPrice,
+				"discount_percent": product.DiscountPercent,
+				"price_change_at":  product.PriceChangeAt,
+			},
+		}
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(query).SetUpdate(data))
+	}
+	opt := options.BulkWrite().SetOrdered(false)
+
+	_, err := p.db.Collection(p.collectionName).BulkWrite(ctx, models, opt)
+	if err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY PRODUCT BULK UPDATE DISCOUNTED PRICE:  %#v \n", err)
+		bulkErr, ok := err.(mongo.BulkWriteException)
+		if !ok {
+			return errs, usecase_error.ErrInternalServerError
+		}
+		for _, writeErr := range bulkErr.WriteErrors {
+			errs[writeErr.Index] = usecase_error.ErrInternalServerError
+		}
+	}
+	return errs, nil
+}
+
+func (p *mongoDBProductRepository) SetFlashSaleSoldOut(ctx context.Context, productID string, flashSaleID string, soldOut bool) error {
+	query := bson.M{
+		"_id":             productID,
+		"flash_sales._id": flashSaleID,
+	}
+	//price change at now let stored discounted price be refreshed without flash sale price
+	data := bson.M{
+		"$set": bson.M{
+			"flash_sales.$.sold_out": soldOut,
+			"price_change_at":        time.Now().Truncate(time.Millisecond),
+			"updated_at":             time.Now().Truncate(time.Millisecond),
+		},
+	}
+
+	result, err := p.db.Collection(p.collectionName).UpdateOne(ctx, query, data)
+	if err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY PRODUCT SET FLASH SALE SOLD OUT:  %#v \n", err)
+		return usecase_error.ErrInternalServerError
+	}
+	if result.MatchedCount == 0 {
+		return usecase_error.ErrNotFound
+	}
+	return nil
+}
+
+func (p *mongoDBProductRepository) UpdateFlashSales(ctx context.Context, product domain.Product) error {
+	query := bson.M{"_id": product.ID}
+	data := bson.M{
+		"$set": bson.M{
+			"flash_sales":      product.FlashSales,
+			"discounted_price": product.DiscountedPrice,
+			"discount_percent": product.DiscountPercent,
+			"price_change_at":  product.PriceChangeAt,
+			"updated_at":       time.Now().Truncate(time.Millisecond),
+		},
+	}
+
+	result, err := p.db.Collection(p.collectionName).UpdateOne(ctx, query, data)
+	if err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY PRODUCT UPDATE FLASH SALES:  %#v \n", err)
+		return usecase_error.ErrInternalServerError
+	}
+	if result.MatchedCount == 0 {
+		return usecase_error.ErrNotFound
+	}
+	return nil
+}
+
+func (p *mongoDBProductRepository) UpdateDiscounts(ctx context.Context, product domain.Product) (domain.Product, error) {
+	query := bson.M{"_id": product.ID}
+	data := bson.M{
+		"$set": bson.M{
+			"discounts":        product.Discounts,
+			"discounted_price": product.DiscountedPrice,
+			"discount_percent": product.DiscountPercent,
+			"price_change_at":  product.PriceChangeAt,
+			"updated_at":       time.Now().Truncate(time.Millisecond),
+		},
+	}
+	opt := options.FindOneAndUpdate().SetReturnDocument(options.ReturnDocument(1))
+
+	var updatedProduct domain.Product
+	if err := p.db.Collection(p.collectionName).FindOneAndUpdate(ctx, query, data, opt).Decode(&updatedProduct); err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY PRODUCT UPDATE DISCOUNTS:  %#v \n", err)
+		if err == mongo.ErrNoDocuments {
+			return product, usecase_error.ErrNotFound
+		}
+
+		return product, usecase_error.ErrInternalServerError
+	}
+	p.convertToLocalTime(&updatedProduct)
+	return updatedProduct, nil
+}
+
+func categoryPathQuery(path domain.Category) bson.M {
+	query := bson.M{}
+	if path.Top != "" {
+		query["category.top"] = path.Top
+	}
+	if path.SecondSub != "" {
+		query["category.second_sub"] = path.SecondSub
+	}
+	if path.ThirdSub != "" {
+		query["category.third_sub"] = path.ThirdSub
+	}
+	return query
+}
+
+func (p *mongoDBProductRepository) MoveCategory(ctx context.Context, from, to domain.Category) error {
+	query := categoryPathQuery(from)
+	set := categoryPathQuery(to)
+	set["updated_at"] = time.Now().Truncate(time.Millisecond)
+	data := bson.M{
+		"$set": set,
+	}
+
+	if _, err := p.db.Collection(p.collectionName).UpdateMany(ctx, query, data); err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY PRODUCT MOVE CATEGORY:  %#v \n", err)
+		return usecase_error.ErrInternalServerError
+	}
+	return nil
+}
+
+func (p *mongoDBProductRepository) UpdateMerchantSuspension(ctx context.Context, merchantID string, suspendedUntil time.Time) error {
+	query := bson.M{"merchant._id": merchantID}
+	data := bson.M{
+		"$set": bson.M{
+			"merchant.suspended_until": suspendedUntil,
+			"updated_at":               time.Now().Truncate(time.Millisecond),
+		},
+	}
+
+	if _, err := p.db.Collection(p.collectionName).UpdateMany(ctx, query, data); err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY PRODUCT UPDATE MERCHANT SUSPENSION:  %#v \n", err)
+		return usecase_error.ErrInternalServerError
+	}
+	return nil
+}
+
 func (p *mongoDBProductRepository) DeleteOne(ctx context.Context, product domain.Product) (domain.Product, error) {
 	query := bson.M{"_id": product.ID}
 
@@ -0,0 +1,177 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoDBSessionRepository struct {
+	db             *mongo.Database
+	collectionName string
+}
+
+func NewSessionRepository(db *mongo.Database) repository.SessionRepository {
+	return &mongoDBSessionRepository{
+		db:             db,
+		collectionName: "sessions",
+	}
+}
+
+func (s *mongoDBSessionRepository) convertToLocalTime(session *domain.Session) {
+	session.LastActivityAt = session.LastActivityAt.Local().Truncate(time.Millisecond)
+	session.RevokedAt = session.RevokedAt.Local().Truncate(time.Millisecond)
+	session.CreatedAt = session.CreatedAt.Local().Truncate(time.Millisecond)
+	session.UpdatedAt = session.UpdatedAt.Local().Truncate(time.Millisecond)
+}
+
+func (s *mongoDBSessionRepository) Create(ctx context.Context, session domain.Session) (domain.Session, error) {
+	session.CreatedAt = time.Now().Truncate(time.Millisecond)
+	session.UpdatedAt = time.Now().Truncate(time.Millisecond)
+
+	_, err := s.db.Collection(s.collectionName).InsertOne(ctx, session)
+	if err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY SESSION CREATE:  %#v \n", err)
+		return session, usecase_error.ErrInternalServerError
+	}
+	return session, nil
+}
+
+func (s *mongoDBSessionRepository) Fetch(ctx context.Context, cursor string, num int64, optionsSearch domain.SessionSearchOptions) ([]domain.Session, error) {
+	var sessions []domain.Session
+
+	query := bson.M{}
+	if optionsSearch.UserID != "" {
+		query["user_id"] = optionsSearch.UserID
+	}
+	if optionsSearch.LoginType != "" {
+		query["login_type"] = optionsSearch.LoginType
+	}
+	if optionsSearch.ActiveOnly {
+		query["revoked"] = false
+		query["last_activity_at"] = bson.M{
+			"$gt": time.Now().Add(-domain.SESSION_IDLE_DURATION).Truncate(time.Millisecond),
+		}
+	}
+	if cursor != "" {
+		last, err := time.Parse(time.RFC3339, cursor)
+		if err != nil {
+			fmt.Printf("[DEBUG] PARSE CURSOR:  %#v \n", err)
+			return sessions, usecase_error.ErrBadParamInput
+		}
+		query["created_at"] = bson.M{
+			"$lt": last.Truncate(time.Millisecond),
+		}
+	}
+
+	cur, err := s.db.Collection(s.collectionName).Find(ctx, query,
+		options.Find().SetLimit(num),
+		options.Find().SetSort(bson.M{"created_at": -1}),
+	)
+	if err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY SESSION FETCH:  %#v \n", err)
+		return sessions, usecase_error.ErrInternalServerError
+	}
+
+	for cur.Next(ctx) {
+		var session domain.Session
+		if err := cur.Decode(&session); err != nil {
+			fmt.Printf("[DEBUG] REPOSITORY SESSION FETCH LOOP:  %#v \n", err)
+			if err == mongo.ErrNilCursor {
+				return sessions, nil
+			}
+
+			return sessions, usecase_error.ErrInternalServerError
+		}
+		s.convertToLocalTime(&session)
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+func (s *mongoDBSessionRepository) GetByID(ctx context.Context, id string) (domain.Session, error) {
+	query := bson.M{"_id": id}
+
+	var session domain.Session
+	if err := s.db.Collection(s.collectionName).FindOne(ctx, query).Decode(&session); err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY SESSION GET BY ID:  %#v \n", err)
+		if err == mongo.ErrNoDocuments {
+			return session, usecase_error.ErrNotFound
+		}
+
+		return session, usecase_error.ErrInternalServerError
+	}
+	s.convertToLocalTime(&session)
+	return session, nil
+}
+
+func (s *mongoDBSessionRepository) UpdateOne(ctx context.Context, session domain.Session) (domain.Session, error) {
+	session.UpdatedAt = time.Now().Truncate(time.Millisecond)
+
+	query := bson.M{"_id": session.ID}
+	data := bson.M{
+		"$set": bson.M{
+			"last_activity_at": session.LastActivityAt,
+			"revoked":          session.Revoked,
+			"revoked_at":       session.RevokedAt,
+			"updated_at":       session.UpdatedAt,
+		},
+	}
+	opt := options.FindOneAndUpdate().SetReturnDocument(options.ReturnDocument(1))
+
+	var updatedSession domain.Session
+	if err := s.db.Collection(s.collectionName).FindOneAndUpdate(ctx, query, data, opt).Decode(&updatedSession); err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY SESSION UPDATE:  %#v \n", err)
+		if err == mongo.ErrNoDocuments {
+			return session, usecase_error.ErrNotFound
+		}
+
+		return session, usecase_error.ErrInternalServerError
+	}
+	s.convertToLocalTime(&updatedSession)
+	return updatedSession, nil
+}
+
+func (s *mongoDBSessionRepository) UpdateLastActivity(ctx context.Context, id string, lastActivityAt time.Time) error {
+	query := bson.M{"_id": id}
+	data := bson.M{
+		"$set": bson.M{
+			"last_activity_at": lastActivityAt.Truncate(time.Millisecond),
+		},
+	}
+
+	if _, err := s.db.Collection(s.collectionName).UpdateOne(ctx, query, data); err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY SESSION UPDATE LAST ACTIVITY:  %#v \n", err)
+		return usecase_error.ErrInternalServerError
+	}
+	return nil
+}
+
+func (s *mongoDBSessionRepository) RevokeAll(ctx context.Context, userID, loginType string, revokedAt time.Time) error {
+	query := bson.M{
+		"user_id":    userID,
+		"login_type": loginType,
+		"revoked":    false,
+	}
+	data := bson.M{
+		"$set": bson.M{
+			"revoked":    true,
+			"revoked_at": revokedAt.Truncate(time.Millisecond),
+			"updated_at": revokedAt.Truncate(time.Millisecond),
+		},
+	}
+
+	if _, err := s.db.Collection(s.collectionName).UpdateMany(ctx, query, data); err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY SESSION REVOKE ALL:  %#v \n", err)
+		return usecase_error.ErrInternalServerError
+	}
+	return nil
+}
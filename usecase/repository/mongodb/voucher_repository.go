@@ -0,0 +1,228 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoDBVoucherRepository struct {
+	db             *mongo.Database
+	collectionName string
+}
+
+func NewVoucherRepository(db *mongo.Database) repository.VoucherRepository {
+	return &mongoDBVoucherRepository{
+		db:             db,
+		collectionName: "vouchers",
+	}
+}
+
+func (v *mongoDBVoucherRepository) convertToLocalTime(voucher *domain.Voucher) {
+	voucher.StartAt = voucher.StartAt.Local().Truncate(time.Millisecond)
+	voucher.EndAt = voucher.EndAt.Local().Truncate(time.Millisecond)
+	voucher.CreatedAt = voucher.CreatedAt.Local().Truncate(time.Millisecond)
+	voucher.UpdatedAt = voucher.UpdatedAt.Local().Truncate(time.Millisecond)
+}
+
+// isDuplicateKeyError is true when write violates unique index, like code of voucher
+func isDuplicateKeyError(err error) bool {
+	const duplicateKeyCode = 11000
+	switch e := err.(type) {
+	case mongo.WriteException:
+		for _, writeErr := range e.WriteErrors {
+			if writeErr.Code == duplicateKeyCode {
+				return true
+			}
+		}
+	case mongo.CommandError:
+		return e.Code == duplicateKeyCode
+	}
+	return false
+}
+
+func (v *mongoDBVoucherRepository) Create(ctx context.Context, voucher domain.Voucher) (domain.Voucher, error) {
+	voucher.CreatedAt = time.Now().Truncate(time.Millisecond)
+	voucher.UpdatedAt = time.Now().Truncate(time.Millisecond)
+
+	_, err := v.db.Collection(v.collectionName).InsertOne(ctx, voucher)
+	if err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY VOUCHER CREATE:  %#v \n", err)
+		if isDuplicateKeyError(err) {
+			return voucher, usecase_error.ErrConflict
+		}
+		return voucher, usecase_error.ErrInternalServerError
+	}
+	v.convertToLocalTime(&voucher)
+	return voucher, nil
+}
+
+func (v *mongoDBVoucherRepository) Fetch(ctx context.Context, searchOptions domain.VoucherSearchOptions) ([]domain.Voucher, error) {
+	query := bson.M{}
+	if searchOptions.MerchantID != nil {
+		query["merchant_id"] = *searchOptions.MerchantID
+	}
+	if searchOptions.Code != "" {
+		query["code"] = searchOptions.Code
+	}
+	if !searchOptions.RunningAt.IsZero() {
+		query["start_at"] = bson.M{"$lte": searchOptions.RunningAt}
+		query["end_at"] = bson.M{"$gt": searchOptions.RunningAt}
+	}
+	opt := options.Find().SetSort(bson.M{"created_at": -1})
+
+	vouchers := []domain.Voucher{}
+	cur, err := v.db.Collection(v.collectionName).Find(ctx, query, opt)
+	if err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY VOUCHER FETCH:  %#v \n", err)
+		return vouchers, usecase_error.ErrInternalServerError
+	}
+
+	for cur.Next(ctx) {
+		var voucher domain.Voucher
+		if err := cur.Decode(&voucher); err != nil {
+			fmt.Printf("[REPOSITORY] REPOSITORY VOUCHER LOOP:  %#v \n", err)
+			if err == mongo.ErrNilCursor {
+				return vouchers, nil
+			}
+
+			return vouchers, usecase_error.ErrInternalServerError
+		}
+		v.convertToLocalTime(&voucher)
+		vouchers = append(vouchers, voucher)
+	}
+
+	return vouchers, nil
+}
+
+func (v *mongoDBVoucherRepository) GetByID(ctx context.Context, id string) (domain.Voucher, error) {
+	query := bson.M{"_id": id}
+
+	var voucher domain.Voucher
+	if err := v.db.Collection(v.collectionName).FindOne(ctx, query).Decode(&voucher); err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY VOUCHER GET BY ID:  %#v \n", err)
+		if err == mongo.ErrNoDocuments {
+			return voucher, usecase_error.ErrNotFound
+		}
+		return voucher, usecase_error.ErrInternalServerError
+	}
+	v.convertToLocalTime(&voucher)
+	return voucher, nil
+}
+
+// UpdateOne never change used and usages, they are only changed by Claim and Release
+func (v *mongoDBVoucherRepository) UpdateOne(ctx context.Context, voucher domain.Voucher) (domain.Voucher, error) {
+	voucher.UpdatedAt = time.Now().Truncate(time.Millisecond)
+
+	query := bson.M{"_id": voucher.ID}
+	data := bson.M{
+		"$set": bson.M{
+			"code":               voucher.Code,
+			"type":               voucher.Type,
+			"value":              voucher.Value,
+			"max_discount":       voucher.MaxDiscount,
+			"min_spend":          voucher.MinSpend,
+			"categories":         voucher.Categories,
+			"merchant_ids":       voucher.MerchantIDs,
+			"new_customer_only":  voucher.NewCustomerOnly,
+			"quota":              voucher.Quota,
+			"quota_per_customer": voucher.QuotaPerCustomer,
+			"start_at":           voucher.StartAt,
+			"end_at":             voucher.EndAt,
+			"updated_at":         voucher.UpdatedAt,
+		},
+	}
+	opt := options.FindOneAndUpdate().SetReturnDocument(options.ReturnDocument(1))
+
+	var updatedVoucher domain.Voucher
+	if err := v.db.Collection(v.collectionName).FindOneAndUpdate(ctx, query, data, opt).Decode(&updatedVoucher); err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY VOUCHER UPDATE ONE:  %#v \n", err)
+		if err == mongo.ErrNoDocuments {
+			return voucher, usecase_error.ErrNotFound
+		}
+		if isDuplicateKeyError(err) {
+			return voucher, usecase_error.ErrConflict
+		}
+
+		return voucher, usecase_error.ErrInternalServerError
+	}
+	v.convertToLocalTime(&updatedVoucher)
+	return updatedVoucher, nil
+}
+
+func (v *mongoDBVoucherRepository) DeleteOne(ctx context.Context, voucher domain.Voucher) (domain.Voucher, error) {
+	query := bson.M{"_id": voucher.ID}
+
+	var deletedVoucher domain.Voucher
+	if err := v.db.Collection(v.collectionName).FindOneAndDelete(ctx, query).Decode(&deletedVoucher); err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY VOUCHER DELETE ONE:  %#v \n", err)
+		if err == mongo.ErrNoDocuments {
+			return deletedVoucher, usecase_error.ErrNotFound
+		}
+
+		return deletedVoucher, usecase_error.ErrInternalServerError
+	}
+	v.convertToLocalTime(&deletedVoucher)
+	return deletedVoucher, nil
+}
+
+func (v *mongoDBVoucherRepository) Claim(ctx context.Context, voucherID string, customerID string) error {
+	usage := "usages." + customerID
+	//quota is compared with stored values, so concurrent claims could not exceed them
+	query := bson.M{
+		"_id": voucherID,
+		"$expr": bson.M{
+			"$and": bson.A{
+				bson.M{"$lt": bson.A{"$used", "$quota"}},
+				bson.M{"$lt": bson.A{bson.M{"$ifNull": bson.A{"$" + usage, 0}}, "$quota_per_customer"}},
+			},
+		},
+	}
+	data := bson.M{
+		"$inc": bson.M{
+			"used": 1,
+			usage:  1,
+		},
+	}
+
+	result, err := v.db.Collection(v.collectionName).UpdateOne(ctx, query, data)
+	if err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY VOUCHER CLAIM:  %#v \n", err)
+		return usecase_error.ErrInternalServerError
+	}
+	if result.MatchedCount == 0 {
+		return usecase_error.ErrNotFound
+	}
+	return nil
+}
+
+func (v *mongoDBVoucherRepository) Release(ctx context.Context, voucherID string, customerID string) error {
+	usage := "usages." + customerID
+	query := bson.M{
+		"_id": voucherID,
+		usage: bson.M{"$gt": 0},
+	}
+	data := bson.M{
+		"$inc": bson.M{
+			"used": -1,
+			usage:  -1,
+		},
+	}
+
+	result, err := v.db.Collection(v.collectionName).UpdateOne(ctx, query, data)
+	if err != nil {
+		fmt.Printf("[REPOSITORY] REPOSITORY VOUCHER RELEASE:  %#v \n", err)
+		return usecase_error.ErrInternalServerError
+	}
+	if result.MatchedCount == 0 {
+		return usecase_error.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoDBWishlistRepository struct {
+	db             *mongo.Database
+	collectionName string
+}
+
+func NewWishlistRepository(db *mongo.Database) repository.WishlistRepository {
+	return &mongoDBWishlistRepository{
+		db:             db,
+		collectionName: "wishlists",
+	}
+}
+
+func (w *mongoDBWishlistRepository) convertToLocalTime(item *domain.WishlistItem) {
+	item.CreatedAt = item.CreatedAt.Local().Truncate(time.Millisecond)
+	item.UpdatedAt = item.UpdatedAt.Local().Truncate(time.Millisecond)
+}
+
+func (w *mongoDBWishlistRepository) Create(ctx context.Context, item domain.WishlistItem) (domain.WishlistItem, error) {
+	item.ID = primitive.NewObjectID().Hex()
+	item.CreatedAt = time.Now().Truncate(time.Millisecond)
+	item.UpdatedAt = time.Now().Truncate(time.Millisecond)
+
+	_, err := w.db.Collection(w.collectionName).InsertOne(ctx, item)
+	if err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY WISHLIST CREATE:  %#v \n", err)
+		return item, usecase_error.ErrInternalServerError
+	}
+	w.convertToLocalTime(&item)
+	return item, nil
+}
+
+// Fetch use last returned id as cursor, zero num return all matched items
+func (w *mongoDBWishlistRepository) Fetch(ctx context.Context, cursor string, num int64, optionsSearch domain.WishlistSearchOptions) ([]domain.WishlistItem, error) {
+	var items []domain.WishlistItem
+
+	query := bson.M{}
+	if optionsSearch.CustomerID != "" {
+		query["customer_id"] = optionsSearch.CustomerID
+	}
+	if optionsSearch.ProductID != "" {
+		query["product._id"] = optionsSearch.ProductID
+	}
+	if cursor != "" {
+		query["_id"] = bson.M{
+			"$lt": cursor,
+		}
+	}
+
+	cur, err := w.db.Collection(w.collectionName).Find(ctx, query,
+		options.Find().SetLimit(num),
+		options.Find().SetSort(bson.M{"_id": -1}),
+	)
+	if err != nil {
+		fmt.Printf("[DEBUG] REPOSITORY WISHLIST FETCH:  %#v \n", err)
+		return items, usecase_error.ErrInternalServerError
+	}
+
+	for cur.Next(ctx) {
+		var item domain.WishlistItem
+		if err := cur.Decode(&item); err != nil {
+			fmt.Printf("[DEBUG] REPOSITORY WISHLIST FETCH LOOP:  %#v \n", err)
+			if err == mongo.ErrNilCursor {
+				return items, nil
+			}
+
+			return items, usecase_error.ErrInternalServerError
+		}
+		w.convertToLocalTime(&item)
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (w *mongoDBWishlistRepository) DeleteOne(ctx context.Context, item domain.WishlistItem) (domain.WishlistItem, error) {
+	query := bson.M{"_id": item.ID}
+
+	var deletedItem domain.WishlistItem
+	if err := w.db.Collection(w.collectionName).FindOneAndDelete(ctx, query).Decode(&deletedItem); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return item, usecase_error.ErrNotFound
+		}
+		fmt.Printf("[DEBUG] REPOSITORY WISHLIST DELETE ONE:  %#v \n", err)
+		return item, usecase_error.ErrInternalServerError
+	}
+
+	w.convertToLocalTime(&deletedItem)
+	return deletedItem, nil
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/market-place/domain"
+)
+
+type NotificationRepository interface {
+	CreateMany(ctx context.Context, notifications []domain.Notification) error
+	Fetch(ctx context.Context, cursor string, num int64, options domain.NotificationSearchOptions) ([]domain.Notification, error)
+	GetByID(ctx context.Context, id string) (domain.Notification, error)
+	MarkRead(ctx context.Context, id string) (domain.Notification, error)
+}
@@ -0,0 +1,334 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+const defaultScopes = "openid email profile"
+
+type providerConfig struct {
+	name         string
+	issuer       string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	scopes       string
+}
+
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type oidcProviderRepo struct {
+	client    *http.Client
+	providers map[string]providerConfig
+
+	mu       sync.Mutex
+	metadata map[string]providerMetadata
+	keys     map[string]map[string]*rsa.PublicKey
+}
+
+// NewOIDCProviderRepo read provider from env :
+// OIDC_PROVIDERS (comma separated name), then for every name
+// OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID, OIDC_<NAME>_CLIENT_SECRET,
+// OIDC_<NAME>_REDIRECT_URL, OIDC_<NAME>_SCOPES (optional).
+// Endpoint is discovered from issuer, so local stub provider can be used by changing issuer
+func NewOIDCProviderRepo() repository.OIDCProviderRepository {
+	providers := map[string]providerConfig{}
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		config := providerConfig{
+			name:         name,
+			issuer:       strings.TrimSuffix(os.Getenv(prefix+"ISSUER"), "/"),
+			clientID:     os.Getenv(prefix + "CLIENT_ID"),
+			clientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			redirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			scopes:       os.Getenv(prefix + "SCOPES"),
+		}
+		if config.scopes == "" {
+			config.scopes = defaultScopes
+		}
+		if config.issuer == "" || config.clientID == "" {
+			fmt.Printf("[DEBUG] : OIDC PROVIDER REPO SKIP PROVIDER %#v \n", name)
+			continue
+		}
+		providers[name] = config
+	}
+
+	return &oidcProviderRepo{
+		client:    &http.Client{Timeout: 5 * time.Second},
+		providers: providers,
+		metadata:  map[string]providerMetadata{},
+		keys:      map[string]map[string]*rsa.PublicKey{},
+	}
+}
+
+func (o *oidcProviderRepo) IsRegistered(provider string) bool {
+	_, ok := o.providers[provider]
+	return ok
+}
+
+func (o *oidcProviderRepo) AuthorizationURL(ctx context.Context, request domain.OIDCAuthRequest, codeChallenge string) (string, error) {
+	config, ok := o.providers[request.Provider]
+	if !ok {
+		return "", usecase_error.ErrNotFound
+	}
+	metadata, err := o.getMetadata(ctx, config)
+	if err != nil {
+		fmt.Printf("[DEBUG] : OIDC PROVIDER REPO DISCOVERY %#v \n", err)
+		return "", usecase_error.ErrInternalServerError
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", config.clientID)
+	query.Set("redirect_uri", config.redirectURL)
+	query.Set("scope", config.scopes)
+	query.Set("state", request.State)
+	query.Set("nonce", request.Nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(metadata.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return metadata.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+func (o *oidcProviderRepo) Exchange(ctx context.Context, provider, code, codeVerifier string) (domain.OIDCIdentity, error) {
+	var identity domain.OIDCIdentity
+	config, ok := o.providers[provider]
+	if !ok {
+		return identity, usecase_error.ErrNotFound
+	}
+	metadata, err := o.getMetadata(ctx, config)
+	if err != nil {
+		fmt.Printf("[DEBUG] : OIDC PROVIDER REPO DISCOVERY %#v \n", err)
+		return identity, usecase_error.ErrInternalServerError
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", config.redirectURL)
+	form.Set("client_id", config.clientID)
+	form.Set("code_verifier", codeVerifier)
+	if config.clientSecret != "" {
+		form.Set("client_secret", config.clientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		fmt.Printf("[DEBUG] : OIDC PROVIDER REPO TOKEN REQUEST %#v \n", err)
+		return identity, usecase_error.ErrInternalServerError
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := o.client.Do(req)
+	if err != nil {
+		fmt.Printf("[DEBUG] : OIDC PROVIDER REPO TOKEN REQUEST %#v \n", err)
+		return identity, usecase_error.ErrInternalServerError
+	}
+	defer res.Body.Close()
+
+	payload := struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		fmt.Printf("[DEBUG] : OIDC PROVIDER REPO TOKEN RESPONSE %#v \n", err)
+		return identity, usecase_error.ErrInternalServerError
+	}
+	//code rejected by provider : expired, used twice or verifier not match
+	if res.StatusCode != http.StatusOK || payload.IDToken == "" {
+		fmt.Printf("[DEBUG] : OIDC PROVIDER REPO TOKEN REJECTED %#v \n", payload.Error+" "+payload.ErrorDescription)
+		return identity, usecase_error.ErrNotAuthentication
+	}
+
+	return o.verifyIDToken(ctx, config, metadata, payload.IDToken)
+}
+
+func (o *oidcProviderRepo) verifyIDToken(ctx context.Context, config providerConfig, metadata providerMetadata, idToken string) (domain.OIDCIdentity, error) {
+	var identity domain.OIDCIdentity
+
+	token, err := jwt.Parse(idToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, errors.New("Token method is not match")
+		}
+		kid, _ := token.Header["kid"].(string)
+		return o.getKey(ctx, config, metadata, kid)
+	})
+	if err != nil {
+		fmt.Printf("[DEBUG] : OIDC PROVIDER REPO VERIFY ID TOKEN %#v \n", err)
+		return identity, usecase_error.ErrNotAuthentication
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return identity, usecase_error.ErrNotAuthentication
+	}
+	if _, ok := claims["exp"]; !ok {
+		fmt.Printf("[DEBUG] : OIDC PROVIDER REPO VERIFY ID TOKEN %#v \n", "EXP NOT FOUND")
+		return identity, usecase_error.ErrNotAuthentication
+	}
+	if issuer, _ := claims["iss"].(string); issuer != metadata.Issuer {
+		fmt.Printf("[DEBUG] : OIDC PROVIDER REPO VERIFY ID TOKEN %#v \n", "ISSUER NOT MATCH")
+		return identity, usecase_error.ErrNotAuthentication
+	}
+	if !audienceContains(claims["aud"], config.clientID) {
+		fmt.Printf("[DEBUG] : OIDC PROVIDER REPO VERIFY ID TOKEN %#v \n", "AUDIENCE NOT MATCH")
+		return identity, usecase_error.ErrNotAuthentication
+	}
+
+	identity.Provider = config.name
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Email, _ = claims["email"].(string)
+	identity.Name, _ = claims["name"].(string)
+	identity.Picture, _ = claims["picture"].(string)
+	identity.Nonce, _ = claims["nonce"].(string)
+	//email without the claim is not trusted, otherwise anyone could register with email of other person
+	identity.EmailVerified, _ = claims["email_verified"].(bool)
+	if identity.Subject == "" {
+		fmt.Printf("[DEBUG] : OIDC PROVIDER REPO VERIFY ID TOKEN %#v \n", "SUBJECT EMPTY")
+		return identity, usecase_error.ErrNotAuthentication
+	}
+
+	return identity, nil
+}
+
+func audienceContains(aud interface{}, clientID string) bool {
+	switch value := aud.(type) {
+	case string:
+		return value == clientID
+	case []interface{}:
+		for _, v := range value {
+			if s, _ := v.(string); s == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (o *oidcProviderRepo) getMetadata(ctx context.Context, config providerConfig) (providerMetadata, error) {
+	o.mu.Lock()
+	metadata, ok := o.metadata[config.name]
+	o.mu.Unlock()
+	if ok {
+		return metadata, nil
+	}
+
+	if err := o.getJSON(ctx, config.issuer+"/.well-known/openid-configuration", &metadata); err != nil {
+		return metadata, err
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return metadata, errors.New("Provider metadata is not complete")
+	}
+	if metadata.Issuer == "" {
+		metadata.Issuer = config.issuer
+	}
+
+	o.mu.Lock()
+	o.metadata[config.name] = metadata
+	o.mu.Unlock()
+	return metadata, nil
+}
+
+// getKey look up signing key by kid, key set is fetched again when kid is unknown because provider rotate key
+func (o *oidcProviderRepo) getKey(ctx context.Context, config providerConfig, metadata providerMetadata, kid string) (*rsa.PublicKey, error) {
+	o.mu.Lock()
+	key, ok := o.keys[config.name][kid]
+	o.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	payload := struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}{}
+	if err := o.getJSON(ctx, metadata.JWKSURI, &payload); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, jwk := range payload.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	o.mu.Lock()
+	o.keys[config.name] = keys
+	o.mu.Unlock()
+
+	key, ok = keys[kid]
+	if !ok {
+		return nil, errors.New("Signing key is not found")
+	}
+	return key, nil
+}
+
+func (o *oidcProviderRepo) getJSON(ctx context.Context, url string, data interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("Request %s return status %d", url, res.StatusCode)
+	}
+	return json.NewDecoder(res.Body).Decode(data)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/market-place/domain"
+)
+
+type OIDCProviderRepository interface {
+	IsRegistered(provider string) bool
+	AuthorizationURL(ctx context.Context, request domain.OIDCAuthRequest, codeChallenge string) (string, error)
+	// Exchange trade authorization code for tokens and return claims of verified id token
+	Exchange(ctx context.Context, provider, code, codeVerifier string) (domain.OIDCIdentity, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/market-place/domain"
+)
+
+type OIDCStateRepository interface {
+	SaveAuthRequest(ctx context.Context, request domain.OIDCAuthRequest) error
+	// TakeAuthRequest return and delete the request, so one state can only be used once
+	TakeAuthRequest(ctx context.Context, state string) (domain.OIDCAuthRequest, error)
+}
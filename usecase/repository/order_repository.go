@@ -11,6 +11,9 @@ type OrderRepository interface {
 	Fetch(ctx context.Context, cursor string, num int64, options domain.OrderSearchOptions) ([]domain.Order, error)
 	GetByID(ctx context.Context, id string) (domain.Order, error)
 	UpdateOne(ctx context.Context, order domain.Order) (domain.Order, error)
+	// UpdateStatus change status of order only when its current status is one of fromStatus,
+	// ErrNotFound when order does not exist or its status is not one of them anymore
+	UpdateStatus(ctx context.Context, orderID string, fromStatus []string, toStatus string) (domain.Order, error)
 	DeleteOne(ctx context.Context, order domain.Order) (domain.Order, error)
 	DeleteAll(ctx context.Context) error
 	EstimasiPendapatan(ctx context.Context, merchantID string, startDay string, endDay string) ([]map[string]interface{}, error)
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/market-place/domain"
+)
+
+type PhoneOTPRepository interface {
+	Save(ctx context.Context, otp domain.PhoneOTP) error
+	GetByCustomerID(ctx context.Context, customerID string) (domain.PhoneOTP, error)
+	// IncrementAttempts return attempts after increment, counted atomically so parallel guess is also limited
+	IncrementAttempts(ctx context.Context, customerID string) (int64, error)
+	Delete(ctx context.Context, customerID string) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/market-place/domain"
+)
+
+type ProductImportJobRepository interface {
+	Create(ctx context.Context, job domain.ProductImportJob) (domain.ProductImportJob, error)
+	GetByID(ctx context.Context, id string) (domain.ProductImportJob, error)
+	UpdateOne(ctx context.Context, job domain.ProductImportJob) (domain.ProductImportJob, error)
+}
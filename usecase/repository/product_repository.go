@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/market-place/domain"
 )
@@ -11,6 +12,28 @@ type ProductRepository interface {
 	Fetch(ctx context.Context, cursor string, num int64, options domain.ProductSearchOptions) ([]domain.Product, error)
 	GetByID(ctx context.Context, id string) (domain.Product, error)
 	UpdateOne(ctx context.Context, product domain.Product) (domain.Product, error)
+	// UpdateMerchantSuspension set suspension end of merchant denormalization in all merchant's products
+	UpdateMerchantSuspension(ctx context.Context, merchantID string, suspendedUntil time.Time) error
+	// IncrementStock add quantity to stock of product or its variant sku and to product total stock,
+	// negative quantity only succeed when stock is enough, otherwise ErrNotFound. Returned product is the incremented one
+	IncrementStock(ctx context.Context, productID string, sku string, quantity int64) (domain.Product, error)
+	// BulkUpdateStockPrice save price, discounted price, stock and changed variants of merchant's products in one bulk write,
+	// variant is only written when its stock is still the previous one, otherwise the product is not saved.
+	// returned errors are in same order as updates, nil when product is saved
+	BulkUpdateStockPrice(ctx context.Context, merchantID string, updates []domain.ProductStockPriceUpdate) ([]error, error)
+	// BulkUpdateDiscountedPrice only save discounted price, discount percent and price change at, so stock is never touched.
+	// Product is skipped when its price changed or its price change at is not due at dueAt anymore, because other update already refreshed it
+	BulkUpdateDiscountedPrice(ctx context.Context, products []domain.Product, dueAt time.Time) ([]error, error)
+	// SetFlashSaleSoldOut mark flash sale of product, stored discounted price is refreshed afterward
+	SetFlashSaleSoldOut(ctx context.Context, productID string, flashSaleID string, soldOut bool) error
+	// UpdateFlashSales only save flash sales, discounted price, discount percent and price change at of product,
+	// so stock and variants changed by orders meanwhile are kept
+	UpdateFlashSales(ctx context.Context, product domain.Product) error
+	// UpdateDiscounts only save discounts, discounted price, discount percent and price change at of product,
+	// returned product is the stored one so its stock is current
+	UpdateDiscounts(ctx context.Context, product domain.Product) (domain.Product, error)
+	// MoveCategory replace category path prefix "from" of products with "to", levels which are empty in "from" are kept
+	MoveCategory(ctx context.Context, from, to domain.Category) error
 	DeleteOne(ctx context.Context, product domain.Product) (domain.Product, error)
 	DeleteAll(ctx context.Context) error
 }
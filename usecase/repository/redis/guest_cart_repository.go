@@ -0,0 +1,75 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type guestCartRepo struct {
+	db           *redis.Client
+	guestCartKey string
+}
+
+func NewGuestCartRepo(db *redis.Client) repository.GuestCartRepository {
+	return &guestCartRepo{
+		db:           db,
+		guestCartKey: "guest_cart",
+	}
+}
+
+func (g *guestCartRepo) key(id string) string {
+	return fmt.Sprintf("%s:%s", g.guestCartKey, id)
+}
+
+func (g *guestCartRepo) Save(ctx context.Context, cart domain.Cart) (domain.Cart, error) {
+	cart.UpdatedAt = time.Now().Truncate(time.Millisecond)
+	//ttl is not extended by update, cart expire together with its token
+	ttl := time.Until(cart.CreatedAt.Add(domain.GUEST_CART_DURATION))
+	if ttl <= 0 {
+		return cart, usecase_error.ErrNotFound
+	}
+
+	data, err := json.Marshal(cart)
+	if err != nil {
+		fmt.Printf("[DEBUG] : GUEST CART REPO SAVE %#v \n", err)
+		return cart, usecase_error.ErrInternalServerError
+	}
+	if err := g.db.Set(ctx, g.key(cart.ID), data, ttl).Err(); err != nil {
+		fmt.Printf("[DEBUG] : GUEST CART REPO SAVE %#v \n", err)
+		return cart, usecase_error.ErrInternalServerError
+	}
+	return cart, nil
+}
+
+func (g *guestCartRepo) GetByID(ctx context.Context, id string) (domain.Cart, error) {
+	var cart domain.Cart
+	data, err := g.db.Get(ctx, g.key(id)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return cart, usecase_error.ErrNotFound
+		}
+		fmt.Printf("[DEBUG] : GUEST CART REPO GET BY ID %#v \n", err)
+		return cart, usecase_error.ErrInternalServerError
+	}
+
+	if err := json.Unmarshal([]byte(data), &cart); err != nil {
+		fmt.Printf("[DEBUG] : GUEST CART REPO GET BY ID %#v \n", err)
+		return cart, usecase_error.ErrInternalServerError
+	}
+	return cart, nil
+}
+
+func (g *guestCartRepo) Delete(ctx context.Context, id string) error {
+	if err := g.db.Del(ctx, g.key(id)).Err(); err != nil {
+		fmt.Printf("[DEBUG] : GUEST CART REPO DELETE %#v \n", err)
+		return usecase_error.ErrInternalServerError
+	}
+	return nil
+}
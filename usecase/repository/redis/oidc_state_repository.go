@@ -0,0 +1,61 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+type oidcStateRepo struct {
+	db             *redis.Client
+	authRequestKey string
+}
+
+func NewOIDCStateRepo(db *redis.Client) repository.OIDCStateRepository {
+	return &oidcStateRepo{
+		db:             db,
+		authRequestKey: "oidc_auth_request",
+	}
+}
+
+func (o *oidcStateRepo) SaveAuthRequest(ctx context.Context, request domain.OIDCAuthRequest) error {
+	data, err := json.Marshal(request)
+	if err != nil {
+		fmt.Printf("[DEBUG] : OIDC STATE REPO SAVE AUTH REQUEST %#v \n", err)
+		return usecase_error.ErrInternalServerError
+	}
+
+	key := fmt.Sprintf("%s:%s", o.authRequestKey, request.State)
+	if err := o.db.Set(ctx, key, data, domain.OIDC_AUTH_REQUEST_DURATION).Err(); err != nil {
+		fmt.Printf("[DEBUG] : OIDC STATE REPO SAVE AUTH REQUEST %#v \n", err)
+		return usecase_error.ErrInternalServerError
+	}
+	return nil
+}
+
+func (o *oidcStateRepo) TakeAuthRequest(ctx context.Context, state string) (domain.OIDCAuthRequest, error) {
+	var request domain.OIDCAuthRequest
+	key := fmt.Sprintf("%s:%s", o.authRequestKey, state)
+
+	pipe := o.db.TxPipeline()
+	get := pipe.Get(ctx, key)
+	pipe.Del(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		if err == redis.Nil {
+			return request, usecase_error.ErrNotFound
+		}
+		fmt.Printf("[DEBUG] : OIDC STATE REPO TAKE AUTH REQUEST %#v \n", err)
+		return request, usecase_error.ErrInternalServerError
+	}
+
+	if err := json.Unmarshal([]byte(get.Val()), &request); err != nil {
+		fmt.Printf("[DEBUG] : OIDC STATE REPO TAKE AUTH REQUEST %#v \n", err)
+		return request, usecase_error.ErrInternalServerError
+	}
+	return request, nil
+}
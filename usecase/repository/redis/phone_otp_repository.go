@@ -0,0 +1,97 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+// increment only existing otp, HINCRBY alone would recreate expired key without ttl
+var incrementAttemptsScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return -1
+end
+return redis.call("HINCRBY", KEYS[1], "attempts", 1)
+`)
+
+type phoneOTPRepo struct {
+	db     *redis.Client
+	otpKey string
+}
+
+func NewPhoneOTPRepo(db *redis.Client) repository.PhoneOTPRepository {
+	return &phoneOTPRepo{
+		db:     db,
+		otpKey: "phone_otp",
+	}
+}
+
+func (p *phoneOTPRepo) key(customerID string) string {
+	return fmt.Sprintf("%s:%s", p.otpKey, customerID)
+}
+
+func (p *phoneOTPRepo) Save(ctx context.Context, otp domain.PhoneOTP) error {
+	key := p.key(otp.CustomerID)
+
+	pipe := p.db.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.HSet(ctx, key,
+		"phone", otp.Phone,
+		"hashed_code", otp.HashedCode,
+		"attempts", otp.Attempts,
+		"sent_at", otp.SentAt.Format(time.RFC3339),
+	)
+	pipe.Expire(ctx, key, domain.PHONE_OTP_DURATION)
+	if _, err := pipe.Exec(ctx); err != nil {
+		fmt.Printf("[DEBUG] : PHONE OTP REPO SAVE %#v \n", err)
+		return usecase_error.ErrInternalServerError
+	}
+	return nil
+}
+
+func (p *phoneOTPRepo) GetByCustomerID(ctx context.Context, customerID string) (domain.PhoneOTP, error) {
+	otp := domain.PhoneOTP{}
+
+	data, err := p.db.HGetAll(ctx, p.key(customerID)).Result()
+	if err != nil {
+		fmt.Printf("[DEBUG] : PHONE OTP REPO GET %#v \n", err)
+		return otp, usecase_error.ErrInternalServerError
+	}
+	if len(data) == 0 {
+		return otp, usecase_error.ErrNotFound
+	}
+
+	otp.CustomerID = customerID
+	otp.Phone = data["phone"]
+	otp.HashedCode = data["hashed_code"]
+	otp.Attempts, _ = strconv.ParseInt(data["attempts"], 10, 64)
+	otp.SentAt, _ = time.Parse(time.RFC3339, data["sent_at"])
+	otp.SentAt = otp.SentAt.Local()
+	return otp, nil
+}
+
+func (p *phoneOTPRepo) IncrementAttempts(ctx context.Context, customerID string) (int64, error) {
+	attempts, err := incrementAttemptsScript.Run(ctx, p.db, []string{p.key(customerID)}).Int64()
+	if err != nil {
+		fmt.Printf("[DEBUG] : PHONE OTP REPO INCREMENT ATTEMPTS %#v \n", err)
+		return attempts, usecase_error.ErrInternalServerError
+	}
+	if attempts < 0 {
+		return attempts, usecase_error.ErrNotFound
+	}
+	return attempts, nil
+}
+
+func (p *phoneOTPRepo) Delete(ctx context.Context, customerID string) error {
+	if err := p.db.Del(ctx, p.key(customerID)).Err(); err != nil {
+		fmt.Printf("[DEBUG] : PHONE OTP REPO DELETE %#v \n", err)
+		return usecase_error.ErrInternalServerError
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/market-place/domain"
+	"github.com/market-place/usecase/repository"
+	"github.com/market-place/usecase/usecase_error"
+)
+
+// expire is only set by the first attempt, so wrong attempts can not extend the lock forever
+var incrementTwoFactorAttemptsScript = redis.NewScript(`
+local attempts = redis.call("INCR", KEYS[1])
+if attempts == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return attempts
+`)
+
+type twoFactorAttemptRepo struct {
+	db         *redis.Client
+	attemptKey string
+}
+
+func NewTwoFactorAttemptRepo(db *redis.Client) repository.TwoFactorAttemptRepository {
+	return &twoFactorAttemptRepo{
+		db:         db,
+		attemptKey: "two_factor_attempts",
+	}
+}
+
+func (t *twoFactorAttemptRepo) key(loginType string, userID string) string {
+	return fmt.Sprintf("%s:%s:%s", t.attemptKey, loginType, userID)
+}
+
+func (t *twoFactorAttemptRepo) Increment(ctx context.Context, loginType string, userID string) (int64, error) {
+	lockDuration := domain.TWO_FACTOR_LOCK_DURATION.Milliseconds()
+	attempts, err := incrementTwoFactorAttemptsScript.Run(ctx, t.db, []string{t.key(loginType, userID)}, lockDuration).Int64()
+	if err != nil {
+		fmt.Printf("[DEBUG] : TWO FACTOR ATTEMPT REPO INCREMENT %#v \n", err)
+		return attempts, usecase_error.ErrInternalServerError
+	}
+	return attempts, nil
+}
+
+func (t *twoFactorAttemptRepo) Reset(ctx context.Context, loginType string, userID string) error {
+	if err := t.db.Del(ctx, t.key(loginType, userID)).Err(); err != nil {
+		fmt.Printf("[DEBUG] : TWO FACTOR ATTEMPT REPO RESET %#v \n", err)
+		return usecase_error.ErrInternalServerError
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/market-place/domain"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session domain.Session) (domain.Session, error)
+	Fetch(ctx context.Context, cursor string, num int64, options domain.SessionSearchOptions) ([]domain.Session, error)
+	GetByID(ctx context.Context, id string) (domain.Session, error)
+	UpdateOne(ctx context.Context, session domain.Session) (domain.Session, error)
+	UpdateLastActivity(ctx context.Context, id string, lastActivityAt time.Time) error
+	RevokeAll(ctx context.Context, userID, loginType string, revokedAt time.Time) error
+}
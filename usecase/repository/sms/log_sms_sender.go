@@ -0,0 +1,22 @@
+package sms
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/market-place/usecase/repository"
+)
+
+type logSMSSender struct{}
+
+// NewLogSMSSender is local stub, message is written to stdout instead of sent to sms gateway
+func NewLogSMSSender() repository.SMSSender {
+	return &logSMSSender{}
+}
+
+func (l *logSMSSender) Send(ctx context.Context, phone, message string) error {
+	log.SetOutput(os.Stdout)
+	log.Printf("[SMS] : to %s : %s \n", phone, message)
+	return nil
+}
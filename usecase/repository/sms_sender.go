@@ -0,0 +1,9 @@
+package repository
+
+import (
+	"context"
+)
+
+type SMSSender interface {
+	Send(ctx context.Context, phone, message string) error
+}
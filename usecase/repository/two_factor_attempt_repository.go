@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+)
+
+type TwoFactorAttemptRepository interface {
+	// Increment return attempts after increment, counted atomically so parallel guess is also limited.
+	// Attempts are forgotten after domain.TWO_FACTOR_LOCK_DURATION since the first one
+	Increment(ctx context.Context, loginType string, userID string) (int64, error)
+	Reset(ctx context.Context, loginType string, userID string) error
+}
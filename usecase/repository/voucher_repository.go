@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/market-place/domain"
+)
+
+type VoucherRepository interface {
+	Create(ctx context.Context, voucher domain.Voucher) (domain.Voucher, error)
+	Fetch(ctx context.Context, options domain.VoucherSearchOptions) ([]domain.Voucher, error)
+	GetByID(ctx context.Context, id string) (domain.Voucher, error)
+	UpdateOne(ctx context.Context, voucher domain.Voucher) (domain.Voucher, error)
+	DeleteOne(ctx context.Context, voucher domain.Voucher) (domain.Voucher, error)
+	// Claim use one quota of voucher for customer in one atomic update,
+	// ErrNotFound when total quota or customer quota is used up
+	Claim(ctx context.Context, voucherID string, customerID string) error
+	// Release give back one quota used by customer
+	Release(ctx context.Context, voucherID string, customerID string) error
+}
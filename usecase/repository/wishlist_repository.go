@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/market-place/domain"
+)
+
+type WishlistRepository interface {
+	Create(ctx context.Context, item domain.WishlistItem) (domain.WishlistItem, error)
+	Fetch(ctx context.Context, cursor string, num int64, options domain.WishlistSearchOptions) ([]domain.WishlistItem, error)
+	DeleteOne(ctx context.Context, item domain.WishlistItem) (domain.WishlistItem, error)
+}